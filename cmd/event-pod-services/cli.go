@@ -3,6 +3,10 @@ package main
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli/v2"
@@ -14,6 +18,9 @@ import (
 	"github.com/multimarket-labs/event-pod-services/database"
 	"github.com/multimarket-labs/event-pod-services/elasticsearch"
 	"github.com/multimarket-labs/event-pod-services/services/api"
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+	"github.com/multimarket-labs/event-pod-services/services/api/service"
+	"github.com/multimarket-labs/event-pod-services/services/api/validator"
 	grpc "github.com/multimarket-labs/event-pod-services/services/gprc"
 )
 
@@ -31,16 +38,188 @@ var (
 		Usage:   "path to migrations folder",
 		EnvVars: []string{"PHOENIX_SERVICES_MIGRATIONS_DIR"},
 	}
+	ImportFileFlag = &cli.StringFlag{
+		Name:     "file",
+		Aliases:  []string{"f"},
+		Usage:    "path to a JSONL or CSV file of create event requests",
+		Required: true,
+	}
+	ImportFormatFlag = &cli.StringFlag{
+		Name:  "format",
+		Usage: "file format: jsonl or csv (default: by file extension)",
+	}
+	ImportModeFlag = &cli.StringFlag{
+		Name:  "mode",
+		Value: models.ImportModeAllOrNothing,
+		Usage: "import mode: all_or_nothing or best_effort",
+	}
+	ImportDryRunFlag = &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "validate every line without writing anything",
+	}
+	MigrateToFlag = &cli.StringFlag{
+		Name:  "to",
+		Usage: "target migration version (up/baseline: up to and including it; down: revert everything after it, 0 reverts all)",
+	}
+	MigrateDryRunFlag = &cli.BoolFlag{
+		Name:  "dry-run",
+		Usage: "print the migrations that would run without executing them",
+	}
 )
 
+// openMigrator 连接数据库并创建迁移器；迁移目录优先使用 --migrations-dir，未指定时使用配置文件中的 migrations
+func openMigrator(ctx *cli.Context) (*database.Migrator, func(), error) {
+	cfg, err := config.New(ctx.String(ConfigFlag.Name))
+	if err != nil {
+		log.Error("failed to load config", "err", err)
+		return nil, nil, err
+	}
+	dir := cfg.Migrations
+	if ctx.IsSet(MigrationsFlag.Name) || dir == "" {
+		dir = ctx.String(MigrationsFlag.Name)
+	}
+	db, err := database.NewDB(ctx.Context, cfg.MasterDB)
+	if err != nil {
+		log.Error("failed to connect to database", "err", err)
+		return nil, nil, err
+	}
+	closeDB := func() {
+		if err := db.Close(); err != nil {
+			log.Error("fail to close database", "err", err)
+		}
+	}
+	return database.NewMigrator(db, dir), closeDB, nil
+}
+
+// runMigrations 执行未执行的迁移，--to 指定时只执行到该版本
 func runMigrations(ctx *cli.Context) error {
 	ctx.Context = opio.CancelOnInterrupt(ctx.Context)
 	log.Info("running migrations...")
+	migrator, closeDB, err := openMigrator(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	dryRun := ctx.Bool(MigrateDryRunFlag.Name)
+	applied, err := migrator.Up(ctx.Context, ctx.String(MigrateToFlag.Name), dryRun)
+	for _, migration := range applied {
+		printMigration("up", migration, dryRun)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("dry_run=%t applied=%d\n", dryRun, len(applied))
+	return nil
+}
+
+// runMigrationsDown 回滚最近的一个迁移，--to 指定时回滚该版本之后的全部迁移
+func runMigrationsDown(ctx *cli.Context) error {
+	ctx.Context = opio.CancelOnInterrupt(ctx.Context)
+	log.Info("rolling back migrations...")
+	migrator, closeDB, err := openMigrator(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	dryRun := ctx.Bool(MigrateDryRunFlag.Name)
+	reverted, err := migrator.Down(ctx.Context, ctx.String(MigrateToFlag.Name), dryRun)
+	for _, migration := range reverted {
+		printMigration("down", migration, dryRun)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("dry_run=%t reverted=%d\n", dryRun, len(reverted))
+	return nil
+}
+
+// runMigrationsBaseline 将已执行过的迁移记录到 schema_migrations 而不执行，--to 指定时只记录到该版本
+func runMigrationsBaseline(ctx *cli.Context) error {
+	ctx.Context = opio.CancelOnInterrupt(ctx.Context)
+	log.Info("recording migration baseline...")
+	migrator, closeDB, err := openMigrator(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	dryRun := ctx.Bool(MigrateDryRunFlag.Name)
+	recorded, err := migrator.Baseline(ctx.Context, ctx.String(MigrateToFlag.Name), dryRun)
+	for _, migration := range recorded {
+		printMigration("baseline", migration, dryRun)
+	}
+	if err != nil {
+		return err
+	}
+	fmt.Printf("dry_run=%t recorded=%d\n", dryRun, len(recorded))
+	return nil
+}
+
+// runMigrationsStatus 列出每个迁移版本的执行状态
+func runMigrationsStatus(ctx *cli.Context) error {
+	migrator, closeDB, err := openMigrator(ctx)
+	if err != nil {
+		return err
+	}
+	defer closeDB()
+
+	statuses, err := migrator.Status(ctx.Context)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("%-16s %-9s %-20s %s\n", "VERSION", "STATE", "APPLIED AT", "NAME")
+	for _, status := range statuses {
+		appliedAt := "-"
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format(time.DateTime)
+		}
+		fmt.Printf("%-16s %-9s %-20s %s\n", status.Version, status.State, appliedAt, status.Name)
+	}
+	return nil
+}
+
+// printMigration 输出一个已执行（或 dry-run 时将执行）的迁移
+func printMigration(direction string, migration database.Migration, dryRun bool) {
+	action := direction
+	if dryRun {
+		action = "would " + direction
+	}
+	if migration.Name == "" {
+		fmt.Printf("%s %s\n", action, migration.Version)
+		return
+	}
+	fmt.Printf("%s %s %s\n", action, migration.Version, migration.Name)
+}
+
+func runEventsImport(ctx *cli.Context) error {
 	cfg, err := config.New(ctx.String(ConfigFlag.Name))
 	if err != nil {
 		log.Error("failed to load config", "err", err)
 		return err
 	}
+
+	path := ctx.String(ImportFileFlag.Name)
+	format := ctx.String(ImportFormatFlag.Name)
+	if format == "" {
+		format = models.ImportFormatJSONL
+		if strings.EqualFold(filepath.Ext(path), ".csv") {
+			format = models.ImportFormatCSV
+		}
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open import file: %w", err)
+	}
+	defer file.Close()
+
+	lines, err := service.ParseEventImport(file, format, 0)
+	if err != nil {
+		return err
+	}
+
 	db, err := database.NewDB(ctx.Context, cfg.MasterDB)
 	if err != nil {
 		log.Error("failed to connect to database", "err", err)
@@ -52,7 +231,34 @@ func runMigrations(ctx *cli.Context) error {
 			log.Error("fail to close database", "err", err)
 		}
 	}(db)
-	return db.ExecuteSQLMigration(cfg.Migrations)
+
+	svc := service.New(new(validator.Validator), db, nil, nil, nil, nil, nil, cfg.JWTSecret, cfg.Domain)
+	report, err := svc.ImportEvents(&models.ImportEventsRequest{
+		Mode:   ctx.String(ImportModeFlag.Name),
+		DryRun: ctx.Bool(ImportDryRunFlag.Name),
+		Lines:  lines,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, result := range report.Results {
+		switch {
+		case result.EventGUID != "":
+			fmt.Printf("line %d: %s %s\n", result.Line, result.Status, result.EventGUID)
+		case result.Error != "":
+			fmt.Printf("line %d: %s: %s\n", result.Line, result.Status, result.Error)
+		default:
+			fmt.Printf("line %d: %s\n", result.Line, result.Status)
+		}
+	}
+	fmt.Printf("mode=%s dry_run=%t committed=%t total=%d succeeded=%d failed=%d\n",
+		report.Mode, report.DryRun, report.Committed, report.Total, report.Succeeded, report.Failed)
+
+	if report.Failed > 0 {
+		return fmt.Errorf("%d of %d events failed to import", report.Failed, report.Total)
+	}
+	return nil
 }
 
 func runEventPool(ctx *cli.Context, shutdown context.CancelCauseFunc) (cliapp.Lifecycle, error) {
@@ -110,6 +316,7 @@ func runRpc(ctx *cli.Context, _ context.CancelCauseFunc) (cliapp.Lifecycle, erro
 func NewCli() *cli.App {
 	flags := []cli.Flag{ConfigFlag}
 	migrationFlags := []cli.Flag{MigrationsFlag, ConfigFlag}
+	migrateRunFlags := []cli.Flag{MigrationsFlag, ConfigFlag, MigrateToFlag, MigrateDryRunFlag}
 	return &cli.App{
 		Version:              "0.0.1",
 		Description:          "An Services For Phoenix Protocol",
@@ -135,9 +342,47 @@ func NewCli() *cli.App {
 			},
 			{
 				Name:        "migrate",
-				Flags:       migrationFlags,
-				Description: "Run event database migrations",
+				Flags:       migrateRunFlags,
+				Description: "Run event database migrations (same as migrate up)",
 				Action:      runMigrations,
+				Subcommands: []*cli.Command{
+					{
+						Name:        "up",
+						Flags:       migrateRunFlags,
+						Description: "Apply pending migrations in version order",
+						Action:      runMigrations,
+					},
+					{
+						Name:        "down",
+						Flags:       migrateRunFlags,
+						Description: "Revert the latest migration, or every migration after --to",
+						Action:      runMigrationsDown,
+					},
+					{
+						Name:        "baseline",
+						Flags:       migrateRunFlags,
+						Description: "Record pending migrations up to --to as applied without running them, for databases migrated before schema_migrations existed",
+						Action:      runMigrationsBaseline,
+					},
+					{
+						Name:        "status",
+						Flags:       migrationFlags,
+						Description: "Show applied, pending and modified migrations",
+						Action:      runMigrationsStatus,
+					},
+				},
+			},
+			{
+				Name:        "events",
+				Description: "Manage events",
+				Subcommands: []*cli.Command{
+					{
+						Name:        "import",
+						Flags:       []cli.Flag{ConfigFlag, ImportFileFlag, ImportFormatFlag, ImportModeFlag, ImportDryRunFlag},
+						Description: "Import events from a JSONL or CSV file",
+						Action:      runEventsImport,
+					},
+				},
 			},
 			{
 				Name:        "version",
@@ -0,0 +1,247 @@
+package decimal
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Precision 小数位数，与数据库 NUMERIC(38,18) 一致
+const Precision = 18
+
+var scale = new(big.Int).Exp(big.NewInt(10), big.NewInt(Precision), nil)
+
+// Decimal 定点小数：以 10^-18 为最小单位的整数表示，零值为 0
+// 用于金额、份额等需要精确记账的数值，运算不产生浮点误差
+type Decimal struct {
+	i *big.Int
+}
+
+// Zero 返回 0
+func Zero() Decimal {
+	return Decimal{}
+}
+
+// New 由整数创建
+func New(v int64) Decimal {
+	return Decimal{i: new(big.Int).Mul(big.NewInt(v), scale)}
+}
+
+// Parse 解析十进制字符串，例如 "12.5"、"-0.000001"，小数位不能超过 Precision
+func Parse(s string) (Decimal, error) {
+	str := strings.TrimSpace(s)
+	if str == "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+
+	negative := false
+	switch str[0] {
+	case '-':
+		negative = true
+		str = str[1:]
+	case '+':
+		str = str[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(str, ".")
+	if intPart == "" && fracPart == "" {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	if len(fracPart) > Precision {
+		return Decimal{}, fmt.Errorf("decimal %q has more than %d fractional digits", s, Precision)
+	}
+	digits := intPart + fracPart + strings.Repeat("0", Precision-len(fracPart))
+	for _, c := range digits {
+		if c < '0' || c > '9' {
+			return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+		}
+	}
+
+	i, ok := new(big.Int).SetString(digits, 10)
+	if !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal %q", s)
+	}
+	if negative {
+		i.Neg(i)
+	}
+	return Decimal{i: i}, nil
+}
+
+// MustParse 解析十进制字符串，失败时 panic，仅用于常量
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return d
+}
+
+// FromFloat 由浮点数创建，超出 Precision 的小数位截断
+func FromFloat(f float64) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, fmt.Errorf("invalid decimal %v", f)
+	}
+	return Parse(strconv.FormatFloat(f, 'f', Precision, 64))
+}
+
+func (d Decimal) int() *big.Int {
+	if d.i == nil {
+		return new(big.Int)
+	}
+	return d.i
+}
+
+// Add 返回 d + o
+func (d Decimal) Add(o Decimal) Decimal {
+	return Decimal{i: new(big.Int).Add(d.int(), o.int())}
+}
+
+// Sub 返回 d - o
+func (d Decimal) Sub(o Decimal) Decimal {
+	return Decimal{i: new(big.Int).Sub(d.int(), o.int())}
+}
+
+// Mul 返回 d × o，超出 Precision 的小数位向零截断
+func (d Decimal) Mul(o Decimal) Decimal {
+	product := new(big.Int).Mul(d.int(), o.int())
+	return Decimal{i: product.Quo(product, scale)}
+}
+
+// Div 返回 d ÷ o，超出 Precision 的小数位向零截断；o 为 0 时 panic
+func (d Decimal) Div(o Decimal) Decimal {
+	numerator := new(big.Int).Mul(d.int(), scale)
+	return Decimal{i: numerator.Quo(numerator, o.int())}
+}
+
+// Neg 返回 -d
+func (d Decimal) Neg() Decimal {
+	return Decimal{i: new(big.Int).Neg(d.int())}
+}
+
+// Abs 返回 |d|
+func (d Decimal) Abs() Decimal {
+	return Decimal{i: new(big.Int).Abs(d.int())}
+}
+
+// Cmp 比较 d 与 o：d < o 返回 -1，相等返回 0，d > o 返回 1
+func (d Decimal) Cmp(o Decimal) int {
+	return d.int().Cmp(o.int())
+}
+
+// Sign d < 0 返回 -1，d == 0 返回 0，d > 0 返回 1
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// IsZero 是否为 0
+func (d Decimal) IsZero() bool {
+	return d.Sign() == 0
+}
+
+// RoundUp 保留 places 位小数，向正无穷取整
+func (d Decimal) RoundUp(places int) Decimal {
+	return d.round(places, true)
+}
+
+// RoundDown 保留 places 位小数，向负无穷取整
+func (d Decimal) RoundDown(places int) Decimal {
+	return d.round(places, false)
+}
+
+func (d Decimal) round(places int, up bool) Decimal {
+	if places >= Precision {
+		return d
+	}
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(Precision-max(places, 0))), nil)
+	// Div 向负无穷取整，Mod 结果非负
+	q, m := new(big.Int).DivMod(d.int(), unit, new(big.Int))
+	if up && m.Sign() != 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	return Decimal{i: q.Mul(q, unit)}
+}
+
+// Float64 转换为浮点数，可能损失精度，仅用于定价等近似计算
+func (d Decimal) Float64() float64 {
+	f, _ := new(big.Rat).SetFrac(d.int(), scale).Float64()
+	return f
+}
+
+// String 十进制字符串，去掉小数末尾的 0
+func (d Decimal) String() string {
+	i := d.int()
+	abs := new(big.Int).Abs(i).String()
+	if len(abs) <= Precision {
+		abs = strings.Repeat("0", Precision-len(abs)+1) + abs
+	}
+	intPart, fracPart := abs[:len(abs)-Precision], strings.TrimRight(abs[len(abs)-Precision:], "0")
+
+	s := intPart
+	if fracPart != "" {
+		s += "." + fracPart
+	}
+	if i.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// Value 实现 driver.Valuer，写入 NUMERIC 列
+func (d Decimal) Value() (driver.Value, error) {
+	return d.String(), nil
+}
+
+// Scan 实现 sql.Scanner，读取 NUMERIC 列
+func (d *Decimal) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*d = Decimal{}
+		return nil
+	case string:
+		return d.scanString(v)
+	case []byte:
+		return d.scanString(string(v))
+	case int64:
+		*d = New(v)
+		return nil
+	case float64:
+		parsed, err := FromFloat(v)
+		if err != nil {
+			return err
+		}
+		*d = parsed
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into Decimal", value)
+	}
+}
+
+func (d *Decimal) scanString(s string) error {
+	parsed, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// MarshalJSON 编码为 JSON 字符串，避免客户端按浮点数解析丢失精度
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+// UnmarshalJSON 接受 JSON 字符串或数字
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	s := string(data)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	return d.scanString(s)
+}
@@ -0,0 +1,100 @@
+package decimal
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAndString(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"0", "0"},
+		{"1", "1"},
+		{"-1", "-1"},
+		{"+2.50", "2.5"},
+		{"0.000000000000000001", "0.000000000000000001"},
+		{"-0.1", "-0.1"},
+		{".5", "0.5"},
+		{"7.", "7"},
+		{"123456789012345678901.123456789012345678", "123456789012345678901.123456789012345678"},
+		{"-0", "0"},
+	}
+	for _, tt := range tests {
+		d, err := Parse(tt.in)
+		require.NoError(t, err, tt.in)
+		require.Equal(t, tt.want, d.String(), tt.in)
+	}
+}
+
+func TestParseRejectsInvalid(t *testing.T) {
+	for _, in := range []string{"", "-", ".", "abc", "1.2.3", "1e5", "--1", "0.0000000000000000001"} {
+		_, err := Parse(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestArithmetic(t *testing.T) {
+	a := MustParse("0.1")
+	b := MustParse("0.2")
+	require.Equal(t, "0.3", a.Add(b).String())
+	require.Equal(t, "-0.1", a.Sub(b).String())
+	require.Equal(t, "0.02", a.Mul(b).String())
+	require.Equal(t, "0.5", a.Div(b).String())
+	require.Equal(t, "0.333333333333333333", New(1).Div(New(3)).String())
+	require.Equal(t, "-0.333333333333333333", New(-1).Div(New(3)).String())
+	require.Equal(t, "0.1", a.Neg().Abs().String())
+	require.Equal(t, -1, a.Cmp(b))
+	require.Equal(t, 0, a.Add(a).Cmp(b))
+	require.True(t, Zero().IsZero())
+	require.True(t, Decimal{}.Add(a).Sub(a).IsZero())
+}
+
+func TestRounding(t *testing.T) {
+	d := MustParse("1.234561")
+	require.Equal(t, "1.23457", d.RoundUp(5).String())
+	require.Equal(t, "1.23456", d.RoundDown(5).String())
+	require.Equal(t, "-1.23456", d.Neg().RoundUp(5).String())
+	require.Equal(t, "-1.23457", d.Neg().RoundDown(5).String())
+	require.Equal(t, "2", d.RoundUp(0).String())
+	require.Equal(t, "1.2", MustParse("1.2").RoundUp(6).String())
+}
+
+func TestFromFloat(t *testing.T) {
+	d, err := FromFloat(0.5)
+	require.NoError(t, err)
+	require.Equal(t, "0.5", d.String())
+	require.InDelta(t, 12.345, MustParse("12.345").Float64(), 1e-12)
+}
+
+func TestScanAndValue(t *testing.T) {
+	var d Decimal
+	require.NoError(t, d.Scan([]byte("10.250000000000000000")))
+	require.Equal(t, "10.25", d.String())
+	require.NoError(t, d.Scan(int64(3)))
+	require.Equal(t, "3", d.String())
+	require.NoError(t, d.Scan(nil))
+	require.True(t, d.IsZero())
+	require.Error(t, d.Scan(true))
+
+	v, err := MustParse("-1.5").Value()
+	require.NoError(t, err)
+	require.Equal(t, "-1.5", v)
+}
+
+func TestJSON(t *testing.T) {
+	var v struct {
+		A Decimal `json:"a"`
+		B Decimal `json:"b"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(`{"a":"1.25","b":2.5}`), &v))
+	require.Equal(t, "1.25", v.A.String())
+	require.Equal(t, "2.5", v.B.String())
+
+	out, err := json.Marshal(v)
+	require.NoError(t, err)
+	require.JSONEq(t, `{"a":"1.25","b":"2.5"}`, string(out))
+}
@@ -30,6 +30,9 @@ type Config struct {
 	RedisConfig               RedisConfig  `yaml:"redis"`
 	CORSAllowedOrigins        string       `yaml:"cors_allowed_origins"`
 	JWTSecret                 string       `yaml:"jwt_secret"`
+	ScoreFeedToken            string       `yaml:"score_feed_token"`           // 比分源推送比分使用的 Bearer Token，为空时关闭推送接口
+	SoftDeleteRetentionDays   int          `yaml:"soft_delete_retention_days"` // 软删除记录的保留天数，超过后由清理任务硬删除，不能再恢复；0 表示不清理
+	IdempotencyKeyTTLHours    int          `yaml:"idempotency_key_ttl_hours"`  // 幂等键的保留小时数，超过后清理，相同的键会被当作新请求；0 表示不清理
 	Domain                    string       `yaml:"domain"`
 	PrivateKey                string       `yaml:"private_key"`
 	NumConfirmations          uint64       `yaml:"num_confirmations"`
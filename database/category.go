@@ -1,13 +1,21 @@
 package database
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // Languages 支持的语言表
 type Languages struct {
-	GUID         string    `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
-	LanguageName string    `gorm:"type:varchar;default:'zh'" json:"language_name"`
-	CreatedAt    time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt    time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+	GUID          string         `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
+	LanguageName  string         `gorm:"type:varchar(20);not null" json:"language_name"`        // 语言代码（如 zh、en、zh-tw），与 Accept-Language 匹配时不区分大小写
+	LanguageLabel string         `gorm:"type:varchar(50)" json:"language_label"`                // 语言显示名称（如 中文、English）
+	IsDefault     bool           `gorm:"type:boolean;not null;default:false" json:"is_default"` // 是否为默认语言，同一时刻只有一个
+	IsActive      bool           `gorm:"type:boolean;not null;default:true" json:"is_active"`
+	CreatedAt     time.Time      `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt     time.Time      `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt     gorm.DeletedAt `gorm:"type:timestamp(0)" json:"deleted_at"` // 软删除时间，NULL 表示未删除
 }
 
 func (Languages) TableName() string {
@@ -16,10 +24,14 @@ func (Languages) TableName() string {
 
 // Category 事件分类表
 type Category struct {
-	GUID      string    `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
-	IsActive  bool      `gorm:"type:boolean;not null;default:true" json:"is_active"`
-	CreatedAt time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+	GUID      string         `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
+	Code      *string        `gorm:"type:varchar(64)" json:"code"`                      // 业务编码（稳定标识），未设置时为 NULL
+	SortOrder int32          `gorm:"type:integer;not null;default:0" json:"sort_order"` // 同级分类内的排序，越小越靠前
+	IsActive  bool           `gorm:"type:boolean;not null;default:true" json:"is_active"`
+	Remark    *string        `gorm:"type:varchar(200)" json:"remark"` // 运营备注，仅后台可见
+	CreatedAt time.Time      `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"type:timestamp(0)" json:"deleted_at"` // 软删除时间，NULL 表示未删除
 }
 
 func (Category) TableName() string {
@@ -45,12 +57,17 @@ func (CategoryLanguage) TableName() string {
 
 // Ecosystem 所属生态
 type Ecosystem struct {
-	GUID         string    `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
-	CategoryGUID string    `gorm:"type:varchar(255);not null" json:"category_guid"`
-	EventNum     string    `gorm:"type:numeric;not null" json:"event_num"` // UINT256 mapped to string for large numbers
-	IsActive     bool      `gorm:"type:boolean;not null;default:true" json:"is_active"`
-	CreatedAt    time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt    time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+	GUID         string         `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
+	CategoryGUID string         `gorm:"type:varchar(255);not null" json:"category_guid"`
+	EventNum     string         `gorm:"type:numeric;not null;default:0" json:"event_num"`  // UINT256 mapped to string for large numbers; 随事件创建、移动、删除在同一事务中维护
+	Code         *string        `gorm:"type:varchar(64)" json:"code"`                      // 业务编码（稳定标识），未设置时为 NULL
+	SortOrder    int32          `gorm:"type:integer;not null;default:0" json:"sort_order"` // 排序，越小越靠前
+	IsActive     bool           `gorm:"type:boolean;not null;default:true" json:"is_active"`
+	Remark       *string        `gorm:"type:varchar(200)" json:"remark"` // 运营备注，仅后台可见
+	Extra        JSONB          `gorm:"type:jsonb" json:"extra"`         // 扩展字段：临时配置/个性化属性
+	CreatedAt    time.Time      `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt    time.Time      `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"type:timestamp(0)" json:"deleted_at"` // 软删除时间，NULL 表示未删除
 }
 
 func (Ecosystem) TableName() string {
@@ -74,11 +91,15 @@ func (EcosystemLanguage) TableName() string {
 
 // EventPeriod 时间标签表
 type EventPeriod struct {
-	GUID         string    `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
-	CategoryGUID string    `gorm:"type:varchar(255);not null" json:"category_guid"`
-	IsActive     bool      `gorm:"type:boolean;not null;default:true" json:"is_active"`
-	CreatedAt    time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt    time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+	GUID         string         `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
+	CategoryGUID string         `gorm:"type:varchar(255);not null" json:"category_guid"`
+	Code         *string        `gorm:"type:varchar(64)" json:"code"` // 业务编码（稳定标识），未设置时为 NULL
+	IsActive     bool           `gorm:"type:boolean;not null;default:true" json:"is_active"`
+	Remark       *string        `gorm:"type:varchar(200)" json:"remark"` // 运营备注，仅后台可见
+	Extra        JSONB          `gorm:"type:jsonb" json:"extra"`         // 扩展字段：临时配置/个性化属性
+	CreatedAt    time.Time      `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt    time.Time      `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `gorm:"type:timestamp(0)" json:"deleted_at"` // 软删除时间，NULL 表示未删除
 }
 
 func (EventPeriod) TableName() string {
@@ -91,6 +112,7 @@ type EventPeriodLanguage struct {
 	EventPeriodGUID string    `gorm:"type:varchar(255);not null" json:"event_period_guid"`
 	LanguageGUID    string    `gorm:"type:varchar(255);not null" json:"language_guid"`
 	Name            string    `gorm:"type:varchar(50);not null" json:"name"`
+	Description     string    `gorm:"type:varchar(200);not null" json:"description"`
 	CreatedAt       time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt       time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
 }
@@ -101,10 +123,11 @@ func (EventPeriodLanguage) TableName() string {
 
 // TeamGroup 运动类团队
 type TeamGroup struct {
-	GUID      string    `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
-	Logo      string    `gorm:"type:varchar(255);not null" json:"logo"`
-	CreatedAt time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+	GUID      string         `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
+	Logo      string         `gorm:"type:varchar(255);not null" json:"logo"`
+	CreatedAt time.Time      `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt time.Time      `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt gorm.DeletedAt `gorm:"type:timestamp(0)" json:"deleted_at"` // 软删除时间，NULL 表示未删除
 }
 
 func (TeamGroup) TableName() string {
@@ -114,6 +137,7 @@ func (TeamGroup) TableName() string {
 // TeamGroupLanguage 运动类团队多语言表
 type TeamGroupLanguage struct {
 	GUID          string    `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
+	LanguageGUID  string    `gorm:"type:varchar(255);not null" json:"language_guid"`
 	TeamGroupGUID string    `gorm:"type:varchar(255);not null" json:"team_group_guid"`
 	Name          string    `gorm:"type:varchar(255);not null" json:"name"`
 	CreatedAt     time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
@@ -0,0 +1,271 @@
+package database
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// categoryTreeLockKey 修改分类层级时使用的事务级咨询锁
+const categoryTreeLockKey = "category_tree"
+
+// CategoryNode 分类在层级中的位置，来自 category_language（同一分类的各语言记录保持一致）
+type CategoryNode struct {
+	CategoryGUID       string `json:"category_guid"`
+	ParentCategoryGUID string `json:"parent_category_guid"`
+	Level              int16  `json:"level"`
+}
+
+// CategoryUsage 引用某分类的记录数量
+type CategoryUsage struct {
+	Events       int64 `json:"events"`
+	Ecosystems   int64 `json:"ecosystems"`
+	EventPeriods int64 `json:"event_periods"`
+}
+
+// InUse 是否仍被事件、生态或时间标签引用
+func (u CategoryUsage) InUse() bool {
+	return u.Events > 0 || u.Ecosystems > 0 || u.EventPeriods > 0
+}
+
+// CategoryRepository 分类及其多语言信息的数据库操作接口
+type CategoryRepository interface {
+	// LockCategoryTree 获取分类层级的事务级锁，串行化新建、移动、排序和删除
+	LockCategoryTree(db *gorm.DB) error
+	// CreateCategory 创建分类
+	CreateCategory(db *gorm.DB, category *Category) error
+	// GetCategory 获取分类，不存在时返回 nil
+	GetCategory(db *gorm.DB, categoryGUID string) (*Category, error)
+	// LockCategory 加行锁获取分类，不存在时返回 nil
+	LockCategory(db *gorm.DB, categoryGUID string) (*Category, error)
+	// GetCategoryByCode 按业务编码获取分类，不存在时返回 nil
+	GetCategoryByCode(db *gorm.DB, code string) (*Category, error)
+	// ListCategories 获取全部分类（包含停用的分类）
+	ListCategories(db *gorm.DB) ([]Category, error)
+	// UpdateCategory 更新分类字段并刷新 updated_at
+	UpdateCategory(db *gorm.DB, categoryGUID string, columns map[string]interface{}) error
+	// DeleteCategory 软删除分类，保留多语言信息以便恢复
+	DeleteCategory(db *gorm.DB, categoryGUID string) error
+	// LockDeletedCategory 加行锁获取已软删除的分类，不存在或未删除时返回 nil
+	LockDeletedCategory(db *gorm.DB, categoryGUID string) (*Category, error)
+	// RestoreCategory 恢复已软删除的分类并刷新 updated_at
+	RestoreCategory(db *gorm.DB, categoryGUID string) error
+	// GetCategoryNodes 获取全部未删除分类的父分类和层级
+	GetCategoryNodes(db *gorm.DB) ([]CategoryNode, error)
+	// MoveCategory 修改分类全部语言记录的父分类和层级
+	MoveCategory(db *gorm.DB, categoryGUID, parentCategoryGUID string, level int16) error
+	// NextCategorySortOrder 返回父分类下新分类的排序值（排在同级末尾）
+	NextCategorySortOrder(db *gorm.DB, parentCategoryGUID string) (int32, error)
+	// GetCategoryLanguages 获取分类的多语言信息，languageGUIDs 为空时返回全部语言
+	GetCategoryLanguages(db *gorm.DB, categoryGUIDs, languageGUIDs []string) ([]CategoryLanguage, error)
+	// UpsertCategoryLanguage 创建或更新分类在某语言下的名称和描述
+	UpsertCategoryLanguage(db *gorm.DB, categoryLang *CategoryLanguage) error
+	// DeleteCategoryLanguages 删除分类在指定语言下的多语言信息
+	DeleteCategoryLanguages(db *gorm.DB, categoryGUID string, languageGUIDs []string) error
+	// GetCategoryUsage 统计引用该分类的事件、生态和时间标签数量
+	GetCategoryUsage(db *gorm.DB, categoryGUID string) (*CategoryUsage, error)
+}
+
+type categoryRepository struct{}
+
+// NewCategoryRepository 创建分类仓储实例
+func NewCategoryRepository() CategoryRepository {
+	return &categoryRepository{}
+}
+
+// LockCategoryTree 获取分类层级的事务级咨询锁，事务结束时自动释放
+func (r *categoryRepository) LockCategoryTree(db *gorm.DB) error {
+	if err := db.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", categoryTreeLockKey).Error; err != nil {
+		return fmt.Errorf("failed to lock category tree: %w", err)
+	}
+	return nil
+}
+
+// CreateCategory 创建分类，GUID 通过 RETURNING 回填
+func (r *categoryRepository) CreateCategory(db *gorm.DB, category *Category) error {
+	if err := db.Clauses(clause.Returning{}).Create(category).Error; err != nil {
+		return fmt.Errorf("failed to create category: %w", err)
+	}
+	return nil
+}
+
+// GetCategory 获取分类，不存在时返回 nil
+func (r *categoryRepository) GetCategory(db *gorm.DB, categoryGUID string) (*Category, error) {
+	var categories []Category
+	if err := db.Where("guid = ?", categoryGUID).Limit(1).Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	if len(categories) == 0 {
+		return nil, nil
+	}
+	return &categories[0], nil
+}
+
+// LockCategory 加行锁获取分类，不存在时返回 nil
+func (r *categoryRepository) LockCategory(db *gorm.DB, categoryGUID string) (*Category, error) {
+	return r.GetCategory(db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}), categoryGUID)
+}
+
+// GetCategoryByCode 按业务编码获取分类，不存在时返回 nil
+func (r *categoryRepository) GetCategoryByCode(db *gorm.DB, code string) (*Category, error) {
+	var categories []Category
+	if err := db.Where("code = ?", code).Limit(1).Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to get category by code: %w", err)
+	}
+	if len(categories) == 0 {
+		return nil, nil
+	}
+	return &categories[0], nil
+}
+
+// ListCategories 获取全部分类（包含停用的分类），按排序值、创建时间升序
+func (r *categoryRepository) ListCategories(db *gorm.DB) ([]Category, error) {
+	var categories []Category
+	if err := db.Order("sort_order ASC, created_at ASC, guid ASC").Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	return categories, nil
+}
+
+// UpdateCategory 更新分类字段并刷新 updated_at
+func (r *categoryRepository) UpdateCategory(db *gorm.DB, categoryGUID string, columns map[string]interface{}) error {
+	updates := make(map[string]interface{}, len(columns)+1)
+	for column, value := range columns {
+		updates[column] = value
+	}
+	updates["updated_at"] = gorm.Expr("CURRENT_TIMESTAMP")
+
+	if err := db.Model(&Category{}).Where("guid = ?", categoryGUID).UpdateColumns(updates).Error; err != nil {
+		return fmt.Errorf("failed to update category: %w", err)
+	}
+	return nil
+}
+
+// DeleteCategory 软删除分类，多语言信息保留到清理任务硬删除时一并删除
+func (r *categoryRepository) DeleteCategory(db *gorm.DB, categoryGUID string) error {
+	if err := softDelete[Category](db, categoryGUID); err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+	return nil
+}
+
+// LockDeletedCategory 加行锁获取已软删除的分类，不存在或未删除时返回 nil
+func (r *categoryRepository) LockDeletedCategory(db *gorm.DB, categoryGUID string) (*Category, error) {
+	deleted, err := lockDeleted[Category](db, categoryGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted category: %w", err)
+	}
+	return deleted, nil
+}
+
+// RestoreCategory 恢复已软删除的分类并刷新 updated_at
+func (r *categoryRepository) RestoreCategory(db *gorm.DB, categoryGUID string) error {
+	if err := restoreDeleted[Category](db, categoryGUID); err != nil {
+		return fmt.Errorf("failed to restore category: %w", err)
+	}
+	return nil
+}
+
+// GetCategoryNodes 获取全部未删除分类的父分类和层级，同一分类的多条语言记录取最近更新的一条
+func (r *categoryRepository) GetCategoryNodes(db *gorm.DB) ([]CategoryNode, error) {
+	var nodes []CategoryNode
+	err := db.Raw(`SELECT DISTINCT ON (category_guid) category_guid, parent_category_guid, level
+		FROM category_language
+		WHERE category_guid IN (SELECT guid FROM category WHERE deleted_at IS NULL)
+		ORDER BY category_guid, updated_at DESC NULLS LAST, guid DESC`).
+		Scan(&nodes).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category nodes: %w", err)
+	}
+	return nodes, nil
+}
+
+// MoveCategory 修改分类全部语言记录的父分类和层级
+func (r *categoryRepository) MoveCategory(db *gorm.DB, categoryGUID, parentCategoryGUID string, level int16) error {
+	err := db.Model(&CategoryLanguage{}).Where("category_guid = ?", categoryGUID).
+		UpdateColumns(map[string]interface{}{
+			"parent_category_guid": parentCategoryGUID,
+			"level":                level,
+			"updated_at":           gorm.Expr("CURRENT_TIMESTAMP"),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to move category: %w", err)
+	}
+	return nil
+}
+
+// NextCategorySortOrder 返回父分类下新分类的排序值（同级未删除分类的最大值 + 1，没有同级分类时为 0）
+func (r *categoryRepository) NextCategorySortOrder(db *gorm.DB, parentCategoryGUID string) (int32, error) {
+	var next int32
+	err := db.Raw(`SELECT COALESCE(MAX(c.sort_order) + 1, 0) FROM category c
+		WHERE c.deleted_at IS NULL AND c.guid IN (SELECT category_guid FROM category_language WHERE parent_category_guid = ?)`,
+		parentCategoryGUID).
+		Scan(&next).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to get next category sort order: %w", err)
+	}
+	return next, nil
+}
+
+// GetCategoryLanguages 获取分类的多语言信息，languageGUIDs 为空时返回全部语言
+func (r *categoryRepository) GetCategoryLanguages(db *gorm.DB, categoryGUIDs, languageGUIDs []string) ([]CategoryLanguage, error) {
+	if len(categoryGUIDs) == 0 {
+		return nil, nil
+	}
+	query := db.Where("category_guid IN ?", categoryGUIDs)
+	if len(languageGUIDs) > 0 {
+		query = query.Where("language_guid IN ?", languageGUIDs)
+	}
+	var categoryLangs []CategoryLanguage
+	if err := query.Order("category_guid ASC, language_guid ASC").Find(&categoryLangs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get category languages: %w", err)
+	}
+	return categoryLangs, nil
+}
+
+// UpsertCategoryLanguage 创建或更新分类在某语言下的信息（依赖 (category_guid, language_guid) 唯一索引）
+func (r *categoryRepository) UpsertCategoryLanguage(db *gorm.DB, categoryLang *CategoryLanguage) error {
+	err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "category_guid"}, {Name: "language_guid"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"name":                 gorm.Expr("excluded.name"),
+			"description":          gorm.Expr("excluded.description"),
+			"parent_category_guid": gorm.Expr("excluded.parent_category_guid"),
+			"level":                gorm.Expr("excluded.level"),
+			"updated_at":           gorm.Expr("CURRENT_TIMESTAMP"),
+		}),
+	}).Create(categoryLang).Error
+	if err != nil {
+		return fmt.Errorf("failed to upsert category language: %w", err)
+	}
+	return nil
+}
+
+// DeleteCategoryLanguages 删除分类在指定语言下的多语言信息
+func (r *categoryRepository) DeleteCategoryLanguages(db *gorm.DB, categoryGUID string, languageGUIDs []string) error {
+	if len(languageGUIDs) == 0 {
+		return nil
+	}
+	err := db.Where("category_guid = ? AND language_guid IN ?", categoryGUID, languageGUIDs).
+		Delete(&CategoryLanguage{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete category languages: %w", err)
+	}
+	return nil
+}
+
+// GetCategoryUsage 统计引用该分类的事件、生态和时间标签数量
+func (r *categoryRepository) GetCategoryUsage(db *gorm.DB, categoryGUID string) (*CategoryUsage, error) {
+	var usage CategoryUsage
+	if err := db.Model(&Event{}).Where("category_guid = ?", categoryGUID).Count(&usage.Events).Error; err != nil {
+		return nil, fmt.Errorf("failed to count category events: %w", err)
+	}
+	if err := db.Model(&Ecosystem{}).Where("category_guid = ?", categoryGUID).Count(&usage.Ecosystems).Error; err != nil {
+		return nil, fmt.Errorf("failed to count category ecosystems: %w", err)
+	}
+	if err := db.Model(&EventPeriod{}).Where("category_guid = ?", categoryGUID).Count(&usage.EventPeriods).Error; err != nil {
+		return nil, fmt.Errorf("failed to count category event periods: %w", err)
+	}
+	return &usage, nil
+}
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ChanceStatRepository 子事件概率统计数据库操作接口
+type ChanceStatRepository interface {
+	// ListTradingDirections 获取交易中（已上线未结束）事件下的全部方向
+	ListTradingDirections(db *gorm.DB) ([]SubEventDirection, error)
+	// UpsertChanceStats 写入概率快照，同一方向、周期和时间桶已有记录时覆盖为最新概率
+	UpsertChanceStats(db *gorm.DB, stats []SubEventChanceStat) error
+	// DeleteChanceStatsBefore 删除某统计周期中早于 before 的时间桶
+	DeleteChanceStatsBefore(db *gorm.DB, statWay int16, before time.Time) (int64, error)
+	// ListChanceStats 按时间顺序获取子事件某统计周期的快照，since 为 nil 时返回全部
+	ListChanceStats(db *gorm.DB, subEventGUID string, statWay int16, since *time.Time) ([]SubEventChanceStat, error)
+}
+
+type chanceStatRepository struct{}
+
+// NewChanceStatRepository 创建概率统计仓储实例
+func NewChanceStatRepository() ChanceStatRepository {
+	return &chanceStatRepository{}
+}
+
+// ListTradingDirections 获取交易中（已上线未结束）事件下的全部方向
+func (r *chanceStatRepository) ListTradingDirections(db *gorm.DB) ([]SubEventDirection, error) {
+	var directions []SubEventDirection
+	err := db.Model(&SubEventDirection{}).
+		Joins("JOIN sub_event ON sub_event.guid = sub_event_direction.sub_event_guid").
+		Joins("JOIN event ON event.guid = sub_event.parent_event_guid").
+		Where("event.status IN ? AND event.deleted_at IS NULL", []string{EventStatusUpcoming, EventStatusLive}).
+		Order("sub_event_direction.sub_event_guid ASC, sub_event_direction.guid ASC").
+		Find(&directions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list trading directions: %w", err)
+	}
+	return directions, nil
+}
+
+// UpsertChanceStats 写入概率快照，同一方向、周期和时间桶已有记录时覆盖为最新概率
+func (r *chanceStatRepository) UpsertChanceStats(db *gorm.DB, stats []SubEventChanceStat) error {
+	if len(stats) == 0 {
+		return nil
+	}
+	err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "direction_guid"}, {Name: "stat_way"}, {Name: "datetime"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"chance":     gorm.Expr("excluded.chance"),
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		}),
+	}).CreateInBatches(stats, 500).Error
+	if err != nil {
+		return fmt.Errorf("failed to upsert chance stats: %w", err)
+	}
+	return nil
+}
+
+// DeleteChanceStatsBefore 删除某统计周期中早于 before 的时间桶
+func (r *chanceStatRepository) DeleteChanceStatsBefore(db *gorm.DB, statWay int16, before time.Time) (int64, error) {
+	// datetime 以 RFC3339 (UTC) 字符串存储，按字典序比较即按时间比较
+	result := db.Where("stat_way = ? AND datetime < ?", statWay, before.UTC().Format(time.RFC3339)).
+		Delete(&SubEventChanceStat{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete chance stats: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// ListChanceStats 按时间顺序获取子事件某统计周期的快照，since 为 nil 时返回全部
+func (r *chanceStatRepository) ListChanceStats(db *gorm.DB, subEventGUID string, statWay int16, since *time.Time) ([]SubEventChanceStat, error) {
+	query := db.Where("sub_event_guid = ? AND stat_way = ?", subEventGUID, statWay)
+	if since != nil {
+		query = query.Where("datetime >= ?", since.UTC().Format(time.RFC3339))
+	}
+
+	var stats []SubEventChanceStat
+	if err := query.Order("datetime ASC").Find(&stats).Error; err != nil {
+		return nil, fmt.Errorf("failed to list chance stats: %w", err)
+	}
+	return stats, nil
+}
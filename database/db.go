@@ -3,12 +3,9 @@ package database
 import (
 	"context"
 	"fmt"
-	"os"
 
-	"github.com/pkg/errors"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"path/filepath"
 
 	"github.com/multimarket-labs/event-pod-services/common/retry"
 	"github.com/multimarket-labs/event-pod-services/config"
@@ -80,28 +77,6 @@ func (db *DB) Close() error {
 	return sql.Close()
 }
 
-func (db *DB) ExecuteSQLMigration(migrationsFolder string) error {
-	err := filepath.Walk(migrationsFolder, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return errors.Wrap(err, fmt.Sprintf("Failed to process migration file: %s", path))
-		}
-		if info.IsDir() {
-			return nil
-		}
-		fileContent, readErr := os.ReadFile(path)
-		if readErr != nil {
-			return errors.Wrap(readErr, fmt.Sprintf("Error reading SQL file: %s", path))
-		}
-
-		execErr := db.gorm.Exec(string(fileContent)).Error
-		if execErr != nil {
-			return errors.Wrap(execErr, fmt.Sprintf("Error executing SQL script: %s", path))
-		}
-		return nil
-	})
-	return err
-}
-
 // GetGorm returns the underlying gorm.DB instance
 // Use this when you need direct access to GORM for custom queries
 func (db *DB) GetGorm() *gorm.DB {
@@ -0,0 +1,206 @@
+package database
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EcosystemRepository 生态及其多语言信息的数据库操作接口
+type EcosystemRepository interface {
+	// CreateEcosystem 创建生态
+	CreateEcosystem(db *gorm.DB, ecosystem *Ecosystem) error
+	// GetEcosystem 获取生态，不存在时返回 nil
+	GetEcosystem(db *gorm.DB, ecosystemGUID string) (*Ecosystem, error)
+	// LockEcosystem 加行锁获取生态，不存在时返回 nil
+	LockEcosystem(db *gorm.DB, ecosystemGUID string) (*Ecosystem, error)
+	// GetEcosystemByCode 按业务编码获取生态，不存在时返回 nil
+	GetEcosystemByCode(db *gorm.DB, code string) (*Ecosystem, error)
+	// ListEcosystems 获取生态列表，categoryGUID 为空时返回全部分类下的生态
+	ListEcosystems(db *gorm.DB, categoryGUID string, activeOnly bool) ([]Ecosystem, error)
+	// UpdateEcosystem 更新生态字段并刷新 updated_at
+	UpdateEcosystem(db *gorm.DB, ecosystemGUID string, columns map[string]interface{}) error
+	// DeleteEcosystem 软删除生态，保留多语言信息以便恢复
+	DeleteEcosystem(db *gorm.DB, ecosystemGUID string) error
+	// LockDeletedEcosystem 加行锁获取已软删除的生态，不存在或未删除时返回 nil
+	LockDeletedEcosystem(db *gorm.DB, ecosystemGUID string) (*Ecosystem, error)
+	// RestoreEcosystem 恢复已软删除的生态并刷新 updated_at
+	RestoreEcosystem(db *gorm.DB, ecosystemGUID string) error
+	// AdjustEcosystemEventNum 调整生态的事件数，delta 为正时增加、为负时减少（不低于 0）
+	AdjustEcosystemEventNum(db *gorm.DB, ecosystemGUID string, delta int) error
+	// CountEcosystemEvents 统计引用该生态的事件数量
+	CountEcosystemEvents(db *gorm.DB, ecosystemGUID string) (int64, error)
+	// GetEcosystemLanguages 获取生态的多语言信息，languageGUIDs 为空时返回全部语言
+	GetEcosystemLanguages(db *gorm.DB, ecosystemGUIDs, languageGUIDs []string) ([]EcosystemLanguage, error)
+	// UpsertEcosystemLanguage 创建或更新生态在某语言下的名称和描述
+	UpsertEcosystemLanguage(db *gorm.DB, ecosystemLang *EcosystemLanguage) error
+	// DeleteEcosystemLanguages 删除生态在指定语言下的多语言信息
+	DeleteEcosystemLanguages(db *gorm.DB, ecosystemGUID string, languageGUIDs []string) error
+}
+
+type ecosystemRepository struct{}
+
+// NewEcosystemRepository 创建生态仓储实例
+func NewEcosystemRepository() EcosystemRepository {
+	return &ecosystemRepository{}
+}
+
+// CreateEcosystem 创建生态，GUID 通过 RETURNING 回填
+func (r *ecosystemRepository) CreateEcosystem(db *gorm.DB, ecosystem *Ecosystem) error {
+	if err := db.Clauses(clause.Returning{}).Create(ecosystem).Error; err != nil {
+		return fmt.Errorf("failed to create ecosystem: %w", err)
+	}
+	return nil
+}
+
+// GetEcosystem 获取生态，不存在时返回 nil
+func (r *ecosystemRepository) GetEcosystem(db *gorm.DB, ecosystemGUID string) (*Ecosystem, error) {
+	var ecosystems []Ecosystem
+	if err := db.Where("guid = ?", ecosystemGUID).Limit(1).Find(&ecosystems).Error; err != nil {
+		return nil, fmt.Errorf("failed to get ecosystem: %w", err)
+	}
+	if len(ecosystems) == 0 {
+		return nil, nil
+	}
+	return &ecosystems[0], nil
+}
+
+// LockEcosystem 加行锁获取生态，不存在时返回 nil
+func (r *ecosystemRepository) LockEcosystem(db *gorm.DB, ecosystemGUID string) (*Ecosystem, error) {
+	return r.GetEcosystem(db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}), ecosystemGUID)
+}
+
+// GetEcosystemByCode 按业务编码获取生态，不存在时返回 nil
+func (r *ecosystemRepository) GetEcosystemByCode(db *gorm.DB, code string) (*Ecosystem, error) {
+	var ecosystems []Ecosystem
+	if err := db.Where("code = ?", code).Limit(1).Find(&ecosystems).Error; err != nil {
+		return nil, fmt.Errorf("failed to get ecosystem by code: %w", err)
+	}
+	if len(ecosystems) == 0 {
+		return nil, nil
+	}
+	return &ecosystems[0], nil
+}
+
+// ListEcosystems 获取生态列表，按排序值、创建时间升序
+func (r *ecosystemRepository) ListEcosystems(db *gorm.DB, categoryGUID string, activeOnly bool) ([]Ecosystem, error) {
+	query := db.Model(&Ecosystem{})
+	if categoryGUID != "" {
+		query = query.Where("category_guid = ?", categoryGUID)
+	}
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	var ecosystems []Ecosystem
+	if err := query.Order("sort_order ASC, created_at ASC, guid ASC").Find(&ecosystems).Error; err != nil {
+		return nil, fmt.Errorf("failed to list ecosystems: %w", err)
+	}
+	return ecosystems, nil
+}
+
+// UpdateEcosystem 更新生态字段并刷新 updated_at
+func (r *ecosystemRepository) UpdateEcosystem(db *gorm.DB, ecosystemGUID string, columns map[string]interface{}) error {
+	updates := make(map[string]interface{}, len(columns)+1)
+	for column, value := range columns {
+		updates[column] = value
+	}
+	updates["updated_at"] = gorm.Expr("CURRENT_TIMESTAMP")
+
+	if err := db.Model(&Ecosystem{}).Where("guid = ?", ecosystemGUID).UpdateColumns(updates).Error; err != nil {
+		return fmt.Errorf("failed to update ecosystem: %w", err)
+	}
+	return nil
+}
+
+// DeleteEcosystem 软删除生态，多语言信息保留到清理任务硬删除时一并删除
+func (r *ecosystemRepository) DeleteEcosystem(db *gorm.DB, ecosystemGUID string) error {
+	if err := softDelete[Ecosystem](db, ecosystemGUID); err != nil {
+		return fmt.Errorf("failed to delete ecosystem: %w", err)
+	}
+	return nil
+}
+
+// LockDeletedEcosystem 加行锁获取已软删除的生态，不存在或未删除时返回 nil
+func (r *ecosystemRepository) LockDeletedEcosystem(db *gorm.DB, ecosystemGUID string) (*Ecosystem, error) {
+	deleted, err := lockDeleted[Ecosystem](db, ecosystemGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted ecosystem: %w", err)
+	}
+	return deleted, nil
+}
+
+// RestoreEcosystem 恢复已软删除的生态并刷新 updated_at
+func (r *ecosystemRepository) RestoreEcosystem(db *gorm.DB, ecosystemGUID string) error {
+	if err := restoreDeleted[Ecosystem](db, ecosystemGUID); err != nil {
+		return fmt.Errorf("failed to restore ecosystem: %w", err)
+	}
+	return nil
+}
+
+// AdjustEcosystemEventNum 在同一语句中调整生态的事件数，并发调整由行锁串行化；结果不低于 0
+func (r *ecosystemRepository) AdjustEcosystemEventNum(db *gorm.DB, ecosystemGUID string, delta int) error {
+	if ecosystemGUID == "" || delta == 0 {
+		return nil
+	}
+	err := db.Model(&Ecosystem{}).Where("guid = ?", ecosystemGUID).
+		UpdateColumn("event_num", gorm.Expr("GREATEST(event_num + ?, 0)", delta)).Error
+	if err != nil {
+		return fmt.Errorf("failed to adjust ecosystem event num: %w", err)
+	}
+	return nil
+}
+
+// CountEcosystemEvents 统计引用该生态的事件数量
+func (r *ecosystemRepository) CountEcosystemEvents(db *gorm.DB, ecosystemGUID string) (int64, error) {
+	var count int64
+	if err := db.Model(&Event{}).Where("ecosystem_guid = ?", ecosystemGUID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count ecosystem events: %w", err)
+	}
+	return count, nil
+}
+
+// GetEcosystemLanguages 获取生态的多语言信息，languageGUIDs 为空时返回全部语言
+func (r *ecosystemRepository) GetEcosystemLanguages(db *gorm.DB, ecosystemGUIDs, languageGUIDs []string) ([]EcosystemLanguage, error) {
+	if len(ecosystemGUIDs) == 0 {
+		return nil, nil
+	}
+	query := db.Where("ecosystem_guid IN ?", ecosystemGUIDs)
+	if len(languageGUIDs) > 0 {
+		query = query.Where("language_guid IN ?", languageGUIDs)
+	}
+	var ecosystemLangs []EcosystemLanguage
+	if err := query.Order("ecosystem_guid ASC, language_guid ASC").Find(&ecosystemLangs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get ecosystem languages: %w", err)
+	}
+	return ecosystemLangs, nil
+}
+
+// UpsertEcosystemLanguage 创建或更新生态在某语言下的信息（依赖 (ecosystem_guid, language_guid) 唯一索引）
+func (r *ecosystemRepository) UpsertEcosystemLanguage(db *gorm.DB, ecosystemLang *EcosystemLanguage) error {
+	err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "ecosystem_guid"}, {Name: "language_guid"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"name":        gorm.Expr("excluded.name"),
+			"description": gorm.Expr("excluded.description"),
+			"updated_at":  gorm.Expr("CURRENT_TIMESTAMP"),
+		}),
+	}).Create(ecosystemLang).Error
+	if err != nil {
+		return fmt.Errorf("failed to upsert ecosystem language: %w", err)
+	}
+	return nil
+}
+
+// DeleteEcosystemLanguages 删除生态在指定语言下的多语言信息
+func (r *ecosystemRepository) DeleteEcosystemLanguages(db *gorm.DB, ecosystemGUID string, languageGUIDs []string) error {
+	if len(languageGUIDs) == 0 {
+		return nil
+	}
+	err := db.Where("ecosystem_guid = ? AND language_guid IN ?", ecosystemGUID, languageGUIDs).
+		Delete(&EcosystemLanguage{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete ecosystem languages: %w", err)
+	}
+	return nil
+}
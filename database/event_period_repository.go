@@ -0,0 +1,191 @@
+package database
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// EventPeriodRepository 时间标签及其多语言信息的数据库操作接口
+type EventPeriodRepository interface {
+	// CreateEventPeriod 创建时间标签
+	CreateEventPeriod(db *gorm.DB, eventPeriod *EventPeriod) error
+	// GetEventPeriod 获取时间标签，不存在时返回 nil
+	GetEventPeriod(db *gorm.DB, eventPeriodGUID string) (*EventPeriod, error)
+	// LockEventPeriod 加行锁获取时间标签，不存在时返回 nil
+	LockEventPeriod(db *gorm.DB, eventPeriodGUID string) (*EventPeriod, error)
+	// GetEventPeriodByCode 按业务编码获取时间标签，不存在时返回 nil
+	GetEventPeriodByCode(db *gorm.DB, code string) (*EventPeriod, error)
+	// ListEventPeriods 获取时间标签列表，categoryGUID 为空时返回全部分类下的时间标签
+	ListEventPeriods(db *gorm.DB, categoryGUID string, activeOnly bool) ([]EventPeriod, error)
+	// UpdateEventPeriod 更新时间标签字段并刷新 updated_at
+	UpdateEventPeriod(db *gorm.DB, eventPeriodGUID string, columns map[string]interface{}) error
+	// DeleteEventPeriod 软删除时间标签，保留多语言信息以便恢复
+	DeleteEventPeriod(db *gorm.DB, eventPeriodGUID string) error
+	// LockDeletedEventPeriod 加行锁获取已软删除的时间标签，不存在或未删除时返回 nil
+	LockDeletedEventPeriod(db *gorm.DB, eventPeriodGUID string) (*EventPeriod, error)
+	// RestoreEventPeriod 恢复已软删除的时间标签并刷新 updated_at
+	RestoreEventPeriod(db *gorm.DB, eventPeriodGUID string) error
+	// CountEventPeriodEvents 统计引用该时间标签的事件数量
+	CountEventPeriodEvents(db *gorm.DB, eventPeriodGUID string) (int64, error)
+	// GetEventPeriodLanguages 获取时间标签的多语言信息，languageGUIDs 为空时返回全部语言
+	GetEventPeriodLanguages(db *gorm.DB, eventPeriodGUIDs, languageGUIDs []string) ([]EventPeriodLanguage, error)
+	// UpsertEventPeriodLanguage 创建或更新时间标签在某语言下的名称和描述
+	UpsertEventPeriodLanguage(db *gorm.DB, eventPeriodLang *EventPeriodLanguage) error
+	// DeleteEventPeriodLanguages 删除时间标签在指定语言下的多语言信息
+	DeleteEventPeriodLanguages(db *gorm.DB, eventPeriodGUID string, languageGUIDs []string) error
+}
+
+type eventPeriodRepository struct{}
+
+// NewEventPeriodRepository 创建时间标签仓储实例
+func NewEventPeriodRepository() EventPeriodRepository {
+	return &eventPeriodRepository{}
+}
+
+// CreateEventPeriod 创建时间标签，GUID 通过 RETURNING 回填
+func (r *eventPeriodRepository) CreateEventPeriod(db *gorm.DB, eventPeriod *EventPeriod) error {
+	if err := db.Clauses(clause.Returning{}).Create(eventPeriod).Error; err != nil {
+		return fmt.Errorf("failed to create event period: %w", err)
+	}
+	return nil
+}
+
+// GetEventPeriod 获取时间标签，不存在时返回 nil
+func (r *eventPeriodRepository) GetEventPeriod(db *gorm.DB, eventPeriodGUID string) (*EventPeriod, error) {
+	var eventPeriods []EventPeriod
+	if err := db.Where("guid = ?", eventPeriodGUID).Limit(1).Find(&eventPeriods).Error; err != nil {
+		return nil, fmt.Errorf("failed to get event period: %w", err)
+	}
+	if len(eventPeriods) == 0 {
+		return nil, nil
+	}
+	return &eventPeriods[0], nil
+}
+
+// LockEventPeriod 加行锁获取时间标签，不存在时返回 nil
+func (r *eventPeriodRepository) LockEventPeriod(db *gorm.DB, eventPeriodGUID string) (*EventPeriod, error) {
+	return r.GetEventPeriod(db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}), eventPeriodGUID)
+}
+
+// GetEventPeriodByCode 按业务编码获取时间标签，不存在时返回 nil
+func (r *eventPeriodRepository) GetEventPeriodByCode(db *gorm.DB, code string) (*EventPeriod, error) {
+	var eventPeriods []EventPeriod
+	if err := db.Where("code = ?", code).Limit(1).Find(&eventPeriods).Error; err != nil {
+		return nil, fmt.Errorf("failed to get event period by code: %w", err)
+	}
+	if len(eventPeriods) == 0 {
+		return nil, nil
+	}
+	return &eventPeriods[0], nil
+}
+
+// ListEventPeriods 获取时间标签列表，按创建时间升序
+func (r *eventPeriodRepository) ListEventPeriods(db *gorm.DB, categoryGUID string, activeOnly bool) ([]EventPeriod, error) {
+	query := db.Model(&EventPeriod{})
+	if categoryGUID != "" {
+		query = query.Where("category_guid = ?", categoryGUID)
+	}
+	if activeOnly {
+		query = query.Where("is_active = ?", true)
+	}
+	var eventPeriods []EventPeriod
+	if err := query.Order("created_at ASC, guid ASC").Find(&eventPeriods).Error; err != nil {
+		return nil, fmt.Errorf("failed to list event periods: %w", err)
+	}
+	return eventPeriods, nil
+}
+
+// UpdateEventPeriod 更新时间标签字段并刷新 updated_at
+func (r *eventPeriodRepository) UpdateEventPeriod(db *gorm.DB, eventPeriodGUID string, columns map[string]interface{}) error {
+	updates := make(map[string]interface{}, len(columns)+1)
+	for column, value := range columns {
+		updates[column] = value
+	}
+	updates["updated_at"] = gorm.Expr("CURRENT_TIMESTAMP")
+
+	if err := db.Model(&EventPeriod{}).Where("guid = ?", eventPeriodGUID).UpdateColumns(updates).Error; err != nil {
+		return fmt.Errorf("failed to update event period: %w", err)
+	}
+	return nil
+}
+
+// DeleteEventPeriod 软删除时间标签，多语言信息保留到清理任务硬删除时一并删除
+func (r *eventPeriodRepository) DeleteEventPeriod(db *gorm.DB, eventPeriodGUID string) error {
+	if err := softDelete[EventPeriod](db, eventPeriodGUID); err != nil {
+		return fmt.Errorf("failed to delete event period: %w", err)
+	}
+	return nil
+}
+
+// LockDeletedEventPeriod 加行锁获取已软删除的时间标签，不存在或未删除时返回 nil
+func (r *eventPeriodRepository) LockDeletedEventPeriod(db *gorm.DB, eventPeriodGUID string) (*EventPeriod, error) {
+	deleted, err := lockDeleted[EventPeriod](db, eventPeriodGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted event period: %w", err)
+	}
+	return deleted, nil
+}
+
+// RestoreEventPeriod 恢复已软删除的时间标签并刷新 updated_at
+func (r *eventPeriodRepository) RestoreEventPeriod(db *gorm.DB, eventPeriodGUID string) error {
+	if err := restoreDeleted[EventPeriod](db, eventPeriodGUID); err != nil {
+		return fmt.Errorf("failed to restore event period: %w", err)
+	}
+	return nil
+}
+
+// CountEventPeriodEvents 统计引用该时间标签的事件数量
+func (r *eventPeriodRepository) CountEventPeriodEvents(db *gorm.DB, eventPeriodGUID string) (int64, error) {
+	var count int64
+	if err := db.Model(&Event{}).Where("event_period_guid = ?", eventPeriodGUID).Count(&count).Error; err != nil {
+		return 0, fmt.Errorf("failed to count event period events: %w", err)
+	}
+	return count, nil
+}
+
+// GetEventPeriodLanguages 获取时间标签的多语言信息，languageGUIDs 为空时返回全部语言
+func (r *eventPeriodRepository) GetEventPeriodLanguages(db *gorm.DB, eventPeriodGUIDs, languageGUIDs []string) ([]EventPeriodLanguage, error) {
+	if len(eventPeriodGUIDs) == 0 {
+		return nil, nil
+	}
+	query := db.Where("event_period_guid IN ?", eventPeriodGUIDs)
+	if len(languageGUIDs) > 0 {
+		query = query.Where("language_guid IN ?", languageGUIDs)
+	}
+	var eventPeriodLangs []EventPeriodLanguage
+	if err := query.Order("event_period_guid ASC, language_guid ASC").Find(&eventPeriodLangs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get event period languages: %w", err)
+	}
+	return eventPeriodLangs, nil
+}
+
+// UpsertEventPeriodLanguage 创建或更新时间标签在某语言下的信息（依赖 (event_period_guid, language_guid) 唯一索引）
+func (r *eventPeriodRepository) UpsertEventPeriodLanguage(db *gorm.DB, eventPeriodLang *EventPeriodLanguage) error {
+	err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "event_period_guid"}, {Name: "language_guid"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"name":        gorm.Expr("excluded.name"),
+			"description": gorm.Expr("excluded.description"),
+			"updated_at":  gorm.Expr("CURRENT_TIMESTAMP"),
+		}),
+	}).Create(eventPeriodLang).Error
+	if err != nil {
+		return fmt.Errorf("failed to upsert event period language: %w", err)
+	}
+	return nil
+}
+
+// DeleteEventPeriodLanguages 删除时间标签在指定语言下的多语言信息
+func (r *eventPeriodRepository) DeleteEventPeriodLanguages(db *gorm.DB, eventPeriodGUID string, languageGUIDs []string) error {
+	if len(languageGUIDs) == 0 {
+		return nil
+	}
+	err := db.Where("event_period_guid = ? AND language_guid IN ?", eventPeriodGUID, languageGUIDs).
+		Delete(&EventPeriodLanguage{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete event period languages: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/common/decimal"
+)
+
+// 事件列表支持的排序键
+const (
+	EventSortCreatedAt   = "created_at"
+	EventSortTradeVolume = "trade_volume"
+	EventSortOpenTime    = "open_time"
+)
+
+// 排序方向
+const (
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// IsValidEventSortKey 判断是否为支持的排序键
+func IsValidEventSortKey(sortKey string) bool {
+	switch sortKey {
+	case EventSortCreatedAt, EventSortTradeVolume, EventSortOpenTime:
+		return true
+	}
+	return false
+}
+
+// EventFilter 事件列表的过滤与排序条件，零值表示不过滤、按创建时间倒序
+type EventFilter struct {
+	CategoryGUID    string     // 分类 GUID
+	EcosystemGUID   string     // 生态 GUID
+	EventPeriodGUID string     // 时间标签 GUID
+	TeamGroupGUID   string     // 运动队 GUID（匹配主队或客队）
+	IsLive          *int16     // 状态：0-进行中, 1-预热, 2-已结束
+	IsSports        *bool      // 是否为运动类事件
+	IsOnline        *bool      // 是否上线
+	OrderType       *int16     // 排序类型：0-热门话题, 1-突发, 2-最新
+	CreatedFrom     *time.Time // 创建时间下限（含）
+	CreatedTo       *time.Time // 创建时间上限（含）
+	OpenTimeFrom    *time.Time // 开盘时间下限（含）
+	OpenTimeTo      *time.Time // 开盘时间上限（含）
+	SortBy          string     // 排序键，默认 created_at
+	Order           string     // 排序方向 asc/desc，默认 desc
+}
+
+// SortKey 返回生效的排序键
+func (f EventFilter) SortKey() string {
+	if f.SortBy == "" {
+		return EventSortCreatedAt
+	}
+	return f.SortBy
+}
+
+// SortOrder 返回生效的排序方向
+func (f EventFilter) SortOrder() string {
+	if strings.EqualFold(f.Order, SortOrderAsc) {
+		return SortOrderAsc
+	}
+	return SortOrderDesc
+}
+
+// orderBy 排序子句，guid 作为排序键相同时的次序保证分页稳定
+func (f EventFilter) orderBy() string {
+	order := strings.ToUpper(f.SortOrder())
+	return fmt.Sprintf("%s %s, guid %s", f.SortKey(), order, order)
+}
+
+// apply 应用过滤条件
+func (f EventFilter) apply(query *gorm.DB) *gorm.DB {
+	if f.CategoryGUID != "" {
+		query = query.Where("category_guid = ?", f.CategoryGUID)
+	}
+	if f.EcosystemGUID != "" {
+		query = query.Where("ecosystem_guid = ?", f.EcosystemGUID)
+	}
+	if f.EventPeriodGUID != "" {
+		query = query.Where("event_period_guid = ?", f.EventPeriodGUID)
+	}
+	if f.TeamGroupGUID != "" {
+		query = query.Where("(main_team_group_guid = ? OR cluster_team_group_guid = ?)", f.TeamGroupGUID, f.TeamGroupGUID)
+	}
+	if f.IsLive != nil {
+		query = query.Where("is_live = ?", *f.IsLive)
+	}
+	if f.IsSports != nil {
+		query = query.Where("is_sports = ?", *f.IsSports)
+	}
+	if f.IsOnline != nil {
+		query = query.Where("is_online = ?", *f.IsOnline)
+	}
+	if f.OrderType != nil {
+		query = query.Where("order_type = ?", *f.OrderType)
+	}
+	if f.CreatedFrom != nil {
+		query = query.Where("created_at >= ?", *f.CreatedFrom)
+	}
+	if f.CreatedTo != nil {
+		query = query.Where("created_at <= ?", *f.CreatedTo)
+	}
+	// open_time 以 RFC3339 (UTC) 字符串存储，按字典序比较即按时间比较
+	if f.OpenTimeFrom != nil || f.OpenTimeTo != nil {
+		query = query.Where("open_time <> ''")
+	}
+	if f.OpenTimeFrom != nil {
+		query = query.Where("open_time >= ?", f.OpenTimeFrom.UTC().Format(time.RFC3339))
+	}
+	if f.OpenTimeTo != nil {
+		query = query.Where("open_time <= ?", f.OpenTimeTo.UTC().Format(time.RFC3339))
+	}
+	return query
+}
+
+// cursorCondition 游标位置之后的查询条件，参数为排序键的值和 guid
+func (f EventFilter) cursorCondition(cursor EventCursor) (string, interface{}, error) {
+	value, err := cursor.value()
+	if err != nil {
+		return "", nil, err
+	}
+	op := "<"
+	if f.SortOrder() == SortOrderAsc {
+		op = ">"
+	}
+	return fmt.Sprintf("(%s, guid) %s (?, ?)", f.SortKey(), op), value, nil
+}
+
+// EventCursor 事件列表游标（keyset 分页）：上一页最后一条记录的排序键值 + guid
+type EventCursor struct {
+	SortKey string `json:"k"` // 排序键
+	Order   string `json:"o"` // 排序方向
+	Value   string `json:"v"` // 排序键的值
+	GUID    string `json:"g"` // 排序键相同时按 guid 区分先后
+}
+
+// NewEventCursor 根据事件生成指向其之后位置的游标
+func NewEventCursor(filter EventFilter, event Event) EventCursor {
+	cursor := EventCursor{
+		SortKey: filter.SortKey(),
+		Order:   filter.SortOrder(),
+		GUID:    event.GUID,
+	}
+	switch cursor.SortKey {
+	case EventSortTradeVolume:
+		cursor.Value = event.TradeVolume.String()
+	case EventSortOpenTime:
+		cursor.Value = event.OpenTime
+	default:
+		cursor.Value = event.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+	return cursor
+}
+
+// ValidateFor 校验游标是否合法且与当前的排序条件一致
+func (c EventCursor) ValidateFor(filter EventFilter) error {
+	if c.GUID == "" {
+		return fmt.Errorf("cursor guid is empty")
+	}
+	if c.SortKey != filter.SortKey() || c.Order != filter.SortOrder() {
+		return fmt.Errorf("cursor was issued for sort %s %s, request uses %s %s",
+			c.SortKey, c.Order, filter.SortKey(), filter.SortOrder())
+	}
+	_, err := c.value()
+	return err
+}
+
+// value 将游标中的排序键值转换为查询参数
+func (c EventCursor) value() (interface{}, error) {
+	switch c.SortKey {
+	case EventSortCreatedAt:
+		createdAt, err := time.Parse(time.RFC3339Nano, c.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid created_at value %q: %w", c.Value, err)
+		}
+		return createdAt, nil
+	case EventSortTradeVolume:
+		tradeVolume, err := decimal.Parse(c.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trade_volume value %q: %w", c.Value, err)
+		}
+		return tradeVolume, nil
+	case EventSortOpenTime:
+		return c.Value, nil
+	}
+	return nil, fmt.Errorf("unsupported sort key %q", c.SortKey)
+}
@@ -2,8 +2,13 @@ package database
 
 import (
 	"fmt"
+	"slices"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/multimarket-labs/event-pod-services/common/decimal"
 )
 
 // EventRepository 事件数据库操作接口
@@ -14,16 +19,90 @@ type EventRepository interface {
 	CreateEventLanguage(db *gorm.DB, eventLang *EventLanguage) error
 	// CreateSubEvent 创建子事件
 	CreateSubEvent(db *gorm.DB, subEvent *SubEvent) error
+	// CreateSubEventLanguage 创建子事件多语言
+	CreateSubEventLanguage(db *gorm.DB, subEventLang *SubEventLanguage) error
 	// CreateSubEventDirection 创建子事件方向
 	CreateSubEventDirection(db *gorm.DB, direction *SubEventDirection) error
 	// ListEvents 查询事件列表（支持多语言）
-	ListEvents(db *gorm.DB, languageGUID, categoryGUID string, isLive *int16, page, limit int) ([]Event, int64, error)
-	// GetEventWithLanguage 获取事件及其多语言信息
-	GetEventWithLanguage(db *gorm.DB, eventGUID, languageGUID string) (*Event, *EventLanguage, error)
+	ListEvents(db *gorm.DB, languageGUID string, filter EventFilter, page, limit int) ([]Event, int64, error)
+	// ListEventsByCursor 基于游标查询事件列表，返回本页事件及是否还有下一页
+	ListEventsByCursor(db *gorm.DB, filter EventFilter, cursor *EventCursor, limit int) ([]Event, bool, error)
+	// GetEventWithLanguage 获取事件及其多语言信息，按 languageGUIDs 的顺序选择翻译，都没有时取任意一条
+	GetEventWithLanguage(db *gorm.DB, eventGUID string, languageGUIDs []string) (*Event, *EventLanguage, error)
 	// GetSubEventsByEventGUID 获取事件的所有子事件
 	GetSubEventsByEventGUID(db *gorm.DB, eventGUID string) ([]SubEvent, error)
+	// GetSubEvent 根据 GUID 获取子事件
+	GetSubEvent(db *gorm.DB, subEventGUID string) (*SubEvent, error)
 	// GetSubEventDirections 获取子事件的所有方向
 	GetSubEventDirections(db *gorm.DB, subEventGUID string) ([]SubEventDirection, error)
+	// GetSubEventLanguages 批量获取子事件的多语言标题
+	GetSubEventLanguages(db *gorm.DB, subEventGUIDs []string, languageGUID string) ([]SubEventLanguage, error)
+	// GetTeamGroupsWithLanguage 批量获取运动队及其在 languageGUIDs 中各语言的名称
+	GetTeamGroupsWithLanguage(db *gorm.DB, teamGroupGUIDs []string, languageGUIDs []string) ([]TeamGroup, []TeamGroupLanguage, error)
+	// GetCategoryLanguage 获取分类的多语言信息，不存在时返回 nil
+	GetCategoryLanguage(db *gorm.DB, categoryGUID, languageGUID string) (*CategoryLanguage, error)
+	// GetEcosystemLanguage 获取生态的多语言信息，不存在时返回 nil
+	GetEcosystemLanguage(db *gorm.DB, ecosystemGUID, languageGUID string) (*EcosystemLanguage, error)
+	// GetEventPeriodLanguage 获取时间标签的多语言信息，不存在时返回 nil
+	GetEventPeriodLanguage(db *gorm.DB, eventPeriodGUID, languageGUID string) (*EventPeriodLanguage, error)
+	// LoadEventTrees 批量加载一页事件的多语言、子事件（含与事件同语言的翻译）及方向，查询次数与事件数量无关
+	LoadEventTrees(db *gorm.DB, events []Event, languageGUIDs []string) ([]EventTree, error)
+	// GetEvent 根据 GUID 获取事件
+	GetEvent(db *gorm.DB, eventGUID string) (*Event, error)
+	// LockEvent 加行锁获取事件，用于读取更新前的字段
+	LockEvent(db *gorm.DB, eventGUID string) (*Event, error)
+	// DeleteEvent 软删除事件，保留多语言信息、子事件和流转记录以便恢复
+	DeleteEvent(db *gorm.DB, eventGUID string) error
+	// LockDeletedEvent 加行锁获取已软删除的事件，不存在或未删除时返回 nil
+	LockDeletedEvent(db *gorm.DB, eventGUID string) (*Event, error)
+	// RestoreEvent 恢复已软删除的事件并刷新 updated_at
+	RestoreEvent(db *gorm.DB, eventGUID string) error
+	// UpdateEventWithVersion 以 updated_at 作为乐观锁更新事件字段，返回是否命中（false 表示已被他人修改或不存在）
+	UpdateEventWithVersion(db *gorm.DB, eventGUID string, expectedUpdatedAt time.Time, updates map[string]interface{}) (bool, error)
+	// UpsertEventLanguage 创建或更新事件在某语言下的标题与规则
+	UpsertEventLanguage(db *gorm.DB, eventLang *EventLanguage) error
+	// UpsertSubEventLanguage 创建或更新子事件在某语言下的标题
+	UpsertSubEventLanguage(db *gorm.DB, subEventLang *SubEventLanguage) error
+	// DeleteSubEvents 删除事件下的子事件及其方向、多语言，返回实际删除的子事件数量
+	DeleteSubEvents(db *gorm.DB, eventGUID string, subEventGUIDs []string) (int64, error)
+	// DeleteSubEventDirections 删除事件下的子事件方向，返回实际删除的数量
+	DeleteSubEventDirections(db *gorm.DB, eventGUID string, directionGUIDs []string) (int64, error)
+	// TransitionEventStatus 仅当事件当前状态为 fromStatus 时更新状态及相关字段，返回是否命中
+	TransitionEventStatus(db *gorm.DB, eventGUID, fromStatus, toStatus string, updates map[string]interface{}) (bool, error)
+	// CreateEventStatusHistory 记录事件状态流转
+	CreateEventStatusHistory(db *gorm.DB, history *EventStatusHistory) error
+	// ListEventStatusHistory 按时间顺序获取事件的状态流转记录
+	ListEventStatusHistory(db *gorm.DB, eventGUID string) ([]EventStatusHistory, error)
+	// CreateEventScoreHistory 记录事件比分变更
+	CreateEventScoreHistory(db *gorm.DB, history *EventScoreHistory) error
+	// ListEventScoreHistory 按时间顺序获取事件的比分变更记录
+	ListEventScoreHistory(db *gorm.DB, eventGUID string) ([]EventScoreHistory, error)
+	// ResolveSubEvent 设置子事件的结算状态，并将 winningDirectionGUIDs 中的方向标记为胜出、其余标记为未胜出
+	ResolveSubEvent(db *gorm.DB, subEventGUID, resolution string, winningDirectionGUIDs []string) error
+	// CreateEventResolution 记录事件结算
+	CreateEventResolution(db *gorm.DB, resolution *EventResolution) error
+	// ListDueEvents 获取处于 status 且调度时间点 schedule 不晚于 now 的事件，按时间先后排序
+	ListDueEvents(db *gorm.DB, status, schedule string, now time.Time, limit int) ([]Event, error)
+	// LockSubEventDirections 加行锁获取子事件的所有方向，用于做市定价
+	LockSubEventDirections(db *gorm.DB, subEventGUID string) ([]SubEventDirection, error)
+	// UpdateDirectionPricing 更新方向的做市份额、概率和买卖价
+	UpdateDirectionPricing(db *gorm.DB, direction *SubEventDirection) error
+	// AddTradeVolume 累加子事件及其所属事件的成交额
+	AddTradeVolume(db *gorm.DB, eventGUID, subEventGUID string, volume decimal.Decimal) error
+}
+
+// EventTree 事件及其多语言信息、子事件和方向
+type EventTree struct {
+	Event     Event
+	Language  *EventLanguage // 按语言优先顺序选出的翻译，事件没有任何翻译时为 nil
+	SubEvents []SubEventTree
+}
+
+// SubEventTree 子事件及其方向
+type SubEventTree struct {
+	SubEvent   SubEvent
+	Language   *SubEventLanguage // 与事件所选翻译同语言的子事件翻译，没有时为 nil
+	Directions []SubEventDirection
 }
 
 type eventRepository struct{}
@@ -33,39 +112,36 @@ func NewEventRepository() EventRepository {
 	return &eventRepository{}
 }
 
+// CreateEvent 创建事件，GUID 等数据库生成的字段通过 RETURNING 回填
 func (r *eventRepository) CreateEvent(db *gorm.DB, event *Event) error {
-	return db.Create(event).Error
+	return db.Clauses(clause.Returning{}).Create(event).Error
 }
 
 func (r *eventRepository) CreateEventLanguage(db *gorm.DB, eventLang *EventLanguage) error {
 	return db.Create(eventLang).Error
 }
 
+// CreateSubEvent 创建子事件，GUID 通过 RETURNING 回填
 func (r *eventRepository) CreateSubEvent(db *gorm.DB, subEvent *SubEvent) error {
-	return db.Create(subEvent).Error
+	return db.Clauses(clause.Returning{}).Create(subEvent).Error
 }
 
+func (r *eventRepository) CreateSubEventLanguage(db *gorm.DB, subEventLang *SubEventLanguage) error {
+	return db.Create(subEventLang).Error
+}
+
+// CreateSubEventDirection 创建子事件方向，GUID 通过 RETURNING 回填
 func (r *eventRepository) CreateSubEventDirection(db *gorm.DB, direction *SubEventDirection) error {
-	return db.Create(direction).Error
+	return db.Clauses(clause.Returning{}).Create(direction).Error
 }
 
 // ListEvents 查询事件列表（支持多语言）
-func (r *eventRepository) ListEvents(db *gorm.DB, languageGUID, categoryGUID string, isLive *int16, page, limit int) ([]Event, int64, error) {
+func (r *eventRepository) ListEvents(db *gorm.DB, languageGUID string, filter EventFilter, page, limit int) ([]Event, int64, error) {
 	var events []Event
 	var total int64
 
 	// 构建查询
-	query := db.Model(&Event{})
-
-	// 按分类过滤
-	if categoryGUID != "" {
-		query = query.Where("category_guid = ?", categoryGUID)
-	}
-
-	// 按状态过滤
-	if isLive != nil {
-		query = query.Where("is_live = ?", *isLive)
-	}
+	query := filter.apply(db.Model(&Event{}))
 
 	// 统计总数
 	if err := query.Count(&total).Error; err != nil {
@@ -74,26 +150,59 @@ func (r *eventRepository) ListEvents(db *gorm.DB, languageGUID, categoryGUID str
 
 	// 分页查询
 	offset := (page - 1) * limit
-	if err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&events).Error; err != nil {
+	if err := query.Order(filter.orderBy()).Limit(limit).Offset(offset).Find(&events).Error; err != nil {
 		return nil, 0, fmt.Errorf("failed to list events: %w", err)
 	}
 
 	return events, total, nil
 }
 
+// ListEventsByCursor 基于游标查询事件列表（按排序键, guid 排序）
+// 不统计总数，多查询一条用于判断是否还有下一页
+func (r *eventRepository) ListEventsByCursor(db *gorm.DB, filter EventFilter, cursor *EventCursor, limit int) ([]Event, bool, error) {
+	var events []Event
+
+	query := filter.apply(db.Model(&Event{}))
+
+	// 从游标位置之后继续查询
+	if cursor != nil {
+		condition, value, err := filter.cursorCondition(*cursor)
+		if err != nil {
+			return nil, false, err
+		}
+		query = query.Where(condition, value, cursor.GUID)
+	}
+
+	if err := query.Order(filter.orderBy()).Limit(limit + 1).Find(&events).Error; err != nil {
+		return nil, false, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	hasMore := len(events) > limit
+	if hasMore {
+		events = events[:limit]
+	}
+	return events, hasMore, nil
+}
+
 // GetEventWithLanguage 获取事件及其多语言信息
-func (r *eventRepository) GetEventWithLanguage(db *gorm.DB, eventGUID, languageGUID string) (*Event, *EventLanguage, error) {
+// 按 languageGUIDs 的顺序选择翻译，都没有时取最早创建的一条；事件没有任何翻译时返回 gorm.ErrRecordNotFound
+func (r *eventRepository) GetEventWithLanguage(db *gorm.DB, eventGUID string, languageGUIDs []string) (*Event, *EventLanguage, error) {
 	var event Event
 	if err := db.Where("guid = ?", eventGUID).First(&event).Error; err != nil {
 		return nil, nil, fmt.Errorf("failed to get event: %w", err)
 	}
 
-	var eventLang EventLanguage
-	if err := db.Where("event_guid = ? AND language_guid = ?", eventGUID, languageGUID).First(&eventLang).Error; err != nil {
+	var eventLangs []EventLanguage
+	if err := db.Where("event_guid = ?", eventGUID).Order("created_at ASC").Find(&eventLangs).Error; err != nil {
 		return &event, nil, fmt.Errorf("failed to get event language: %w", err)
 	}
 
-	return &event, &eventLang, nil
+	eventLang := pickEventLanguage(eventLangs, languageGUIDs)
+	if eventLang == nil {
+		return &event, nil, fmt.Errorf("failed to get event language: %w", gorm.ErrRecordNotFound)
+	}
+
+	return &event, eventLang, nil
 }
 
 // GetSubEventsByEventGUID 获取事件的所有子事件
@@ -105,6 +214,16 @@ func (r *eventRepository) GetSubEventsByEventGUID(db *gorm.DB, eventGUID string)
 	return subEvents, nil
 }
 
+// GetSubEvent 根据 GUID 获取子事件，所属事件已软删除时视为不存在
+func (r *eventRepository) GetSubEvent(db *gorm.DB, subEventGUID string) (*SubEvent, error) {
+	var subEvent SubEvent
+	liveEvents := db.Model(&Event{}).Select("guid")
+	if err := db.Where("guid = ? AND parent_event_guid IN (?)", subEventGUID, liveEvents).First(&subEvent).Error; err != nil {
+		return nil, fmt.Errorf("failed to get sub event: %w", err)
+	}
+	return &subEvent, nil
+}
+
 // GetSubEventDirections 获取子事件的所有方向
 func (r *eventRepository) GetSubEventDirections(db *gorm.DB, subEventGUID string) ([]SubEventDirection, error) {
 	var directions []SubEventDirection
@@ -113,3 +232,464 @@ func (r *eventRepository) GetSubEventDirections(db *gorm.DB, subEventGUID string
 	}
 	return directions, nil
 }
+
+// GetSubEventLanguages 批量获取子事件的多语言标题
+func (r *eventRepository) GetSubEventLanguages(db *gorm.DB, subEventGUIDs []string, languageGUID string) ([]SubEventLanguage, error) {
+	var subEventLangs []SubEventLanguage
+	if len(subEventGUIDs) == 0 {
+		return subEventLangs, nil
+	}
+	if err := db.Where("sub_event_guid IN ? AND language_guid = ?", subEventGUIDs, languageGUID).Find(&subEventLangs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get sub event languages: %w", err)
+	}
+	return subEventLangs, nil
+}
+
+// GetTeamGroupsWithLanguage 批量获取运动队及其在 languageGUIDs 中各语言的名称
+func (r *eventRepository) GetTeamGroupsWithLanguage(db *gorm.DB, teamGroupGUIDs []string, languageGUIDs []string) ([]TeamGroup, []TeamGroupLanguage, error) {
+	var teamGroups []TeamGroup
+	var teamGroupLangs []TeamGroupLanguage
+	if len(teamGroupGUIDs) == 0 {
+		return teamGroups, teamGroupLangs, nil
+	}
+	if err := db.Where("guid IN ?", teamGroupGUIDs).Find(&teamGroups).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to get team groups: %w", err)
+	}
+	if err := db.Where("team_group_guid IN ? AND language_guid IN ?", teamGroupGUIDs, languageGUIDs).Find(&teamGroupLangs).Error; err != nil {
+		return nil, nil, fmt.Errorf("failed to get team group languages: %w", err)
+	}
+	return teamGroups, teamGroupLangs, nil
+}
+
+// GetCategoryLanguage 获取分类的多语言信息，不存在时返回 nil
+func (r *eventRepository) GetCategoryLanguage(db *gorm.DB, categoryGUID, languageGUID string) (*CategoryLanguage, error) {
+	var categoryLang CategoryLanguage
+	result := db.Where("category_guid = ? AND language_guid = ?", categoryGUID, languageGUID).Limit(1).Find(&categoryLang)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get category language: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &categoryLang, nil
+}
+
+// GetEcosystemLanguage 获取生态的多语言信息，不存在时返回 nil
+func (r *eventRepository) GetEcosystemLanguage(db *gorm.DB, ecosystemGUID, languageGUID string) (*EcosystemLanguage, error) {
+	var ecosystemLang EcosystemLanguage
+	result := db.Where("ecosystem_guid = ? AND language_guid = ?", ecosystemGUID, languageGUID).Limit(1).Find(&ecosystemLang)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get ecosystem language: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &ecosystemLang, nil
+}
+
+// GetEventPeriodLanguage 获取时间标签的多语言信息，不存在时返回 nil
+func (r *eventRepository) GetEventPeriodLanguage(db *gorm.DB, eventPeriodGUID, languageGUID string) (*EventPeriodLanguage, error) {
+	var eventPeriodLang EventPeriodLanguage
+	result := db.Where("event_period_guid = ? AND language_guid = ?", eventPeriodGUID, languageGUID).Limit(1).Find(&eventPeriodLang)
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to get event period language: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &eventPeriodLang, nil
+}
+
+// LoadEventTrees 批量加载一页事件的多语言、子事件及方向
+// 最多执行 4 条 IN 查询（event_language、sub_event、sub_event_direction、sub_event_language），不随事件数量增长
+// 每个事件按 languageGUIDs 的顺序选择翻译，都没有时取最早创建的一条；子事件取与事件所选翻译同语言的翻译
+func (r *eventRepository) LoadEventTrees(db *gorm.DB, events []Event, languageGUIDs []string) ([]EventTree, error) {
+	trees := make([]EventTree, 0, len(events))
+	if len(events) == 0 {
+		return trees, nil
+	}
+
+	eventGUIDs := make([]string, 0, len(events))
+	for _, event := range events {
+		eventGUIDs = append(eventGUIDs, event.GUID)
+	}
+
+	var eventLangs []EventLanguage
+	if err := db.Where("event_guid IN ?", eventGUIDs).Order("created_at ASC").Find(&eventLangs).Error; err != nil {
+		return nil, fmt.Errorf("failed to get event languages: %w", err)
+	}
+
+	var subEvents []SubEvent
+	if err := db.Where("parent_event_guid IN ?", eventGUIDs).Order("created_at ASC").Find(&subEvents).Error; err != nil {
+		return nil, fmt.Errorf("failed to get sub events: %w", err)
+	}
+
+	// 按父 GUID 建立索引，保持查询返回的顺序
+	langsByEvent := make(map[string][]EventLanguage, len(events))
+	for _, eventLang := range eventLangs {
+		langsByEvent[eventLang.EventGUID] = append(langsByEvent[eventLang.EventGUID], eventLang)
+	}
+	pickedLangs := make(map[string]*EventLanguage, len(events))
+	var pickedLanguageGUIDs []string
+	for _, event := range events {
+		eventLang := pickEventLanguage(langsByEvent[event.GUID], languageGUIDs)
+		pickedLangs[event.GUID] = eventLang
+		if eventLang != nil && !slices.Contains(pickedLanguageGUIDs, eventLang.LanguageGUID) {
+			pickedLanguageGUIDs = append(pickedLanguageGUIDs, eventLang.LanguageGUID)
+		}
+	}
+
+	var directions []SubEventDirection
+	var subEventLangs []SubEventLanguage
+	if len(subEvents) > 0 {
+		subEventGUIDs := make([]string, 0, len(subEvents))
+		for _, subEvent := range subEvents {
+			subEventGUIDs = append(subEventGUIDs, subEvent.GUID)
+		}
+		if err := db.Where("sub_event_guid IN ?", subEventGUIDs).Order("created_at ASC").Find(&directions).Error; err != nil {
+			return nil, fmt.Errorf("failed to get sub event directions: %w", err)
+		}
+		// 子事件标题与事件使用同一语言，未指定语言时沿用 sub_event.title
+		if len(languageGUIDs) > 0 && len(pickedLanguageGUIDs) > 0 {
+			if err := db.Where("sub_event_guid IN ? AND language_guid IN ?", subEventGUIDs, pickedLanguageGUIDs).Find(&subEventLangs).Error; err != nil {
+				return nil, fmt.Errorf("failed to get sub event languages: %w", err)
+			}
+		}
+	}
+
+	directionsBySubEvent := make(map[string][]SubEventDirection, len(subEvents))
+	for _, direction := range directions {
+		directionsBySubEvent[direction.SubEventGUID] = append(directionsBySubEvent[direction.SubEventGUID], direction)
+	}
+	subEventLangsBySubEvent := make(map[string][]SubEventLanguage, len(subEvents))
+	for _, subEventLang := range subEventLangs {
+		subEventLangsBySubEvent[subEventLang.SubEventGUID] = append(subEventLangsBySubEvent[subEventLang.SubEventGUID], subEventLang)
+	}
+	subEventsByEvent := make(map[string][]SubEventTree, len(events))
+	for _, subEvent := range subEvents {
+		var subEventLang *SubEventLanguage
+		if eventLang := pickedLangs[subEvent.ParentEventGUID]; eventLang != nil {
+			for i, candidate := range subEventLangsBySubEvent[subEvent.GUID] {
+				if candidate.LanguageGUID == eventLang.LanguageGUID {
+					subEventLang = &subEventLangsBySubEvent[subEvent.GUID][i]
+					break
+				}
+			}
+		}
+		subEventsByEvent[subEvent.ParentEventGUID] = append(subEventsByEvent[subEvent.ParentEventGUID], SubEventTree{
+			SubEvent:   subEvent,
+			Language:   subEventLang,
+			Directions: directionsBySubEvent[subEvent.GUID],
+		})
+	}
+
+	for _, event := range events {
+		trees = append(trees, EventTree{
+			Event:     event,
+			Language:  pickedLangs[event.GUID],
+			SubEvents: subEventsByEvent[event.GUID],
+		})
+	}
+	return trees, nil
+}
+
+// pickEventLanguage 按语言优先顺序选择翻译，都没有时返回第一条
+func pickEventLanguage(eventLangs []EventLanguage, languageGUIDs []string) *EventLanguage {
+	for _, languageGUID := range languageGUIDs {
+		for i := range eventLangs {
+			if eventLangs[i].LanguageGUID == languageGUID {
+				return &eventLangs[i]
+			}
+		}
+	}
+	if len(eventLangs) == 0 {
+		return nil
+	}
+	return &eventLangs[0]
+}
+
+// GetEvent 根据 GUID 获取事件
+func (r *eventRepository) GetEvent(db *gorm.DB, eventGUID string) (*Event, error) {
+	var event Event
+	if err := db.Where("guid = ?", eventGUID).First(&event).Error; err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	return &event, nil
+}
+
+// LockEvent 加行锁获取事件
+func (r *eventRepository) LockEvent(db *gorm.DB, eventGUID string) (*Event, error) {
+	return r.GetEvent(db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}), eventGUID)
+}
+
+// DeleteEvent 软删除事件，多语言信息、子事件和流转记录保留到清理任务硬删除时一并删除
+func (r *eventRepository) DeleteEvent(db *gorm.DB, eventGUID string) error {
+	if err := softDelete[Event](db, eventGUID); err != nil {
+		return fmt.Errorf("failed to delete event: %w", err)
+	}
+	return nil
+}
+
+// LockDeletedEvent 加行锁获取已软删除的事件，不存在或未删除时返回 nil
+func (r *eventRepository) LockDeletedEvent(db *gorm.DB, eventGUID string) (*Event, error) {
+	deleted, err := lockDeleted[Event](db, eventGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted event: %w", err)
+	}
+	return deleted, nil
+}
+
+// RestoreEvent 恢复已软删除的事件，updated_at 与编辑事件一样至少增加 1 秒，使删除前读取的版本失效
+func (r *eventRepository) RestoreEvent(db *gorm.DB, eventGUID string) error {
+	err := db.Unscoped().Model(&Event{}).Where("guid = ? AND deleted_at IS NOT NULL", eventGUID).
+		UpdateColumns(map[string]interface{}{
+			"deleted_at": nil,
+			"updated_at": gorm.Expr("GREATEST(CURRENT_TIMESTAMP(0), updated_at + INTERVAL '1 second')"),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to restore event: %w", err)
+	}
+	return nil
+}
+
+// UpdateEventWithVersion 以 updated_at 作为乐观锁更新事件字段
+// updated_at 精度为秒，新值至少比旧值大 1 秒，保证同一秒内的连续修改也能被识别
+func (r *eventRepository) UpdateEventWithVersion(db *gorm.DB, eventGUID string, expectedUpdatedAt time.Time, updates map[string]interface{}) (bool, error) {
+	columns := make(map[string]interface{}, len(updates)+1)
+	for column, value := range updates {
+		columns[column] = value
+	}
+	columns["updated_at"] = gorm.Expr("GREATEST(CURRENT_TIMESTAMP(0), updated_at + INTERVAL '1 second')")
+
+	result := db.Model(&Event{}).
+		Where("guid = ? AND updated_at = ?", eventGUID, expectedUpdatedAt).
+		UpdateColumns(columns)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to update event: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// UpsertEventLanguage 创建或更新事件在某语言下的标题与规则
+func (r *eventRepository) UpsertEventLanguage(db *gorm.DB, eventLang *EventLanguage) error {
+	result := db.Model(&EventLanguage{}).
+		Where("event_guid = ? AND language_guid = ?", eventLang.EventGUID, eventLang.LanguageGUID).
+		Updates(map[string]interface{}{
+			"title":      eventLang.Title,
+			"rules":      eventLang.Rules,
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update event language: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+	if err := db.Create(eventLang).Error; err != nil {
+		return fmt.Errorf("failed to create event language: %w", err)
+	}
+	return nil
+}
+
+// UpsertSubEventLanguage 创建或更新子事件在某语言下的标题
+func (r *eventRepository) UpsertSubEventLanguage(db *gorm.DB, subEventLang *SubEventLanguage) error {
+	result := db.Model(&SubEventLanguage{}).
+		Where("sub_event_guid = ? AND language_guid = ?", subEventLang.SubEventGUID, subEventLang.LanguageGUID).
+		Updates(map[string]interface{}{
+			"title":      subEventLang.Title,
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+	if result.Error != nil {
+		return fmt.Errorf("failed to update sub event language: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+	if err := db.Create(subEventLang).Error; err != nil {
+		return fmt.Errorf("failed to create sub event language: %w", err)
+	}
+	return nil
+}
+
+// DeleteSubEvents 删除事件下的子事件及其方向、多语言，不属于该事件的 GUID 会被忽略
+func (r *eventRepository) DeleteSubEvents(db *gorm.DB, eventGUID string, subEventGUIDs []string) (int64, error) {
+	if len(subEventGUIDs) == 0 {
+		return 0, nil
+	}
+
+	var owned []string
+	if err := db.Model(&SubEvent{}).Where("parent_event_guid = ? AND guid IN ?", eventGUID, subEventGUIDs).
+		Pluck("guid", &owned).Error; err != nil {
+		return 0, fmt.Errorf("failed to get sub events: %w", err)
+	}
+	if len(owned) == 0 {
+		return 0, nil
+	}
+
+	if err := db.Where("sub_event_guid IN ?", owned).Delete(&SubEventDirection{}).Error; err != nil {
+		return 0, fmt.Errorf("failed to delete sub event directions: %w", err)
+	}
+	if err := db.Where("sub_event_guid IN ?", owned).Delete(&SubEventLanguage{}).Error; err != nil {
+		return 0, fmt.Errorf("failed to delete sub event languages: %w", err)
+	}
+	result := db.Where("guid IN ?", owned).Delete(&SubEvent{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete sub events: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// DeleteSubEventDirections 删除事件下的子事件方向，不属于该事件的 GUID 会被忽略
+func (r *eventRepository) DeleteSubEventDirections(db *gorm.DB, eventGUID string, directionGUIDs []string) (int64, error) {
+	if len(directionGUIDs) == 0 {
+		return 0, nil
+	}
+
+	subEventGUIDs := db.Model(&SubEvent{}).Select("guid").Where("parent_event_guid = ?", eventGUID)
+	result := db.Where("guid IN ? AND sub_event_guid IN (?)", directionGUIDs, subEventGUIDs).Delete(&SubEventDirection{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("failed to delete sub event directions: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
+
+// TransitionEventStatus 仅当事件当前状态为 fromStatus 时更新状态，避免并发流转互相覆盖
+func (r *eventRepository) TransitionEventStatus(db *gorm.DB, eventGUID, fromStatus, toStatus string, updates map[string]interface{}) (bool, error) {
+	columns := make(map[string]interface{}, len(updates)+2)
+	for column, value := range updates {
+		columns[column] = value
+	}
+	columns["status"] = toStatus
+	columns["updated_at"] = gorm.Expr("GREATEST(CURRENT_TIMESTAMP(0), updated_at + INTERVAL '1 second')")
+
+	result := db.Model(&Event{}).
+		Where("guid = ? AND status = ?", eventGUID, fromStatus).
+		UpdateColumns(columns)
+	if result.Error != nil {
+		return false, fmt.Errorf("failed to update event status: %w", result.Error)
+	}
+	return result.RowsAffected > 0, nil
+}
+
+func (r *eventRepository) CreateEventStatusHistory(db *gorm.DB, history *EventStatusHistory) error {
+	return db.Create(history).Error
+}
+
+// ListEventStatusHistory 按时间顺序获取事件的状态流转记录
+func (r *eventRepository) ListEventStatusHistory(db *gorm.DB, eventGUID string) ([]EventStatusHistory, error) {
+	var histories []EventStatusHistory
+	if err := db.Where("event_guid = ?", eventGUID).Order("created_at ASC").Find(&histories).Error; err != nil {
+		return nil, fmt.Errorf("failed to get event status history: %w", err)
+	}
+	return histories, nil
+}
+
+// CreateEventScoreHistory 记录事件比分变更，GUID 通过 RETURNING 回填
+func (r *eventRepository) CreateEventScoreHistory(db *gorm.DB, history *EventScoreHistory) error {
+	if err := db.Clauses(clause.Returning{}).Create(history).Error; err != nil {
+		return fmt.Errorf("failed to create event score history: %w", err)
+	}
+	return nil
+}
+
+// ListEventScoreHistory 按时间顺序获取事件的比分变更记录
+func (r *eventRepository) ListEventScoreHistory(db *gorm.DB, eventGUID string) ([]EventScoreHistory, error) {
+	var histories []EventScoreHistory
+	if err := db.Where("event_guid = ?", eventGUID).Order("created_at ASC, guid ASC").Find(&histories).Error; err != nil {
+		return nil, fmt.Errorf("failed to get event score history: %w", err)
+	}
+	return histories, nil
+}
+
+// ResolveSubEvent 设置子事件的结算状态，并重置其所有方向的胜负
+func (r *eventRepository) ResolveSubEvent(db *gorm.DB, subEventGUID, resolution string, winningDirectionGUIDs []string) error {
+	if err := db.Model(&SubEvent{}).Where("guid = ?", subEventGUID).
+		UpdateColumns(map[string]interface{}{
+			"resolution": resolution,
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		}).Error; err != nil {
+		return fmt.Errorf("failed to update sub event resolution: %w", err)
+	}
+
+	isWin := gorm.Expr("FALSE")
+	if len(winningDirectionGUIDs) > 0 {
+		isWin = gorm.Expr("guid IN ?", winningDirectionGUIDs)
+	}
+	if err := db.Model(&SubEventDirection{}).Where("sub_event_guid = ?", subEventGUID).
+		UpdateColumns(map[string]interface{}{
+			"is_win":     isWin,
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		}).Error; err != nil {
+		return fmt.Errorf("failed to update sub event directions: %w", err)
+	}
+	return nil
+}
+
+func (r *eventRepository) CreateEventResolution(db *gorm.DB, resolution *EventResolution) error {
+	return db.Create(resolution).Error
+}
+
+// ListDueEvents 获取处于 status 且调度时间点 schedule 不晚于 now 的事件，按时间先后排序
+func (r *eventRepository) ListDueEvents(db *gorm.DB, status, schedule string, now time.Time, limit int) ([]Event, error) {
+	column, ok := eventScheduleColumns[schedule]
+	if !ok {
+		return nil, fmt.Errorf("unknown event schedule %q", schedule)
+	}
+
+	var events []Event
+	err := db.Where("status = ?", status).
+		Where(column+" <= ?", now.UTC()).
+		Order(column + " ASC, guid ASC").
+		Limit(limit).
+		Find(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to list due events: %w", err)
+	}
+	return events, nil
+}
+
+// LockSubEventDirections 加行锁按创建顺序获取子事件的所有方向
+func (r *eventRepository) LockSubEventDirections(db *gorm.DB, subEventGUID string) ([]SubEventDirection, error) {
+	var directions []SubEventDirection
+	err := db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("sub_event_guid = ?", subEventGUID).
+		Order("created_at ASC, guid ASC").
+		Find(&directions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock sub event directions: %w", err)
+	}
+	return directions, nil
+}
+
+// UpdateDirectionPricing 更新方向的做市份额、概率和买卖价
+func (r *eventRepository) UpdateDirectionPricing(db *gorm.DB, direction *SubEventDirection) error {
+	err := db.Model(&SubEventDirection{}).Where("guid = ?", direction.GUID).
+		UpdateColumns(map[string]interface{}{
+			"shares":        direction.Shares,
+			"chance":        direction.Chance,
+			"new_ask_price": direction.NewAskPrice,
+			"new_bid_price": direction.NewBidPrice,
+			"updated_at":    gorm.Expr("CURRENT_TIMESTAMP"),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update direction pricing: %w", err)
+	}
+	return nil
+}
+
+// AddTradeVolume 累加子事件及其所属事件的成交额
+func (r *eventRepository) AddTradeVolume(db *gorm.DB, eventGUID, subEventGUID string, volume decimal.Decimal) error {
+	columns := map[string]interface{}{
+		"trade_volume": gorm.Expr("trade_volume + ?", volume),
+		"updated_at":   gorm.Expr("CURRENT_TIMESTAMP"),
+	}
+	if err := db.Model(&SubEvent{}).Where("guid = ?", subEventGUID).UpdateColumns(columns).Error; err != nil {
+		return fmt.Errorf("failed to update sub event trade volume: %w", err)
+	}
+	// 成交额不影响事件内容，不更新 event.updated_at，以免与编辑事件的乐观锁冲突
+	if err := db.Model(&Event{}).Where("guid = ?", eventGUID).
+		UpdateColumn("trade_volume", gorm.Expr("trade_volume + ?", volume)).Error; err != nil {
+		return fmt.Errorf("failed to update event trade volume: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// fakeEventDriver 模拟数据库驱动：统计查询次数，并为每个事件返回翻译、2 个子事件、每个子事件 2 个方向
+type fakeEventDriver struct {
+	queries atomic.Int64
+}
+
+func (d *fakeEventDriver) Open(string) (driver.Conn, error) {
+	return &fakeEventConn{driver: d}, nil
+}
+
+type fakeEventConn struct {
+	driver *fakeEventDriver
+}
+
+func (c *fakeEventConn) Prepare(string) (driver.Stmt, error) {
+	return nil, fmt.Errorf("prepare not supported")
+}
+
+func (c *fakeEventConn) Close() error { return nil }
+
+func (c *fakeEventConn) Begin() (driver.Tx, error) {
+	return nil, fmt.Errorf("transactions not supported")
+}
+
+func (c *fakeEventConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.driver.queries.Add(1)
+
+	parents := make([]string, 0, len(args))
+	for _, arg := range args {
+		parents = append(parents, arg.Value.(string))
+	}
+
+	rows := &fakeEventRows{}
+	switch {
+	case strings.Contains(query, `FROM "event_language"`):
+		// 每个事件都有 zh 翻译，只有 event0 有 en 翻译
+		rows.columns = []string{"guid", "event_guid", "language_guid", "title", "rules"}
+		for _, eventGUID := range parents {
+			rows.values = append(rows.values, []driver.Value{"zh-" + eventGUID, eventGUID, "zh", "标题", "规则"})
+			if eventGUID == "event0" {
+				rows.values = append(rows.values, []driver.Value{"en-" + eventGUID, eventGUID, "en", "title", "rules"})
+			}
+		}
+	case strings.Contains(query, `FROM "sub_event_language"`):
+		// 参数依次为子事件 GUID 和语言 GUID；每个子事件都有 zh 翻译，只有 event0-sub0 有 en 翻译
+		rows.columns = []string{"guid", "sub_event_guid", "language_guid", "title"}
+		var subEventGUIDs, languageGUIDs []string
+		for _, parent := range parents {
+			if strings.Contains(parent, "-sub") {
+				subEventGUIDs = append(subEventGUIDs, parent)
+			} else {
+				languageGUIDs = append(languageGUIDs, parent)
+			}
+		}
+		for _, subEventGUID := range subEventGUIDs {
+			for _, languageGUID := range languageGUIDs {
+				if languageGUID == "zh" || (languageGUID == "en" && subEventGUID == "event0-sub0") {
+					rows.values = append(rows.values, []driver.Value{languageGUID + "-" + subEventGUID, subEventGUID, languageGUID, languageGUID + " sub"})
+				}
+			}
+		}
+	case strings.Contains(query, `FROM "sub_event_direction"`):
+		rows.columns = []string{"guid", "sub_event_guid", "direction", "chance"}
+		for _, subEventGUID := range parents {
+			rows.values = append(rows.values,
+				[]driver.Value{subEventGUID + "-yes", subEventGUID, "Yes", int64(50)},
+				[]driver.Value{subEventGUID + "-no", subEventGUID, "No", int64(50)},
+			)
+		}
+	case strings.Contains(query, `FROM "sub_event"`):
+		rows.columns = []string{"guid", "parent_event_guid", "title"}
+		for _, eventGUID := range parents {
+			for i := 0; i < 2; i++ {
+				rows.values = append(rows.values, []driver.Value{fmt.Sprintf("%s-sub%d", eventGUID, i), eventGUID, "sub"})
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unexpected query: %s", query)
+	}
+	return rows, nil
+}
+
+type fakeEventRows struct {
+	columns []string
+	values  [][]driver.Value
+	next    int
+}
+
+func (r *fakeEventRows) Columns() []string { return r.columns }
+
+func (r *fakeEventRows) Close() error { return nil }
+
+func (r *fakeEventRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.values) {
+		return io.EOF
+	}
+	copy(dest, r.values[r.next])
+	r.next++
+	return nil
+}
+
+func newFakeEventDB(t testing.TB) (*gorm.DB, *fakeEventDriver) {
+	t.Helper()
+	fake := &fakeEventDriver{}
+	driverName := fmt.Sprintf("fake-event-%p", fake)
+	sql.Register(driverName, fake)
+
+	sqlDB, err := sql.Open(driverName, "")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	db, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		DisableAutomaticPing: true,
+		Logger:               logger.Discard,
+	})
+	require.NoError(t, err)
+	return db, fake
+}
+
+func newTestEvents(n int) []Event {
+	events := make([]Event, 0, n)
+	for i := 0; i < n; i++ {
+		events = append(events, Event{GUID: fmt.Sprintf("event%d", i)})
+	}
+	return events
+}
+
+func TestLoadEventTrees(t *testing.T) {
+	db, fake := newFakeEventDB(t)
+	repo := NewEventRepository()
+
+	trees, err := repo.LoadEventTrees(db, newTestEvents(3), []string{"en"})
+	require.NoError(t, err)
+	require.Len(t, trees, 3)
+	for i, tree := range trees {
+		require.Equal(t, fmt.Sprintf("event%d", i), tree.Event.GUID)
+		require.NotNil(t, tree.Language)
+		require.Equal(t, tree.Event.GUID, tree.Language.EventGUID)
+		require.Len(t, tree.SubEvents, 2)
+		for _, subEvent := range tree.SubEvents {
+			require.Equal(t, tree.Event.GUID, subEvent.SubEvent.ParentEventGUID)
+			require.Len(t, subEvent.Directions, 2)
+			for _, direction := range subEvent.Directions {
+				require.Equal(t, subEvent.SubEvent.GUID, direction.SubEventGUID)
+			}
+		}
+	}
+	require.EqualValues(t, 4, fake.queries.Load())
+}
+
+func TestLoadEventTreesLanguageFallback(t *testing.T) {
+	db, _ := newFakeEventDB(t)
+	repo := NewEventRepository()
+
+	tests := []struct {
+		name          string
+		languageGUIDs []string
+		want          []string // 各事件实际返回的语言
+	}{
+		{name: "requested language first", languageGUIDs: []string{"en", "zh"}, want: []string{"en", "zh", "zh"}},
+		{name: "fallback to default", languageGUIDs: []string{"ja", "en"}, want: []string{"en", "zh", "zh"}},
+		{name: "fallback to any translation", languageGUIDs: []string{"ja"}, want: []string{"zh", "zh", "zh"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trees, err := repo.LoadEventTrees(db, newTestEvents(3), tt.languageGUIDs)
+			require.NoError(t, err)
+			require.Len(t, trees, len(tt.want))
+			for i, tree := range trees {
+				require.NotNil(t, tree.Language)
+				require.Equal(t, tt.want[i], tree.Language.LanguageGUID, tree.Event.GUID)
+			}
+		})
+	}
+}
+
+func TestLoadEventTreesSubEventLanguage(t *testing.T) {
+	db, fake := newFakeEventDB(t)
+	repo := NewEventRepository()
+
+	// 子事件使用事件所选翻译的语言，没有该语言的翻译时为 nil
+	trees, err := repo.LoadEventTrees(db, newTestEvents(2), []string{"en", "zh"})
+	require.NoError(t, err)
+	require.NotNil(t, trees[0].SubEvents[0].Language)
+	require.Equal(t, "en sub", trees[0].SubEvents[0].Language.Title)
+	require.Nil(t, trees[0].SubEvents[1].Language)
+	for _, subEvent := range trees[1].SubEvents {
+		require.NotNil(t, subEvent.Language)
+		require.Equal(t, "zh", subEvent.Language.LanguageGUID)
+	}
+
+	// 未指定语言时不查询子事件翻译
+	fake.queries.Store(0)
+	trees, err = repo.LoadEventTrees(db, newTestEvents(1), nil)
+	require.NoError(t, err)
+	require.Nil(t, trees[0].SubEvents[0].Language)
+	require.EqualValues(t, 3, fake.queries.Load())
+}
+
+func TestLoadEventTreesEmpty(t *testing.T) {
+	db, fake := newFakeEventDB(t)
+
+	trees, err := NewEventRepository().LoadEventTrees(db, nil, []string{"en"})
+	require.NoError(t, err)
+	require.Empty(t, trees)
+	require.Zero(t, fake.queries.Load())
+}
+
+func TestLoadEventTreesQueryCountIndependentOfPageSize(t *testing.T) {
+	repo := NewEventRepository()
+	for _, pageSize := range []int{1, 20, 100} {
+		db, fake := newFakeEventDB(t)
+		_, err := repo.LoadEventTrees(db, newTestEvents(pageSize), []string{"en"})
+		require.NoError(t, err)
+		require.EqualValues(t, 4, fake.queries.Load(), "page size %d", pageSize)
+	}
+}
+
+func BenchmarkLoadEventTrees(b *testing.B) {
+	repo := NewEventRepository()
+	for _, pageSize := range []int{10, 100} {
+		b.Run(fmt.Sprintf("events=%d", pageSize), func(b *testing.B) {
+			db, fake := newFakeEventDB(b)
+			events := newTestEvents(pageSize)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := repo.LoadEventTrees(db, events, []string{"en"}); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(fake.queries.Load())/float64(b.N), "queries/op")
+		})
+	}
+}
@@ -3,7 +3,12 @@ package database
 import (
 	"database/sql/driver"
 	"encoding/json"
+	"maps"
 	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/common/decimal"
 )
 
 // JSONB 自定义类型用于处理 PostgreSQL JSONB 字段
@@ -32,33 +37,130 @@ func (j *JSONB) Scan(value interface{}) error {
 
 // Event 事件表
 type Event struct {
-	GUID                 string    `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
-	CategoryGUID         string    `gorm:"type:varchar(500);not null" json:"category_guid"`
-	EcosystemGUID        string    `gorm:"type:varchar(500);not null" json:"ecosystem_guid"`
-	EventPeriodGUID      string    `gorm:"type:varchar(500);not null" json:"event_period_guid"`
-	MainTeamGroupGUID    string    `gorm:"type:varchar(255);not null" json:"main_team_group_guid"`
-	ClusterTeamGroupGUID string    `gorm:"type:varchar(255);not null" json:"cluster_team_group_guid"`
-	MainScore            string    `gorm:"type:numeric;not null" json:"main_score"`    // UINT256 mapped to string
-	ClusterScore         string    `gorm:"type:numeric;not null" json:"cluster_score"` // UINT256 mapped to string
-	Logo                 string    `gorm:"type:varchar(300);not null" json:"logo"`
-	OrderType            int16     `gorm:"type:smallint;not null;default:0" json:"order_type"`
-	OrderNum             string    `gorm:"type:numeric;not null" json:"order_num"` // UINT256 mapped to string
-	OpenTime             string    `gorm:"type:varchar(100);not null" json:"open_time"`
-	TradeVolume          float64   `gorm:"type:numeric(32,16);not null;default:0" json:"trade_volume"`
-	ExperimentResult     string    `gorm:"type:text;not null" json:"experiment_result"`
-	Info                 JSONB     `gorm:"type:jsonb;not null;default:'{}'" json:"info"`
-	IsOnline             bool      `gorm:"type:boolean;not null;default:false" json:"is_online"`
-	IsLive               int16     `gorm:"type:smallint;not null;default:0" json:"is_live"`
-	IsSports             bool      `gorm:"type:boolean;not null;default:true" json:"is_sports"`
-	Stage                string    `gorm:"type:varchar(20);not null;default:'Q1'" json:"stage"`
-	CreatedAt            time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt            time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+	GUID                 string          `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
+	CategoryGUID         string          `gorm:"type:varchar(500);not null" json:"category_guid"`
+	EcosystemGUID        string          `gorm:"type:varchar(500);not null" json:"ecosystem_guid"`
+	EventPeriodGUID      string          `gorm:"type:varchar(500);not null" json:"event_period_guid"`
+	MainTeamGroupGUID    string          `gorm:"type:varchar(255);not null" json:"main_team_group_guid"`
+	ClusterTeamGroupGUID string          `gorm:"type:varchar(255);not null" json:"cluster_team_group_guid"`
+	MainScore            string          `gorm:"type:numeric;not null" json:"main_score"`    // UINT256 mapped to string
+	ClusterScore         string          `gorm:"type:numeric;not null" json:"cluster_score"` // UINT256 mapped to string
+	Logo                 string          `gorm:"type:varchar(300);not null" json:"logo"`
+	OrderType            int16           `gorm:"type:smallint;not null;default:0" json:"order_type"`
+	OrderNum             string          `gorm:"type:numeric;not null" json:"order_num"` // UINT256 mapped to string
+	OpenTime             string          `gorm:"type:varchar(100);not null" json:"open_time"`
+	StartAt              *time.Time      `gorm:"type:timestamp(0)" json:"start_at"`                          // 开始时间，到达后进入进行中（未设置时取 open_at）
+	OpenAt               *time.Time      `gorm:"type:timestamp(0)" json:"open_at"`                           // 开盘时间，到达后自动上线
+	CloseAt              *time.Time      `gorm:"type:timestamp(0)" json:"close_at"`                          // 收盘时间，到达后停止交易
+	TradeVolume          decimal.Decimal `gorm:"type:numeric(38,18);not null;default:0" json:"trade_volume"` // 成交额，下单时累加
+	ExperimentResult     string          `gorm:"type:text;not null" json:"experiment_result"`
+	Info                 JSONB           `gorm:"type:jsonb;not null;default:'{}'" json:"info"`
+	IsOnline             bool            `gorm:"type:boolean;not null;default:false" json:"is_online"`
+	IsLive               int16           `gorm:"type:smallint;not null;default:0" json:"is_live"`
+	IsSports             bool            `gorm:"type:boolean;not null;default:true" json:"is_sports"`
+	Stage                string          `gorm:"type:varchar(20);not null;default:'Q1'" json:"stage"`
+	Sport                string          `gorm:"type:varchar(32);not null;default:''" json:"sport"` // 运动项目，决定比赛阶段的顺序（非运动类为空）
+	Status               string          `gorm:"type:varchar(20);not null;default:'draft'" json:"status"`
+	CreatedAt            time.Time       `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt            time.Time       `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+	DeletedAt            gorm.DeletedAt  `gorm:"type:timestamp(0)" json:"deleted_at"` // 软删除时间，NULL 表示未删除
 }
 
 func (Event) TableName() string {
 	return "event"
 }
 
+// 事件生命周期状态
+const (
+	EventStatusDraft     = "draft"     // 草稿，未上线
+	EventStatusUpcoming  = "upcoming"  // 已上线，未开始
+	EventStatusLive      = "live"      // 进行中
+	EventStatusEnded     = "ended"     // 已结束，等待结算
+	EventStatusResolved  = "resolved"  // 已结算
+	EventStatusCancelled = "cancelled" // 已取消
+)
+
+// eventStatusColumns 各状态对应的 is_online / is_live 取值，保持旧字段与生命周期状态一致
+var eventStatusColumns = map[string]map[string]interface{}{
+	EventStatusDraft:     {"is_online": false, "is_live": int16(1)},
+	EventStatusUpcoming:  {"is_online": true, "is_live": int16(1)},
+	EventStatusLive:      {"is_online": true, "is_live": int16(0)},
+	EventStatusEnded:     {"is_online": true, "is_live": int16(2)},
+	EventStatusResolved:  {"is_online": true, "is_live": int16(2)},
+	EventStatusCancelled: {"is_online": false, "is_live": int16(2)},
+}
+
+// EventStatusColumns 返回流转到 status 时需要同步更新的旧字段，未知状态返回 false
+func EventStatusColumns(status string) (map[string]interface{}, bool) {
+	columns, ok := eventStatusColumns[status]
+	return maps.Clone(columns), ok
+}
+
+// 事件调度时间点
+const (
+	EventScheduleOpen  = "open"  // 开盘时间 open_at
+	EventScheduleStart = "start" // 开始时间 start_at，未设置时取 open_at
+	EventScheduleClose = "close" // 收盘时间 close_at
+)
+
+// eventScheduleColumns 调度时间点对应的 SQL 表达式
+var eventScheduleColumns = map[string]string{
+	EventScheduleOpen:  "open_at",
+	EventScheduleStart: "COALESCE(start_at, open_at)",
+	EventScheduleClose: "close_at",
+}
+
+// EventStatusHistory 事件状态流转记录表
+type EventStatusHistory struct {
+	GUID       string    `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
+	EventGUID  string    `gorm:"type:varchar(500);not null" json:"event_guid"`
+	FromStatus string    `gorm:"type:varchar(20);not null" json:"from_status"`
+	ToStatus   string    `gorm:"type:varchar(20);not null" json:"to_status"`
+	Actor      string    `gorm:"type:varchar(255);not null" json:"actor"`
+	Reason     string    `gorm:"type:varchar(500);not null;default:''" json:"reason"`
+	CreatedAt  time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt  time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (EventStatusHistory) TableName() string {
+	return "event_status_history"
+}
+
+// EventScoreHistory 事件比分变更记录表：记录比分源每次推送带来的比分或阶段变化
+type EventScoreHistory struct {
+	GUID             string    `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
+	EventGUID        string    `gorm:"type:varchar(500);not null" json:"event_guid"`
+	FromMainScore    string    `gorm:"type:numeric;not null" json:"from_main_score"`        // 变更前主队得分
+	FromClusterScore string    `gorm:"type:numeric;not null" json:"from_cluster_score"`     // 变更前客队得分
+	FromStage        string    `gorm:"type:varchar(20);not null" json:"from_stage"`         // 变更前比赛阶段
+	MainScore        string    `gorm:"type:numeric;not null" json:"main_score"`             // 变更后主队得分
+	ClusterScore     string    `gorm:"type:numeric;not null" json:"cluster_score"`          // 变更后客队得分
+	Stage            string    `gorm:"type:varchar(20);not null" json:"stage"`              // 变更后比赛阶段
+	Source           string    `gorm:"type:varchar(100);not null;default:''" json:"source"` // 比分源
+	CreatedAt        time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (EventScoreHistory) TableName() string {
+	return "event_score_history"
+}
+
+// EventResolution 事件结算记录表
+type EventResolution struct {
+	GUID        string    `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
+	EventGUID   string    `gorm:"type:varchar(500);not null" json:"event_guid"`
+	Actor       string    `gorm:"type:varchar(255);not null" json:"actor"`
+	Result      string    `gorm:"type:text;not null;default:''" json:"result"`
+	Reason      string    `gorm:"type:varchar(500);not null;default:''" json:"reason"`
+	IsReResolve bool      `gorm:"type:boolean;not null;default:false" json:"is_re_resolve"`
+	Detail      JSONB     `gorm:"type:jsonb;not null;default:'{}'" json:"detail"`
+	CreatedAt   time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (EventResolution) TableName() string {
+	return "event_resolution"
+}
+
 // EventLanguage 事件多语言表
 type EventLanguage struct {
 	GUID         string    `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
@@ -76,19 +178,29 @@ func (EventLanguage) TableName() string {
 
 // SubEvent 事件子表
 type SubEvent struct {
-	GUID            string    `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
-	ParentEventGUID string    `gorm:"type:varchar(500);not null;index:idx_sub_event_parent_event_guid" json:"parent_event_guid"`
-	Title           string    `gorm:"type:varchar(200);not null" json:"title"`
-	Logo            string    `gorm:"type:varchar(300);not null" json:"logo"`
-	TradeVolume     float64   `gorm:"type:numeric(32,16);not null;default:0" json:"trade_volume"`
-	CreatedAt       time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt       time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+	GUID            string          `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
+	ParentEventGUID string          `gorm:"type:varchar(500);not null;index:idx_sub_event_parent_event_guid" json:"parent_event_guid"`
+	Title           string          `gorm:"type:varchar(200);not null" json:"title"`
+	Logo            string          `gorm:"type:varchar(300);not null" json:"logo"`
+	TradeVolume     decimal.Decimal `gorm:"type:numeric(38,18);not null;default:0" json:"trade_volume"`    // 成交额，下单时累加
+	Resolution      string          `gorm:"type:varchar(20);not null;default:'pending'" json:"resolution"` // pending / resolved / void
+	EndAt           *time.Time      `gorm:"type:timestamp(0)" json:"end_at"`                               // 子事件截止时间
+	Liquidity       float64         `gorm:"type:numeric(32,16);not null;default:100" json:"liquidity"`     // LMSR 做市流动性参数 b
+	CreatedAt       time.Time       `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt       time.Time       `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
 }
 
 func (SubEvent) TableName() string {
 	return "sub_event"
 }
 
+// 子事件结算状态
+const (
+	SubEventResolutionPending  = "pending"  // 待结算
+	SubEventResolutionResolved = "resolved" // 已结算
+	SubEventResolutionVoid     = "void"     // 作废，全部方向不计胜负
+)
+
 // SubEventLanguage 子事件多语言表
 type SubEventLanguage struct {
 	GUID         string    `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
@@ -109,9 +221,11 @@ type SubEventDirection struct {
 	SubEventGUID string    `gorm:"type:varchar(500);not null;index:idx_sub_event_direction_sub_event_guid" json:"sub_event_guid"`
 	Direction    string    `gorm:"type:varchar(200);not null;default:'Yes'" json:"direction"`
 	Chance       int16     `gorm:"type:smallint;not null" json:"chance"`
-	NewAskPrice  string    `gorm:"type:numeric;not null;default:'0'" json:"new_ask_price"` // UINT256 mapped to string
-	NewBidPrice  string    `gorm:"type:numeric;not null;default:'0'" json:"new_bid_price"` // UINT256 mapped to string
+	NewAskPrice  string    `gorm:"type:numeric;not null;default:'0'" json:"new_ask_price"` // 买入 1 份的均价 (0,1)，由做市商计算
+	NewBidPrice  string    `gorm:"type:numeric;not null;default:'0'" json:"new_bid_price"` // 卖出 1 份的均价 (0,1)，由做市商计算
+	Shares       float64   `gorm:"type:numeric(32,16);not null;default:0" json:"shares"`   // 做市商已售出的份额 q
 	Info         JSONB     `gorm:"type:jsonb;not null;default:'{}'" json:"info"`
+	IsWin        bool      `gorm:"type:boolean;not null;default:false" json:"is_win"`
 	CreatedAt    time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
 	UpdatedAt    time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
 }
@@ -120,17 +234,68 @@ func (SubEventDirection) TableName() string {
 	return "sub_event_direction"
 }
 
-// SubEventChanceStat 子事件概率统计表
+// SubEventChanceStat 子事件概率统计表：按统计周期降采样的方向概率快照，同一时间桶只保留最后一次采样
 type SubEventChanceStat struct {
-	GUID         string    `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
-	SubEventGUID string    `gorm:"type:varchar(500);not null;index:idx_sub_event_chance_stat_sub_event_guid" json:"sub_event_guid"`
-	Chance       int16     `gorm:"type:smallint;not null" json:"chance"`
-	Datetime     string    `gorm:"type:varchar(500);not null" json:"datetime"`
-	StatWay      int16     `gorm:"type:smallint;not null" json:"stat_way"` // 0:1h; 2:6h; 3:1d; 4:1w; 5:All
-	CreatedAt    time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
-	UpdatedAt    time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+	GUID          string    `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
+	SubEventGUID  string    `gorm:"type:varchar(500);not null;index:idx_sub_event_chance_stat_sub_event_guid" json:"sub_event_guid"`
+	DirectionGUID string    `gorm:"type:varchar(500);not null" json:"direction_guid"`
+	Chance        int16     `gorm:"type:smallint;not null" json:"chance"`
+	Datetime      string    `gorm:"type:varchar(500);not null" json:"datetime"` // 时间桶起点，RFC3339 (UTC)
+	StatWay       int16     `gorm:"type:smallint;not null" json:"stat_way"`     // 0:1h; 2:6h; 3:1d; 4:1w; 5:All
+	CreatedAt     time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt     time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
 }
 
 func (SubEventChanceStat) TableName() string {
 	return "sub_event_chance_stat"
 }
+
+// 概率统计周期 (stat_way)
+const (
+	ChanceStatWay1h  int16 = 0
+	ChanceStatWay6h  int16 = 2
+	ChanceStatWay1d  int16 = 3
+	ChanceStatWay1w  int16 = 4
+	ChanceStatWayAll int16 = 5
+)
+
+// ChanceStatWindow 概率走势的时间窗口：窗口长度及其采样间隔
+type ChanceStatWindow struct {
+	Name     string        // 窗口名：1h、6h、1d、1w、all
+	StatWay  int16         // 对应的 stat_way
+	Window   time.Duration // 窗口长度，0 表示全部历史
+	Interval time.Duration // 采样间隔（时间桶大小）
+}
+
+// ChanceStatWindows 支持的时间窗口，每个窗口约 60~170 个点
+var ChanceStatWindows = []ChanceStatWindow{
+	{Name: "1h", StatWay: ChanceStatWay1h, Window: time.Hour, Interval: time.Minute},
+	{Name: "6h", StatWay: ChanceStatWay6h, Window: 6 * time.Hour, Interval: 5 * time.Minute},
+	{Name: "1d", StatWay: ChanceStatWay1d, Window: 24 * time.Hour, Interval: 15 * time.Minute},
+	{Name: "1w", StatWay: ChanceStatWay1w, Window: 7 * 24 * time.Hour, Interval: time.Hour},
+	{Name: "all", StatWay: ChanceStatWayAll, Interval: 24 * time.Hour},
+}
+
+// LookupChanceStatWindow 按窗口名查找时间窗口
+func LookupChanceStatWindow(name string) (ChanceStatWindow, bool) {
+	for _, window := range ChanceStatWindows {
+		if window.Name == name {
+			return window, true
+		}
+	}
+	return ChanceStatWindow{}, false
+}
+
+// Bucket 返回 t 所在时间桶的起点 (UTC)
+func (w ChanceStatWindow) Bucket(t time.Time) time.Time {
+	return t.UTC().Truncate(w.Interval)
+}
+
+// Since 返回窗口内最早的时间桶起点，全部历史时返回 nil
+func (w ChanceStatWindow) Since(now time.Time) *time.Time {
+	if w.Window == 0 {
+		return nil
+	}
+	since := w.Bucket(now.Add(-w.Window))
+	return &since
+}
@@ -0,0 +1,18 @@
+package database
+
+import "time"
+
+// IdempotencyKey 幂等键表
+type IdempotencyKey struct {
+	GUID           string    `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
+	Scope          string    `gorm:"type:varchar(64);not null" json:"scope"`
+	IdempotencyKey string    `gorm:"type:varchar(255);not null" json:"idempotency_key"`
+	RequestHash    string    `gorm:"type:varchar(64);not null" json:"request_hash"`
+	Response       string    `gorm:"type:text;not null;default:''" json:"response"`
+	CreatedAt      time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP;index:idx_idempotency_key_created_at" json:"created_at"`
+	UpdatedAt      time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (IdempotencyKey) TableName() string {
+	return "idempotency_key"
+}
@@ -0,0 +1,78 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IdempotencyRepository 幂等键数据库操作接口
+type IdempotencyRepository interface {
+	// ClaimIdempotencyKey 占用幂等键，返回是否占用成功；键已存在时返回 false 及已有记录
+	// 需在业务事务中调用：并发的相同键会等待先占用的事务提交或回滚
+	ClaimIdempotencyKey(db *gorm.DB, scope, key, requestHash string) (bool, *IdempotencyKey, error)
+	// SaveIdempotencyResponse 保存首次请求的响应
+	SaveIdempotencyResponse(db *gorm.DB, scope, key, response string) error
+	// PurgeExpired 删除创建超过 ttl 的幂等键，每次最多 limit 条，返回删除的记录数
+	PurgeExpired(db *gorm.DB, ttl time.Duration, limit int) (int, error)
+}
+
+type idempotencyRepository struct{}
+
+// NewIdempotencyRepository 创建幂等键仓储实例
+func NewIdempotencyRepository() IdempotencyRepository {
+	return &idempotencyRepository{}
+}
+
+// ClaimIdempotencyKey 占用幂等键（INSERT ... ON CONFLICT DO NOTHING）
+func (r *idempotencyRepository) ClaimIdempotencyKey(db *gorm.DB, scope, key, requestHash string) (bool, *IdempotencyKey, error) {
+	record := &IdempotencyKey{
+		Scope:          scope,
+		IdempotencyKey: key,
+		RequestHash:    requestHash,
+	}
+	result := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "scope"}, {Name: "idempotency_key"}},
+		DoNothing: true,
+	}).Create(record)
+	if result.Error != nil {
+		return false, nil, fmt.Errorf("failed to claim idempotency key: %w", result.Error)
+	}
+	if result.RowsAffected > 0 {
+		return true, record, nil
+	}
+
+	var existing IdempotencyKey
+	if err := db.Where("scope = ? AND idempotency_key = ?", scope, key).First(&existing).Error; err != nil {
+		return false, nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+	return false, &existing, nil
+}
+
+// SaveIdempotencyResponse 保存首次请求的响应
+func (r *idempotencyRepository) SaveIdempotencyResponse(db *gorm.DB, scope, key, response string) error {
+	if err := db.Model(&IdempotencyKey{}).
+		Where("scope = ? AND idempotency_key = ?", scope, key).
+		Updates(map[string]interface{}{
+			"response":   response,
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		}).Error; err != nil {
+		return fmt.Errorf("failed to save idempotency response: %w", err)
+	}
+	return nil
+}
+
+// PurgeExpired 删除创建超过 ttl 的幂等键；跳过被进行中的请求锁定的记录
+func (r *idempotencyRepository) PurgeExpired(db *gorm.DB, ttl time.Duration, limit int) (int, error) {
+	var guids []string
+	err := db.Raw(`DELETE FROM idempotency_key WHERE guid IN (
+			SELECT guid FROM idempotency_key WHERE created_at < CURRENT_TIMESTAMP - make_interval(secs => ?) ORDER BY created_at ASC, guid ASC LIMIT ? FOR UPDATE SKIP LOCKED
+		) RETURNING guid`, ttl.Seconds(), limit).
+		Scan(&guids).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge idempotency keys: %w", err)
+	}
+	return len(guids), nil
+}
@@ -0,0 +1,244 @@
+package database
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// defaultLanguageLockKey 切换默认语言时使用的事务级咨询锁
+const defaultLanguageLockKey = "languages_default"
+
+// MissingTranslations 缺少某语言翻译的记录：总数及按创建时间排列的前若干个 GUID
+type MissingTranslations struct {
+	Total int64
+	GUIDs []string
+}
+
+// TranslationGaps 事件、分类、运动队缺少某语言翻译的情况
+type TranslationGaps struct {
+	Events     MissingTranslations
+	Categories MissingTranslations
+	TeamGroups MissingTranslations
+}
+
+// LanguageRepository 语言数据库操作接口
+type LanguageRepository interface {
+	// GetActiveLanguagesByGUIDs 获取给定 GUID 中已启用的语言
+	GetActiveLanguagesByGUIDs(db *gorm.DB, languageGUIDs []string) ([]Languages, error)
+	// GetDefaultLanguage 获取已启用的默认语言，未配置时返回 nil
+	GetDefaultLanguage(db *gorm.DB) (*Languages, error)
+	// ListActiveLanguages 获取全部已启用的语言
+	ListActiveLanguages(db *gorm.DB) ([]Languages, error)
+	// ListLanguages 获取全部语言（包含停用的语言）
+	ListLanguages(db *gorm.DB) ([]Languages, error)
+	// CreateLanguage 创建语言
+	CreateLanguage(db *gorm.DB, language *Languages) error
+	// GetLanguage 获取语言，不存在时返回 nil
+	GetLanguage(db *gorm.DB, languageGUID string) (*Languages, error)
+	// LockLanguage 加行锁获取语言，不存在时返回 nil
+	LockLanguage(db *gorm.DB, languageGUID string) (*Languages, error)
+	// GetLanguageByName 按语言代码（不区分大小写）获取语言（包含已软删除的语言），不存在时返回 nil
+	GetLanguageByName(db *gorm.DB, languageName string) (*Languages, error)
+	// UpdateLanguage 更新语言字段并刷新 updated_at
+	UpdateLanguage(db *gorm.DB, languageGUID string, columns map[string]interface{}) error
+	// DeleteLanguage 软删除语言，保留各表中该语言的翻译以便恢复
+	DeleteLanguage(db *gorm.DB, languageGUID string) error
+	// LockDeletedLanguage 加行锁获取已软删除的语言，不存在或未删除时返回 nil
+	LockDeletedLanguage(db *gorm.DB, languageGUID string) (*Languages, error)
+	// RestoreLanguage 恢复已软删除的语言并刷新 updated_at
+	RestoreLanguage(db *gorm.DB, languageGUID string) error
+	// SetDefaultLanguage 将语言设为唯一的默认语言，其余语言取消默认
+	SetDefaultLanguage(db *gorm.DB, languageGUID string) error
+	// GetTranslationGaps 统计缺少某语言翻译的事件、分类、运动队，每类最多返回 limit 个 GUID
+	GetTranslationGaps(db *gorm.DB, languageGUID string, limit int) (*TranslationGaps, error)
+}
+
+type languageRepository struct{}
+
+// NewLanguageRepository 创建语言仓储实例
+func NewLanguageRepository() LanguageRepository {
+	return &languageRepository{}
+}
+
+// GetActiveLanguagesByGUIDs 获取给定 GUID 中已启用的语言
+func (r *languageRepository) GetActiveLanguagesByGUIDs(db *gorm.DB, languageGUIDs []string) ([]Languages, error) {
+	if len(languageGUIDs) == 0 {
+		return nil, nil
+	}
+	var languages []Languages
+	if err := db.Where("guid IN ? AND is_active = ?", languageGUIDs, true).Find(&languages).Error; err != nil {
+		return nil, fmt.Errorf("failed to get languages: %w", err)
+	}
+	return languages, nil
+}
+
+// GetDefaultLanguage 获取已启用的默认语言，未配置时返回 nil
+func (r *languageRepository) GetDefaultLanguage(db *gorm.DB) (*Languages, error) {
+	var languages []Languages
+	if err := db.Where("is_default = ? AND is_active = ?", true, true).Limit(1).Find(&languages).Error; err != nil {
+		return nil, fmt.Errorf("failed to get default language: %w", err)
+	}
+	if len(languages) == 0 {
+		return nil, nil
+	}
+	return &languages[0], nil
+}
+
+// ListActiveLanguages 获取全部已启用的语言
+func (r *languageRepository) ListActiveLanguages(db *gorm.DB) ([]Languages, error) {
+	var languages []Languages
+	if err := db.Where("is_active = ?", true).Order("language_name ASC").Find(&languages).Error; err != nil {
+		return nil, fmt.Errorf("failed to list languages: %w", err)
+	}
+	return languages, nil
+}
+
+// ListLanguages 获取全部语言，默认语言在前，其余按语言代码升序
+func (r *languageRepository) ListLanguages(db *gorm.DB) ([]Languages, error) {
+	var languages []Languages
+	if err := db.Order("is_default DESC, language_name ASC").Find(&languages).Error; err != nil {
+		return nil, fmt.Errorf("failed to list languages: %w", err)
+	}
+	return languages, nil
+}
+
+// CreateLanguage 创建语言，GUID 通过 RETURNING 回填
+func (r *languageRepository) CreateLanguage(db *gorm.DB, language *Languages) error {
+	if err := db.Clauses(clause.Returning{}).Create(language).Error; err != nil {
+		return fmt.Errorf("failed to create language: %w", err)
+	}
+	return nil
+}
+
+// GetLanguage 获取语言，不存在时返回 nil
+func (r *languageRepository) GetLanguage(db *gorm.DB, languageGUID string) (*Languages, error) {
+	var languages []Languages
+	if err := db.Where("guid = ?", languageGUID).Limit(1).Find(&languages).Error; err != nil {
+		return nil, fmt.Errorf("failed to get language: %w", err)
+	}
+	if len(languages) == 0 {
+		return nil, nil
+	}
+	return &languages[0], nil
+}
+
+// LockLanguage 加行锁获取语言，不存在时返回 nil
+func (r *languageRepository) LockLanguage(db *gorm.DB, languageGUID string) (*Languages, error) {
+	return r.GetLanguage(db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}), languageGUID)
+}
+
+// GetLanguageByName 按语言代码（不区分大小写）获取语言，不存在时返回 nil
+// language_name 的唯一索引包含已软删除的语言，因此查询不排除已删除的记录
+func (r *languageRepository) GetLanguageByName(db *gorm.DB, languageName string) (*Languages, error) {
+	var languages []Languages
+	if err := db.Unscoped().Where("LOWER(language_name) = LOWER(?)", languageName).Limit(1).Find(&languages).Error; err != nil {
+		return nil, fmt.Errorf("failed to get language by name: %w", err)
+	}
+	if len(languages) == 0 {
+		return nil, nil
+	}
+	return &languages[0], nil
+}
+
+// UpdateLanguage 更新语言字段并刷新 updated_at
+func (r *languageRepository) UpdateLanguage(db *gorm.DB, languageGUID string, columns map[string]interface{}) error {
+	updates := make(map[string]interface{}, len(columns)+1)
+	for column, value := range columns {
+		updates[column] = value
+	}
+	updates["updated_at"] = gorm.Expr("CURRENT_TIMESTAMP")
+
+	if err := db.Model(&Languages{}).Where("guid = ?", languageGUID).UpdateColumns(updates).Error; err != nil {
+		return fmt.Errorf("failed to update language: %w", err)
+	}
+	return nil
+}
+
+// DeleteLanguage 软删除语言，各表中该语言的翻译保留到清理任务硬删除时一并删除
+func (r *languageRepository) DeleteLanguage(db *gorm.DB, languageGUID string) error {
+	if err := softDelete[Languages](db, languageGUID); err != nil {
+		return fmt.Errorf("failed to delete language: %w", err)
+	}
+	return nil
+}
+
+// LockDeletedLanguage 加行锁获取已软删除的语言，不存在或未删除时返回 nil
+func (r *languageRepository) LockDeletedLanguage(db *gorm.DB, languageGUID string) (*Languages, error) {
+	deleted, err := lockDeleted[Languages](db, languageGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted language: %w", err)
+	}
+	return deleted, nil
+}
+
+// RestoreLanguage 恢复已软删除的语言并刷新 updated_at
+func (r *languageRepository) RestoreLanguage(db *gorm.DB, languageGUID string) error {
+	if err := restoreDeleted[Languages](db, languageGUID); err != nil {
+		return fmt.Errorf("failed to restore language: %w", err)
+	}
+	return nil
+}
+
+// SetDefaultLanguage 在咨询锁保护下先取消原默认语言再设置新的默认语言，
+// 并发切换时依次执行，uq_languages_single_default 唯一索引兜底保证只有一个默认语言
+func (r *languageRepository) SetDefaultLanguage(db *gorm.DB, languageGUID string) error {
+	if err := db.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", defaultLanguageLockKey).Error; err != nil {
+		return fmt.Errorf("failed to lock default language: %w", err)
+	}
+	err := db.Model(&Languages{}).Where("is_default = ? AND guid <> ?", true, languageGUID).
+		UpdateColumns(map[string]interface{}{
+			"is_default": false,
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to clear default language: %w", err)
+	}
+	err = db.Model(&Languages{}).Where("guid = ? AND is_default = ?", languageGUID, false).
+		UpdateColumns(map[string]interface{}{
+			"is_default": true,
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to set default language: %w", err)
+	}
+	return nil
+}
+
+// GetTranslationGaps 统计缺少某语言翻译的事件、分类、运动队，每类最多返回 limit 个 GUID
+func (r *languageRepository) GetTranslationGaps(db *gorm.DB, languageGUID string, limit int) (*TranslationGaps, error) {
+	var gaps TranslationGaps
+	targets := []struct {
+		name   string
+		owner  interface{}
+		filter string
+		result *MissingTranslations
+	}{
+		{"events", &Event{},
+			"NOT EXISTS (SELECT 1 FROM event_language l WHERE l.event_guid = event.guid AND l.language_guid = ?)",
+			&gaps.Events},
+		{"categories", &Category{},
+			"NOT EXISTS (SELECT 1 FROM category_language l WHERE l.category_guid = category.guid AND l.language_guid = ?)",
+			&gaps.Categories},
+		{"team groups", &TeamGroup{},
+			"NOT EXISTS (SELECT 1 FROM team_group_language l WHERE l.team_group_guid = team_group.guid AND l.language_guid = ?)",
+			&gaps.TeamGroups},
+	}
+	for _, target := range targets {
+		query := db.Model(target.owner).Where(target.filter, languageGUID)
+		if err := query.Count(&target.result.Total).Error; err != nil {
+			return nil, fmt.Errorf("failed to count %s missing translations: %w", target.name, err)
+		}
+		target.result.GUIDs = []string{}
+		if target.result.Total == 0 {
+			continue
+		}
+		err := db.Model(target.owner).Where(target.filter, languageGUID).
+			Order("created_at ASC, guid ASC").Limit(limit).Pluck("guid", &target.result.GUIDs).Error
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s missing translations: %w", target.name, err)
+		}
+	}
+	return &gaps, nil
+}
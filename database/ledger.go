@@ -0,0 +1,112 @@
+package database
+
+import (
+	"time"
+
+	"github.com/multimarket-labs/event-pod-services/common/decimal"
+)
+
+// 订单方向
+const (
+	OrderSideBuy  = "buy"  // 买入
+	OrderSideSell = "sell" // 卖出
+)
+
+// 订单状态：订单直接与做市商成交，下单成功即全部成交
+const (
+	OrderStatusFilled = "filled" // 已成交
+)
+
+// 持仓状态
+const (
+	PositionStatusOpen    = "open"    // 持仓中
+	PositionStatusSettled = "settled" // 已结算（子事件结算后派彩）
+)
+
+// UserBalance 用户余额表：金额以 NUMERIC(38,18) 精确记账
+type UserBalance struct {
+	GUID        string          `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
+	UserAddress string          `gorm:"type:varchar(100);not null;uniqueIndex:uq_user_balance_user_address" json:"user_address"` // 钱包地址（小写）
+	Available   decimal.Decimal `gorm:"type:numeric(38,18);not null;default:0" json:"available"`                                 // 可用余额
+	CreatedAt   time.Time       `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt   time.Time       `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (UserBalance) TableName() string {
+	return "user_balance"
+}
+
+// BalanceCredit 后台入账记录表：记录每次入账的金额和操作人
+type BalanceCredit struct {
+	GUID        string          `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
+	UserAddress string          `gorm:"type:varchar(100);not null;index:idx_balance_credit_user_address" json:"user_address"` // 钱包地址（小写）
+	Amount      decimal.Decimal `gorm:"type:numeric(38,18);not null" json:"amount"`                                           // 入账金额
+	Operator    string          `gorm:"type:varchar(100);not null;index:idx_balance_credit_operator" json:"operator"`         // 操作人
+	CreatedAt   time.Time       `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (BalanceCredit) TableName() string {
+	return "balance_credit"
+}
+
+// TradeOrder 订单表
+type TradeOrder struct {
+	GUID          string           `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
+	UserAddress   string           `gorm:"type:varchar(100);not null;index:idx_trade_order_user_address" json:"user_address"`
+	EventGUID     string           `gorm:"type:varchar(500);not null" json:"event_guid"`
+	SubEventGUID  string           `gorm:"type:varchar(500);not null" json:"sub_event_guid"`
+	DirectionGUID string           `gorm:"type:varchar(500);not null" json:"direction_guid"`
+	Side          string           `gorm:"type:varchar(10);not null" json:"side"`                    // buy / sell
+	Shares        decimal.Decimal  `gorm:"type:numeric(38,18);not null" json:"shares"`               // 份额
+	LimitPrice    *decimal.Decimal `gorm:"type:numeric(38,18)" json:"limit_price"`                   // 限价：买入均价上限 / 卖出均价下限
+	Amount        decimal.Decimal  `gorm:"type:numeric(38,18);not null" json:"amount"`               // 买入支付 / 卖出所得
+	AveragePrice  decimal.Decimal  `gorm:"type:numeric(38,18);not null" json:"average_price"`        // 成交均价
+	Status        string           `gorm:"type:varchar(20);not null;default:'filled'" json:"status"` // 订单状态
+	CreatedAt     time.Time        `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt     time.Time        `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (TradeOrder) TableName() string {
+	return "trade_order"
+}
+
+// TradeFill 成交表：记录每笔成交及成交前后的价格
+type TradeFill struct {
+	GUID          string          `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
+	OrderGUID     string          `gorm:"type:varchar(500);not null;index:idx_trade_fill_order_guid" json:"order_guid"`
+	UserAddress   string          `gorm:"type:varchar(100);not null" json:"user_address"`
+	SubEventGUID  string          `gorm:"type:varchar(500);not null" json:"sub_event_guid"`
+	DirectionGUID string          `gorm:"type:varchar(500);not null" json:"direction_guid"`
+	Side          string          `gorm:"type:varchar(10);not null" json:"side"`
+	Shares        decimal.Decimal `gorm:"type:numeric(38,18);not null" json:"shares"`
+	Price         decimal.Decimal `gorm:"type:numeric(38,18);not null" json:"price"`        // 成交均价
+	Amount        decimal.Decimal `gorm:"type:numeric(38,18);not null" json:"amount"`       // 成交金额
+	PriceBefore   decimal.Decimal `gorm:"type:numeric(38,18);not null" json:"price_before"` // 成交前该方向的价格
+	PriceAfter    decimal.Decimal `gorm:"type:numeric(38,18);not null" json:"price_after"`  // 成交后该方向的价格
+	CreatedAt     time.Time       `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (TradeFill) TableName() string {
+	return "trade_fill"
+}
+
+// Position 持仓表：每个用户在每个方向上一条记录，表名避开 SQL 关键字 position
+type Position struct {
+	GUID          string          `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
+	UserAddress   string          `gorm:"type:varchar(100);not null" json:"user_address"`
+	EventGUID     string          `gorm:"type:varchar(500);not null" json:"event_guid"`
+	SubEventGUID  string          `gorm:"type:varchar(500);not null;index:idx_user_position_sub_event_guid" json:"sub_event_guid"`
+	DirectionGUID string          `gorm:"type:varchar(500);not null" json:"direction_guid"`
+	Shares        decimal.Decimal `gorm:"type:numeric(38,18);not null;default:0" json:"shares"`       // 持有份额
+	CostBasis     decimal.Decimal `gorm:"type:numeric(38,18);not null;default:0" json:"cost_basis"`   // 持有份额的买入成本
+	RealizedPnl   decimal.Decimal `gorm:"type:numeric(38,18);not null;default:0" json:"realized_pnl"` // 卖出已实现盈亏
+	Payout        decimal.Decimal `gorm:"type:numeric(38,18);not null;default:0" json:"payout"`       // 结算派彩
+	Status        string          `gorm:"type:varchar(20);not null;default:'open'" json:"status"`     // open / settled
+	SettledAt     *time.Time      `gorm:"type:timestamp(0)" json:"settled_at"`
+	CreatedAt     time.Time       `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt     time.Time       `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (Position) TableName() string {
+	return "user_position"
+}
@@ -0,0 +1,217 @@
+package database
+
+import (
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/multimarket-labs/event-pod-services/common/decimal"
+)
+
+// LedgerRepository 订单、成交、持仓和余额数据库操作接口
+type LedgerRepository interface {
+	// GetBalance 获取用户余额，不存在时返回 nil
+	GetBalance(db *gorm.DB, userAddress string) (*UserBalance, error)
+	// CreditBalance 增加用户余额（amount 可为负数，用于重新结算时冲正），余额记录不存在时创建
+	CreditBalance(db *gorm.DB, userAddress string, amount decimal.Decimal) (*UserBalance, error)
+	// DebitBalance 仅当可用余额不少于 amount 时扣减，返回扣减后的余额；余额不足或不存在时返回 nil
+	DebitBalance(db *gorm.DB, userAddress string, amount decimal.Decimal) (*UserBalance, error)
+	// CreateBalanceCredit 记录一次后台入账
+	CreateBalanceCredit(db *gorm.DB, credit *BalanceCredit) error
+	// CreateOrder 创建订单
+	CreateOrder(db *gorm.DB, order *TradeOrder) error
+	// CreateFill 创建成交记录
+	CreateFill(db *gorm.DB, fill *TradeFill) error
+	// ListOrders 按时间倒序分页获取用户的订单
+	ListOrders(db *gorm.DB, userAddress string, page, limit int) ([]TradeOrder, int64, error)
+	// LockPosition 加行锁获取用户在某方向上的持仓，不存在时返回 nil
+	LockPosition(db *gorm.DB, userAddress, directionGUID string) (*Position, error)
+	// LockSubEventPositions 加行锁获取子事件下的全部持仓
+	LockSubEventPositions(db *gorm.DB, subEventGUID string) ([]Position, error)
+	// SavePosition 保存持仓，GUID 为空时创建
+	SavePosition(db *gorm.DB, position *Position) error
+	// ListPositions 获取用户的持仓，status 为空时返回全部
+	ListPositions(db *gorm.DB, userAddress, status string) ([]Position, error)
+	// HasEventPositions 判断事件下是否有持仓（包括已卖出或已结算的持仓）
+	HasEventPositions(db *gorm.DB, eventGUID string) (bool, error)
+}
+
+type ledgerRepository struct{}
+
+// NewLedgerRepository 创建账本仓储实例
+func NewLedgerRepository() LedgerRepository {
+	return &ledgerRepository{}
+}
+
+// GetBalance 获取用户余额，不存在时返回 nil
+func (r *ledgerRepository) GetBalance(db *gorm.DB, userAddress string) (*UserBalance, error) {
+	var balance UserBalance
+	err := db.Where("user_address = ?", userAddress).First(&balance).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance: %w", err)
+	}
+	return &balance, nil
+}
+
+// CreditBalance 增加用户余额，余额记录不存在时创建（INSERT ... ON CONFLICT DO UPDATE）
+func (r *ledgerRepository) CreditBalance(db *gorm.DB, userAddress string, amount decimal.Decimal) (*UserBalance, error) {
+	balance := &UserBalance{UserAddress: userAddress, Available: amount}
+	err := db.Clauses(
+		clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_address"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"available":  gorm.Expr("user_balance.available + excluded.available"),
+				"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+			}),
+		},
+		clause.Returning{},
+	).Select("user_address", "available").Create(balance).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to credit balance: %w", err)
+	}
+	return balance, nil
+}
+
+// DebitBalance 仅当可用余额不少于 amount 时扣减，返回扣减后的余额；余额不足或不存在时返回 nil
+func (r *ledgerRepository) DebitBalance(db *gorm.DB, userAddress string, amount decimal.Decimal) (*UserBalance, error) {
+	var balances []UserBalance
+	result := db.Model(&balances).Clauses(clause.Returning{}).
+		Where("user_address = ? AND available >= ?", userAddress, amount).
+		UpdateColumns(map[string]interface{}{
+			"available":  gorm.Expr("available - ?", amount),
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		})
+	if result.Error != nil {
+		return nil, fmt.Errorf("failed to debit balance: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return nil, nil
+	}
+	return &balances[0], nil
+}
+
+// CreateBalanceCredit 记录一次后台入账，GUID 通过 RETURNING 回填
+func (r *ledgerRepository) CreateBalanceCredit(db *gorm.DB, credit *BalanceCredit) error {
+	if err := db.Clauses(clause.Returning{}).Create(credit).Error; err != nil {
+		return fmt.Errorf("failed to create balance credit: %w", err)
+	}
+	return nil
+}
+
+// CreateOrder 创建订单，GUID 通过 RETURNING 回填
+func (r *ledgerRepository) CreateOrder(db *gorm.DB, order *TradeOrder) error {
+	if err := db.Clauses(clause.Returning{}).Create(order).Error; err != nil {
+		return fmt.Errorf("failed to create order: %w", err)
+	}
+	return nil
+}
+
+// CreateFill 创建成交记录，GUID 通过 RETURNING 回填
+func (r *ledgerRepository) CreateFill(db *gorm.DB, fill *TradeFill) error {
+	if err := db.Clauses(clause.Returning{}).Create(fill).Error; err != nil {
+		return fmt.Errorf("failed to create fill: %w", err)
+	}
+	return nil
+}
+
+// ListOrders 按时间倒序分页获取用户的订单
+func (r *ledgerRepository) ListOrders(db *gorm.DB, userAddress string, page, limit int) ([]TradeOrder, int64, error) {
+	query := db.Model(&TradeOrder{}).Where("user_address = ?", userAddress)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count orders: %w", err)
+	}
+
+	var orders []TradeOrder
+	err := query.Order("created_at DESC, guid DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&orders).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list orders: %w", err)
+	}
+	return orders, total, nil
+}
+
+// LockPosition 加行锁获取用户在某方向上的持仓，不存在时返回 nil
+func (r *ledgerRepository) LockPosition(db *gorm.DB, userAddress, directionGUID string) (*Position, error) {
+	var positions []Position
+	err := db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("user_address = ? AND direction_guid = ?", userAddress, directionGUID).
+		Limit(1).
+		Find(&positions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock position: %w", err)
+	}
+	if len(positions) == 0 {
+		return nil, nil
+	}
+	return &positions[0], nil
+}
+
+// LockSubEventPositions 加行锁获取子事件下的全部持仓
+func (r *ledgerRepository) LockSubEventPositions(db *gorm.DB, subEventGUID string) ([]Position, error) {
+	var positions []Position
+	err := db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("sub_event_guid = ?", subEventGUID).
+		Order("guid ASC").
+		Find(&positions).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock sub event positions: %w", err)
+	}
+	return positions, nil
+}
+
+// SavePosition 保存持仓，GUID 为空时创建（GUID 通过 RETURNING 回填）
+func (r *ledgerRepository) SavePosition(db *gorm.DB, position *Position) error {
+	if position.GUID == "" {
+		if err := db.Clauses(clause.Returning{}).Create(position).Error; err != nil {
+			return fmt.Errorf("failed to create position: %w", err)
+		}
+		return nil
+	}
+
+	err := db.Model(&Position{}).Where("guid = ?", position.GUID).
+		UpdateColumns(map[string]interface{}{
+			"shares":       position.Shares,
+			"cost_basis":   position.CostBasis,
+			"realized_pnl": position.RealizedPnl,
+			"payout":       position.Payout,
+			"status":       position.Status,
+			"settled_at":   position.SettledAt,
+			"updated_at":   gorm.Expr("CURRENT_TIMESTAMP"),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update position: %w", err)
+	}
+	return nil
+}
+
+// ListPositions 获取用户的持仓，status 为空时返回全部
+func (r *ledgerRepository) ListPositions(db *gorm.DB, userAddress, status string) ([]Position, error) {
+	query := db.Where("user_address = ?", userAddress)
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var positions []Position
+	if err := query.Order("created_at DESC, guid DESC").Find(&positions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list positions: %w", err)
+	}
+	return positions, nil
+}
+
+// HasEventPositions 判断事件下是否有持仓（包括已卖出或已结算的持仓）
+func (r *ledgerRepository) HasEventPositions(db *gorm.DB, eventGUID string) (bool, error) {
+	var count int64
+	if err := db.Model(&Position{}).Where("event_guid = ?", eventGUID).Limit(1).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check event positions: %w", err)
+	}
+	return count > 0, nil
+}
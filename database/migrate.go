@@ -0,0 +1,381 @@
+package database
+
+import (
+	"cmp"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// migrationLockKey 执行迁移时持有的会话级咨询锁，保证同一时刻只有一个进程在迁移
+const migrationLockKey = "schema_migrations"
+
+// MigrationVersionNone 作为 down --to 的目标时表示回滚全部迁移
+const MigrationVersionNone = "0"
+
+// 迁移状态
+const (
+	MigrationStatePending  = "pending"  // 未执行
+	MigrationStateApplied  = "applied"  // 已执行
+	MigrationStateModified = "modified" // 已执行，但文件内容在执行后被修改
+	MigrationStateMissing  = "missing"  // 已执行，但迁移文件已不存在
+)
+
+// migrationFilePattern 迁移文件名：<version>[_name].sql 只有升级脚本；
+// <version>[_name].up.sql 与 <version>[_name].down.sql 分别为升级和回滚脚本
+var migrationFilePattern = regexp.MustCompile(`^(\d+)(?:_([A-Za-z0-9_-]+))?(\.up|\.down)?\.sql$`)
+
+// createSchemaMigrationsSQL 迁移历史表，由迁移器自身创建
+const createSchemaMigrationsSQL = `CREATE TABLE IF NOT EXISTS schema_migrations (
+    version      VARCHAR(32)  PRIMARY KEY,                       -- 迁移版本
+    name         VARCHAR(255) NOT NULL DEFAULT '',               -- 迁移名称
+    checksum     VARCHAR(64)  NOT NULL,                          -- 升级脚本的 SHA-256
+    execution_ms BIGINT       NOT NULL DEFAULT 0,                -- 执行耗时（毫秒）
+    applied_at   TIMESTAMP(0) NOT NULL DEFAULT CURRENT_TIMESTAMP -- 执行时间
+)`
+
+// SchemaMigration 已执行的迁移记录
+type SchemaMigration struct {
+	Version     string    `gorm:"primaryKey;type:varchar(32)" json:"version"`
+	Name        string    `gorm:"type:varchar(255);not null;default:''" json:"name"`
+	Checksum    string    `gorm:"type:varchar(64);not null" json:"checksum"`
+	ExecutionMs int64     `gorm:"not null;default:0" json:"execution_ms"`
+	AppliedAt   time.Time `gorm:"type:timestamp(0);not null;default:CURRENT_TIMESTAMP" json:"applied_at"`
+}
+
+func (SchemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+// Migration 迁移目录中的一个版本
+type Migration struct {
+	Version  string
+	Name     string
+	UpSQL    string
+	DownSQL  string // 为空表示该版本不能回滚
+	Checksum string // 升级脚本的 SHA-256
+}
+
+// MigrationStatus 迁移版本的执行状态
+type MigrationStatus struct {
+	Version   string
+	Name      string
+	State     string
+	AppliedAt *time.Time
+}
+
+// compareMigrationVersion 按数值比较两个版本号
+func compareMigrationVersion(a, b string) int {
+	a, b = strings.TrimLeft(a, "0"), strings.TrimLeft(b, "0")
+	if c := cmp.Compare(len(a), len(b)); c != 0 {
+		return c
+	}
+	return strings.Compare(a, b)
+}
+
+// LoadMigrations 读取迁移目录下的迁移文件，按版本升序返回；文件名不合法、版本重复或只有回滚脚本时返回错误
+func LoadMigrations(dir string) ([]Migration, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations folder: %w", err)
+	}
+
+	byVersion := make(map[string]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".sql" {
+			continue
+		}
+		match := migrationFilePattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %s: expected <version>[_name][.up|.down].sql", entry.Name())
+		}
+		version, name, direction := match[1], match[2], match[3]
+
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration file %s: %w", entry.Name(), err)
+		}
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: name}
+			byVersion[version] = migration
+		} else if migration.Name != name {
+			return nil, fmt.Errorf("migration %s has files with different names: %q and %q", version, migration.Name, name)
+		}
+
+		if direction == ".down" {
+			if migration.DownSQL != "" {
+				return nil, fmt.Errorf("duplicate down script for migration %s", version)
+			}
+			migration.DownSQL = string(content)
+			continue
+		}
+		if migration.UpSQL != "" {
+			return nil, fmt.Errorf("duplicate up script for migration %s", version)
+		}
+		migration.UpSQL = string(content)
+		sum := sha256.Sum256(content)
+		migration.Checksum = hex.EncodeToString(sum[:])
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.UpSQL == "" {
+			return nil, fmt.Errorf("migration %s has a down script but no up script", migration.Version)
+		}
+		migrations = append(migrations, *migration)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int {
+		return compareMigrationVersion(a.Version, b.Version)
+	})
+	for i := 1; i < len(migrations); i++ {
+		if compareMigrationVersion(migrations[i-1].Version, migrations[i].Version) == 0 {
+			return nil, fmt.Errorf("migrations %s and %s have the same version", migrations[i-1].Version, migrations[i].Version)
+		}
+	}
+	return migrations, nil
+}
+
+// migrationStatuses 合并迁移文件与迁移历史，按版本升序返回每个版本的状态
+func migrationStatuses(migrations []Migration, applied []SchemaMigration) []MigrationStatus {
+	records := make(map[string]SchemaMigration, len(applied))
+	for _, record := range applied {
+		records[record.Version] = record
+	}
+
+	statuses := make([]MigrationStatus, 0, len(migrations)+len(applied))
+	for _, migration := range migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name, State: MigrationStatePending}
+		if record, ok := records[migration.Version]; ok {
+			status.State = MigrationStateApplied
+			if record.Checksum != migration.Checksum {
+				status.State = MigrationStateModified
+			}
+			status.AppliedAt = &record.AppliedAt
+			delete(records, migration.Version)
+		}
+		statuses = append(statuses, status)
+	}
+	for _, record := range records {
+		statuses = append(statuses, MigrationStatus{
+			Version:   record.Version,
+			Name:      record.Name,
+			State:     MigrationStateMissing,
+			AppliedAt: &record.AppliedAt,
+		})
+	}
+	slices.SortFunc(statuses, func(a, b MigrationStatus) int {
+		return compareMigrationVersion(a.Version, b.Version)
+	})
+	return statuses
+}
+
+// checkMigrationHistory 已执行的迁移被修改或删除时返回错误，此时不再执行升级或回滚
+func checkMigrationHistory(statuses []MigrationStatus) error {
+	for _, status := range statuses {
+		switch status.State {
+		case MigrationStateModified:
+			return fmt.Errorf("migration %s was modified after it was applied", status.Version)
+		case MigrationStateMissing:
+			return fmt.Errorf("migration %s was applied but its file is missing", status.Version)
+		}
+	}
+	return nil
+}
+
+// planUp 返回需要执行的迁移：版本不大于 to（为空表示全部）的未执行迁移，按版本升序
+func planUp(migrations []Migration, applied []SchemaMigration, to string) ([]Migration, error) {
+	if err := checkMigrationHistory(migrationStatuses(migrations, applied)); err != nil {
+		return nil, err
+	}
+	if to != "" && !slices.ContainsFunc(migrations, func(m Migration) bool { return compareMigrationVersion(m.Version, to) == 0 }) {
+		return nil, fmt.Errorf("unknown migration version %s", to)
+	}
+
+	var plan []Migration
+	for _, migration := range migrations {
+		if slices.ContainsFunc(applied, func(record SchemaMigration) bool { return record.Version == migration.Version }) {
+			continue
+		}
+		if to != "" && compareMigrationVersion(migration.Version, to) > 0 {
+			break
+		}
+		plan = append(plan, migration)
+	}
+	return plan, nil
+}
+
+// planDown 返回需要回滚的迁移，按版本降序：to 为空时只回滚最近的一个版本，
+// 否则回滚版本大于 to 的全部已执行迁移；任一迁移没有回滚脚本时返回错误
+func planDown(migrations []Migration, applied []SchemaMigration, to string) ([]Migration, error) {
+	if err := checkMigrationHistory(migrationStatuses(migrations, applied)); err != nil {
+		return nil, err
+	}
+	if to != "" && compareMigrationVersion(to, MigrationVersionNone) != 0 &&
+		!slices.ContainsFunc(applied, func(m SchemaMigration) bool { return compareMigrationVersion(m.Version, to) == 0 }) {
+		return nil, fmt.Errorf("migration %s has not been applied", to)
+	}
+
+	var plan []Migration
+	for i := len(migrations) - 1; i >= 0; i-- {
+		migration := migrations[i]
+		if !slices.ContainsFunc(applied, func(record SchemaMigration) bool { return record.Version == migration.Version }) {
+			continue
+		}
+		if to != "" && compareMigrationVersion(migration.Version, to) <= 0 {
+			break
+		}
+		if migration.DownSQL == "" {
+			return nil, fmt.Errorf("migration %s has no down script", migration.Version)
+		}
+		plan = append(plan, migration)
+		if to == "" {
+			break
+		}
+	}
+	return plan, nil
+}
+
+// Migrator 按版本执行迁移目录中的迁移，并在 schema_migrations 中记录执行历史
+type Migrator struct {
+	db  *DB
+	dir string
+}
+
+// NewMigrator 创建迁移器
+func NewMigrator(db *DB, dir string) *Migrator {
+	return &Migrator{db: db, dir: dir}
+}
+
+// Status 返回每个迁移版本的执行状态，不修改数据库
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	migrations, err := LoadMigrations(m.dir)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := m.appliedMigrations(m.db.gorm.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return migrationStatuses(migrations, applied), nil
+}
+
+// Up 依次执行版本不大于 to（为空表示全部）的未执行迁移，返回执行的迁移；
+// 每个迁移与其历史记录在同一事务中提交，失败时已执行的迁移保留；dryRun 时只返回计划，不修改数据库
+func (m *Migrator) Up(ctx context.Context, to string, dryRun bool) ([]Migration, error) {
+	return m.run(ctx, to, dryRun, planUp, func(tx *gorm.DB, migration Migration) error {
+		start := time.Now()
+		if err := tx.Exec(migration.UpSQL).Error; err != nil {
+			return err
+		}
+		return tx.Create(&SchemaMigration{
+			Version:     migration.Version,
+			Name:        migration.Name,
+			Checksum:    migration.Checksum,
+			ExecutionMs: time.Since(start).Milliseconds(),
+		}).Error
+	})
+}
+
+// Down 回滚迁移并删除其历史记录，返回回滚的迁移：to 为空时只回滚最近的一个版本，
+// 否则回滚版本大于 to 的全部迁移（to 为 MigrationVersionNone 时回滚全部）；dryRun 时只返回计划，不修改数据库
+func (m *Migrator) Down(ctx context.Context, to string, dryRun bool) ([]Migration, error) {
+	return m.run(ctx, to, dryRun, planDown, func(tx *gorm.DB, migration Migration) error {
+		if err := tx.Exec(migration.DownSQL).Error; err != nil {
+			return err
+		}
+		return tx.Where("version = ?", migration.Version).Delete(&SchemaMigration{}).Error
+	})
+}
+
+// Baseline 只记录版本不大于 to（为空表示全部）的未执行迁移而不执行，返回记录的迁移；
+// 用于接入已由旧的全量执行方式迁移过的数据库，之后 Up 只执行新增的迁移；dryRun 时只返回计划，不修改数据库
+func (m *Migrator) Baseline(ctx context.Context, to string, dryRun bool) ([]Migration, error) {
+	return m.run(ctx, to, dryRun, planUp, func(tx *gorm.DB, migration Migration) error {
+		return tx.Create(&SchemaMigration{
+			Version:  migration.Version,
+			Name:     migration.Name,
+			Checksum: migration.Checksum,
+		}).Error
+	})
+}
+
+// run 在咨询锁保护下计算迁移计划并逐个在事务中执行
+func (m *Migrator) run(
+	ctx context.Context,
+	to string,
+	dryRun bool,
+	plan func([]Migration, []SchemaMigration, string) ([]Migration, error),
+	apply func(*gorm.DB, Migration) error,
+) ([]Migration, error) {
+	migrations, err := LoadMigrations(m.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	if dryRun {
+		applied, err := m.appliedMigrations(m.db.gorm.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		return plan(migrations, applied, to)
+	}
+
+	var done []Migration
+	err = m.db.gorm.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if err := conn.Exec("SELECT pg_advisory_lock(hashtext(?))", migrationLockKey).Error; err != nil {
+			return fmt.Errorf("failed to acquire migration lock: %w", err)
+		}
+		// 连接归还连接池前必须释放会话级锁，即使 ctx 已取消
+		defer conn.WithContext(context.Background()).Exec("SELECT pg_advisory_unlock(hashtext(?))", migrationLockKey)
+
+		if err := conn.Exec(createSchemaMigrationsSQL).Error; err != nil {
+			return fmt.Errorf("failed to create schema_migrations: %w", err)
+		}
+		// 持有锁之后再读取历史，其他进程已执行的迁移不会重复执行
+		applied, err := m.appliedMigrations(conn)
+		if err != nil {
+			return err
+		}
+		steps, err := plan(migrations, applied, to)
+		if err != nil {
+			return err
+		}
+		for _, migration := range steps {
+			if err := conn.Transaction(func(tx *gorm.DB) error {
+				return apply(tx, migration)
+			}); err != nil {
+				return fmt.Errorf("migration %s failed: %w", migration.Version, err)
+			}
+			done = append(done, migration)
+		}
+		return nil
+	})
+	return done, err
+}
+
+// appliedMigrations 获取已执行的迁移，schema_migrations 尚未创建时返回空
+func (m *Migrator) appliedMigrations(db *gorm.DB) ([]SchemaMigration, error) {
+	var exists bool
+	if err := db.Raw("SELECT to_regclass('schema_migrations') IS NOT NULL").Scan(&exists).Error; err != nil {
+		return nil, fmt.Errorf("failed to check schema_migrations: %w", err)
+	}
+	if !exists {
+		return nil, nil
+	}
+	var applied []SchemaMigration
+	if err := db.Order("version ASC").Find(&applied).Error; err != nil {
+		return nil, fmt.Errorf("failed to get applied migrations: %w", err)
+	}
+	return applied, nil
+}
@@ -0,0 +1,209 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// writeMigrations 在临时目录中写入迁移文件
+func writeMigrations(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	return dir
+}
+
+// appliedRecords 按迁移文件生成已执行的历史记录
+func appliedRecords(migrations []Migration, versions ...string) []SchemaMigration {
+	var records []SchemaMigration
+	for _, version := range versions {
+		for _, migration := range migrations {
+			if migration.Version == version {
+				records = append(records, SchemaMigration{Version: version, Name: migration.Name, Checksum: migration.Checksum})
+			}
+		}
+	}
+	return records
+}
+
+func versions(migrations []Migration) []string {
+	var out []string
+	for _, migration := range migrations {
+		out = append(out, migration.Version)
+	}
+	return out
+}
+
+func TestLoadMigrationsOrdersVersionsAndPairsScripts(t *testing.T) {
+	dir := writeMigrations(t, map[string]string{
+		"20251202001.sql":                      "SELECT 2;",
+		"20251117001.sql":                      "SELECT 1;",
+		"20251203001_add_index.up.sql":         "CREATE INDEX x ON t (c);",
+		"20251203001_add_index.down.sql":       "DROP INDEX x;",
+		"README.md":                            "not a migration",
+		"20251204001_schema_migrations.up.sql": "SELECT 4;",
+	})
+
+	migrations, err := LoadMigrations(dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{"20251117001", "20251202001", "20251203001", "20251204001"}, versions(migrations))
+
+	require.Equal(t, "add_index", migrations[2].Name)
+	require.Equal(t, "CREATE INDEX x ON t (c);", migrations[2].UpSQL)
+	require.Equal(t, "DROP INDEX x;", migrations[2].DownSQL)
+	require.Empty(t, migrations[0].DownSQL)
+	require.Len(t, migrations[0].Checksum, 64)
+	require.NotEqual(t, migrations[0].Checksum, migrations[1].Checksum)
+}
+
+func TestLoadMigrationsOrdersVersionsNumerically(t *testing.T) {
+	dir := writeMigrations(t, map[string]string{
+		"10_b.sql": "SELECT 10;",
+		"9_a.sql":  "SELECT 9;",
+	})
+
+	migrations, err := LoadMigrations(dir)
+	require.NoError(t, err)
+	require.Equal(t, []string{"9", "10"}, versions(migrations))
+}
+
+func TestLoadMigrationsRejectsInvalidFiles(t *testing.T) {
+	cases := map[string]map[string]string{
+		"bad name":          {"add_index.sql": "SELECT 1;"},
+		"down without up":   {"1.down.sql": "SELECT 1;"},
+		"duplicate up":      {"1.sql": "SELECT 1;", "1.up.sql": "SELECT 1;"},
+		"different names":   {"1_a.up.sql": "SELECT 1;", "1_b.down.sql": "SELECT 1;"},
+		"same version":      {"1.sql": "SELECT 1;", "01.sql": "SELECT 1;"},
+		"missing directory": nil,
+	}
+	for name, files := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir := filepath.Join(t.TempDir(), "missing")
+			if files != nil {
+				dir = writeMigrations(t, files)
+			}
+			_, err := LoadMigrations(dir)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestMigrationStatuses(t *testing.T) {
+	migrations, err := LoadMigrations(writeMigrations(t, map[string]string{
+		"1.sql": "SELECT 1;",
+		"2.sql": "SELECT 2;",
+		"3.sql": "SELECT 3;",
+	}))
+	require.NoError(t, err)
+
+	applied := appliedRecords(migrations, "1", "2")
+	applied[1].Checksum = "changed"
+	applied = append(applied, SchemaMigration{Version: "0", Name: "removed", Checksum: "x"})
+
+	statuses := migrationStatuses(migrations, applied)
+	var states []string
+	for _, status := range statuses {
+		states = append(states, status.Version+":"+status.State)
+	}
+	require.Equal(t, []string{"0:missing", "1:applied", "2:modified", "3:pending"}, states)
+	require.NotNil(t, statuses[1].AppliedAt)
+	require.Nil(t, statuses[3].AppliedAt)
+}
+
+func TestPlanUp(t *testing.T) {
+	migrations, err := LoadMigrations(writeMigrations(t, map[string]string{
+		"1.sql": "SELECT 1;",
+		"2.sql": "SELECT 2;",
+		"3.sql": "SELECT 3;",
+		"4.sql": "SELECT 4;",
+	}))
+	require.NoError(t, err)
+
+	plan, err := planUp(migrations, nil, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2", "3", "4"}, versions(plan))
+
+	plan, err = planUp(migrations, appliedRecords(migrations, "1"), "3")
+	require.NoError(t, err)
+	require.Equal(t, []string{"2", "3"}, versions(plan))
+
+	// 合并分支后出现的较早版本也会执行
+	plan, err = planUp(migrations, appliedRecords(migrations, "1", "3"), "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"2", "4"}, versions(plan))
+
+	plan, err = planUp(migrations, appliedRecords(migrations, "1", "2", "3", "4"), "")
+	require.NoError(t, err)
+	require.Empty(t, plan)
+
+	_, err = planUp(migrations, nil, "5")
+	require.ErrorContains(t, err, "unknown migration version 5")
+}
+
+func TestPlanUpRejectsChangedHistory(t *testing.T) {
+	migrations, err := LoadMigrations(writeMigrations(t, map[string]string{
+		"1.sql": "SELECT 1;",
+		"2.sql": "SELECT 2;",
+	}))
+	require.NoError(t, err)
+
+	applied := appliedRecords(migrations, "1")
+	applied[0].Checksum = "changed"
+	_, err = planUp(migrations, applied, "")
+	require.ErrorContains(t, err, "migration 1 was modified after it was applied")
+
+	_, err = planUp(migrations, []SchemaMigration{{Version: "0", Checksum: "x"}}, "")
+	require.ErrorContains(t, err, "migration 0 was applied but its file is missing")
+}
+
+func TestPlanDown(t *testing.T) {
+	migrations, err := LoadMigrations(writeMigrations(t, map[string]string{
+		"1.sql":      "SELECT 1;",
+		"2.up.sql":   "SELECT 2;",
+		"2.down.sql": "SELECT -2;",
+		"3.up.sql":   "SELECT 3;",
+		"3.down.sql": "SELECT -3;",
+		"4.up.sql":   "SELECT 4;",
+		"4.down.sql": "SELECT -4;",
+	}))
+	require.NoError(t, err)
+	applied := appliedRecords(migrations, "1", "2", "3")
+
+	plan, err := planDown(migrations, applied, "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"3"}, versions(plan))
+
+	plan, err = planDown(migrations, applied, "1")
+	require.NoError(t, err)
+	require.Equal(t, []string{"3", "2"}, versions(plan))
+
+	plan, err = planDown(migrations, applied, "3")
+	require.NoError(t, err)
+	require.Empty(t, plan)
+
+	_, err = planDown(migrations, applied, MigrationVersionNone)
+	require.ErrorContains(t, err, "migration 1 has no down script")
+
+	_, err = planDown(migrations, applied, "4")
+	require.ErrorContains(t, err, "migration 4 has not been applied")
+
+	plan, err = planDown(migrations, nil, "")
+	require.NoError(t, err)
+	require.Empty(t, plan)
+}
+
+func TestLoadMigrationsFromRepository(t *testing.T) {
+	migrations, err := LoadMigrations("../migrations")
+	require.NoError(t, err)
+	require.NotEmpty(t, migrations)
+
+	// 初始建表语句之后的迁移都必须能回滚
+	for _, migration := range migrations[1:] {
+		require.NotEmpty(t, migration.DownSQL, "migration %s has no down script", migration.Version)
+	}
+}
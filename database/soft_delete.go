@@ -0,0 +1,117 @@
+package database
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SoftDeleteTables 支持软删除的表，按清理顺序排列：先清理引用方（事件），再清理被引用的分类数据和语言
+var SoftDeleteTables = []string{"event", "team_group", "event_period", "ecosystem", "category", "languages"}
+
+// purgeChild 清理主记录时一并删除的子表，where 的参数为被清理的主记录 GUID
+type purgeChild struct {
+	table string
+	where string
+}
+
+// softDeleteChildren 各软删除表的子表，按删除顺序排列
+var softDeleteChildren = map[string][]purgeChild{
+	"event": {
+		{"sub_event_chance_stat", "sub_event_guid IN (SELECT guid FROM sub_event WHERE parent_event_guid IN ?)"},
+		{"sub_event_direction", "sub_event_guid IN (SELECT guid FROM sub_event WHERE parent_event_guid IN ?)"},
+		{"sub_event_language", "sub_event_guid IN (SELECT guid FROM sub_event WHERE parent_event_guid IN ?)"},
+		{"sub_event", "parent_event_guid IN ?"},
+		{"event_language", "event_guid IN ?"},
+		{"event_status_history", "event_guid IN ?"},
+		{"event_score_history", "event_guid IN ?"},
+		{"event_resolution", "event_guid IN ?"},
+		{"event_tag", "event_guid IN ?"},
+	},
+	"team_group":   {{"team_group_language", "team_group_guid IN ?"}},
+	"event_period": {{"event_period_language", "event_period_guid IN ?"}},
+	"ecosystem":    {{"ecosystem_language", "ecosystem_guid IN ?"}},
+	"category":     {{"category_language", "category_guid IN ?"}},
+	"languages": {
+		{"event_language", "language_guid IN ?"},
+		{"sub_event_language", "language_guid IN ?"},
+		{"category_language", "language_guid IN ?"},
+		{"ecosystem_language", "language_guid IN ?"},
+		{"event_period_language", "language_guid IN ?"},
+		{"team_group_language", "language_guid IN ?"},
+	},
+}
+
+// SoftDeleteRepository 软删除记录的清理接口
+type SoftDeleteRepository interface {
+	// PurgeDeleted 硬删除表中软删除超过 retention 的记录及其子表记录，每次最多 limit 条，返回被删除的 GUID
+	PurgeDeleted(db *gorm.DB, table string, retention time.Duration, limit int) ([]string, error)
+}
+
+type softDeleteRepository struct{}
+
+// NewSoftDeleteRepository 创建软删除清理仓储实例
+func NewSoftDeleteRepository() SoftDeleteRepository {
+	return &softDeleteRepository{}
+}
+
+// PurgeDeleted 硬删除表中软删除超过 retention 的记录及其子表记录，应在事务中调用
+// 截止时间按数据库时间计算，与写入 deleted_at 时一致；正在被恢复（已加行锁）的记录跳过
+func (r *softDeleteRepository) PurgeDeleted(db *gorm.DB, table string, retention time.Duration, limit int) ([]string, error) {
+	children, ok := softDeleteChildren[table]
+	if !ok {
+		return nil, fmt.Errorf("table %q does not support soft delete", table)
+	}
+
+	var guids []string
+	err := db.Raw(fmt.Sprintf(`DELETE FROM %[1]s WHERE guid IN (
+			SELECT guid FROM %[1]s WHERE deleted_at < CURRENT_TIMESTAMP - make_interval(secs => ?) ORDER BY deleted_at ASC, guid ASC LIMIT ? FOR UPDATE SKIP LOCKED
+		) RETURNING guid`, table), retention.Seconds(), limit).
+		Scan(&guids).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge %s: %w", table, err)
+	}
+	if len(guids) == 0 {
+		return nil, nil
+	}
+
+	for _, child := range children {
+		if err := db.Exec("DELETE FROM "+child.table+" WHERE "+child.where, guids).Error; err != nil {
+			return nil, fmt.Errorf("failed to purge %s of %s: %w", child.table, table, err)
+		}
+	}
+	return guids, nil
+}
+
+// softDelete 软删除记录；deleted_at 与 created_at、updated_at 一样取数据库时间，已删除的记录不受影响
+func softDelete[T any](db *gorm.DB, guid string) error {
+	return db.Model(new(T)).Where("guid = ?", guid).
+		UpdateColumn("deleted_at", gorm.Expr("CURRENT_TIMESTAMP")).Error
+}
+
+// lockDeleted 加行锁获取已软删除的记录，不存在或未删除时返回 nil
+func lockDeleted[T any](db *gorm.DB, guid string) (*T, error) {
+	var rows []T
+	err := db.Unscoped().Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}).
+		Where("guid = ? AND deleted_at IS NOT NULL", guid).
+		Limit(1).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return &rows[0], nil
+}
+
+// restoreDeleted 恢复已软删除的记录并刷新 updated_at
+func restoreDeleted[T any](db *gorm.DB, guid string) error {
+	return db.Unscoped().Model(new(T)).Where("guid = ? AND deleted_at IS NOT NULL", guid).
+		UpdateColumns(map[string]interface{}{
+			"deleted_at": nil,
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		}).Error
+}
@@ -0,0 +1,27 @@
+package database
+
+import "time"
+
+// Tag 标签表：名称唯一，同名标签复用
+type Tag struct {
+	GUID      string    `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
+	Name      string    `gorm:"type:varchar(100);not null;uniqueIndex:uq_tag_name" json:"name"` // 标签名称
+	CreatedAt time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
+	UpdatedAt time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"updated_at"`
+}
+
+func (Tag) TableName() string {
+	return "tag"
+}
+
+// EventTag 事件与标签的关联表，清理事件时一并删除
+type EventTag struct {
+	GUID      string    `gorm:"type:text;primaryKey;default:replace(uuid_generate_v4()::text, '-', '')" json:"guid"`
+	EventGUID string    `gorm:"type:varchar(500);not null;uniqueIndex:uq_event_tag_event_tag" json:"event_guid"`
+	TagGUID   string    `gorm:"type:varchar(500);not null;uniqueIndex:uq_event_tag_event_tag;index:idx_event_tag_tag_guid" json:"tag_guid"`
+	CreatedAt time.Time `gorm:"type:timestamp(0);default:CURRENT_TIMESTAMP" json:"created_at"`
+}
+
+func (EventTag) TableName() string {
+	return "event_tag"
+}
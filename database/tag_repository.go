@@ -0,0 +1,77 @@
+package database
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TagRepository 标签数据库操作接口
+type TagRepository interface {
+	// EnsureTags 按名称获取标签，不存在的标签自动创建，返回顺序与 names 一致
+	EnsureTags(db *gorm.DB, names []string) ([]Tag, error)
+	// CreateEventTags 关联事件与标签
+	CreateEventTags(db *gorm.DB, eventGUID string, tagGUIDs []string) error
+}
+
+type tagRepository struct{}
+
+// NewTagRepository 创建标签仓储实例
+func NewTagRepository() TagRepository {
+	return &tagRepository{}
+}
+
+// EnsureTags 插入不存在的标签（INSERT ... ON CONFLICT DO NOTHING）后按名称读取
+// 并发创建同名标签时等待先插入的事务提交，之后读取到同一条记录
+func (r *tagRepository) EnsureTags(db *gorm.DB, names []string) ([]Tag, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	tags := make([]Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, Tag{Name: name})
+	}
+	if err := db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoNothing: true,
+	}).Select("name").Create(&tags).Error; err != nil {
+		return nil, fmt.Errorf("failed to create tags: %w", err)
+	}
+
+	var existing []Tag
+	if err := db.Where("name IN ?", names).Find(&existing).Error; err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	byName := make(map[string]Tag, len(existing))
+	for _, tag := range existing {
+		byName[tag.Name] = tag
+	}
+
+	result := make([]Tag, 0, len(names))
+	for _, name := range names {
+		tag, ok := byName[name]
+		if !ok {
+			return nil, fmt.Errorf("tag %q was not created", name)
+		}
+		result = append(result, tag)
+	}
+	return result, nil
+}
+
+// CreateEventTags 关联事件与标签，已关联的标签忽略
+func (r *tagRepository) CreateEventTags(db *gorm.DB, eventGUID string, tagGUIDs []string) error {
+	if len(tagGUIDs) == 0 {
+		return nil
+	}
+	eventTags := make([]EventTag, 0, len(tagGUIDs))
+	for _, tagGUID := range tagGUIDs {
+		eventTags = append(eventTags, EventTag{EventGUID: eventGUID, TagGUID: tagGUID})
+	}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).
+		Select("event_guid", "tag_guid").Create(&eventTags).Error; err != nil {
+		return fmt.Errorf("failed to create event tags: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package database
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TaxonomyRepository 分类、生态、时间标签、运动队等事件引用数据的数据库操作接口
+// 查询均加共享锁，在事务中调用时可防止被引用的记录在提交前被修改或删除
+type TaxonomyRepository interface {
+	// GetCategory 获取分类，不存在时返回 nil
+	GetCategory(db *gorm.DB, categoryGUID string) (*Category, error)
+	// GetEcosystem 获取生态，不存在时返回 nil
+	GetEcosystem(db *gorm.DB, ecosystemGUID string) (*Ecosystem, error)
+	// GetEventPeriod 获取时间标签，不存在时返回 nil
+	GetEventPeriod(db *gorm.DB, eventPeriodGUID string) (*EventPeriod, error)
+	// GetTeamGroupsByGUIDs 批量获取运动队
+	GetTeamGroupsByGUIDs(db *gorm.DB, teamGroupGUIDs []string) ([]TeamGroup, error)
+}
+
+type taxonomyRepository struct{}
+
+// NewTaxonomyRepository 创建分类数据仓储实例
+func NewTaxonomyRepository() TaxonomyRepository {
+	return &taxonomyRepository{}
+}
+
+// GetCategory 获取分类，不存在时返回 nil
+func (r *taxonomyRepository) GetCategory(db *gorm.DB, categoryGUID string) (*Category, error) {
+	var categories []Category
+	if err := shareLocked(db).Where("guid = ?", categoryGUID).Limit(1).Find(&categories).Error; err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	if len(categories) == 0 {
+		return nil, nil
+	}
+	return &categories[0], nil
+}
+
+// GetEcosystem 获取生态，不存在时返回 nil
+func (r *taxonomyRepository) GetEcosystem(db *gorm.DB, ecosystemGUID string) (*Ecosystem, error) {
+	var ecosystems []Ecosystem
+	if err := shareLocked(db).Where("guid = ?", ecosystemGUID).Limit(1).Find(&ecosystems).Error; err != nil {
+		return nil, fmt.Errorf("failed to get ecosystem: %w", err)
+	}
+	if len(ecosystems) == 0 {
+		return nil, nil
+	}
+	return &ecosystems[0], nil
+}
+
+// GetEventPeriod 获取时间标签，不存在时返回 nil
+func (r *taxonomyRepository) GetEventPeriod(db *gorm.DB, eventPeriodGUID string) (*EventPeriod, error) {
+	var eventPeriods []EventPeriod
+	if err := shareLocked(db).Where("guid = ?", eventPeriodGUID).Limit(1).Find(&eventPeriods).Error; err != nil {
+		return nil, fmt.Errorf("failed to get event period: %w", err)
+	}
+	if len(eventPeriods) == 0 {
+		return nil, nil
+	}
+	return &eventPeriods[0], nil
+}
+
+// GetTeamGroupsByGUIDs 批量获取运动队
+func (r *taxonomyRepository) GetTeamGroupsByGUIDs(db *gorm.DB, teamGroupGUIDs []string) ([]TeamGroup, error) {
+	if len(teamGroupGUIDs) == 0 {
+		return nil, nil
+	}
+	var teamGroups []TeamGroup
+	if err := shareLocked(db).Where("guid IN ?", teamGroupGUIDs).Find(&teamGroups).Error; err != nil {
+		return nil, fmt.Errorf("failed to get team groups: %w", err)
+	}
+	return teamGroups, nil
+}
+
+// shareLocked 对查询加 FOR SHARE 锁
+func shareLocked(db *gorm.DB) *gorm.DB {
+	return db.Clauses(clause.Locking{Strength: clause.LockingStrengthShare})
+}
@@ -0,0 +1,193 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TeamGroupRepository 运动队及其多语言名称的数据库操作接口
+type TeamGroupRepository interface {
+	// CreateTeamGroup 创建运动队
+	CreateTeamGroup(db *gorm.DB, teamGroup *TeamGroup) error
+	// GetTeamGroup 获取运动队，不存在时返回 nil
+	GetTeamGroup(db *gorm.DB, teamGroupGUID string) (*TeamGroup, error)
+	// LockTeamGroup 加行锁获取运动队，不存在时返回 nil
+	LockTeamGroup(db *gorm.DB, teamGroupGUID string) (*TeamGroup, error)
+	// UpdateTeamGroup 更新运动队 Logo 并刷新 updated_at
+	UpdateTeamGroup(db *gorm.DB, teamGroupGUID, logo string) error
+	// DeleteTeamGroup 软删除运动队，保留多语言名称以便恢复
+	DeleteTeamGroup(db *gorm.DB, teamGroupGUID string) error
+	// LockDeletedTeamGroup 加行锁获取已软删除的运动队，不存在或未删除时返回 nil
+	LockDeletedTeamGroup(db *gorm.DB, teamGroupGUID string) (*TeamGroup, error)
+	// RestoreTeamGroup 恢复已软删除的运动队并刷新 updated_at
+	RestoreTeamGroup(db *gorm.DB, teamGroupGUID string) error
+	// SearchTeamGroups 按名称（任意语言，不区分大小写）模糊搜索运动队，query 为空时返回全部
+	SearchTeamGroups(db *gorm.DB, query string, page, limit int) ([]TeamGroup, int64, error)
+	// GetTeamGroupLanguages 获取运动队在全部语言下的名称
+	GetTeamGroupLanguages(db *gorm.DB, teamGroupGUIDs []string) ([]TeamGroupLanguage, error)
+	// UpsertTeamGroupLanguage 创建或更新运动队在某语言下的名称
+	UpsertTeamGroupLanguage(db *gorm.DB, teamGroupLang *TeamGroupLanguage) error
+	// DeleteTeamGroupLanguages 删除运动队在指定语言下的名称
+	DeleteTeamGroupLanguages(db *gorm.DB, teamGroupGUID string, languageGUIDs []string) error
+	// CountTeamGroupEvents 统计以该运动队为主队或客队的事件数量
+	CountTeamGroupEvents(db *gorm.DB, teamGroupGUID string) (int64, error)
+}
+
+type teamGroupRepository struct{}
+
+// NewTeamGroupRepository 创建运动队仓储实例
+func NewTeamGroupRepository() TeamGroupRepository {
+	return &teamGroupRepository{}
+}
+
+// CreateTeamGroup 创建运动队，GUID 通过 RETURNING 回填
+func (r *teamGroupRepository) CreateTeamGroup(db *gorm.DB, teamGroup *TeamGroup) error {
+	if err := db.Clauses(clause.Returning{}).Create(teamGroup).Error; err != nil {
+		return fmt.Errorf("failed to create team group: %w", err)
+	}
+	return nil
+}
+
+// GetTeamGroup 获取运动队，不存在时返回 nil
+func (r *teamGroupRepository) GetTeamGroup(db *gorm.DB, teamGroupGUID string) (*TeamGroup, error) {
+	var teamGroups []TeamGroup
+	if err := db.Where("guid = ?", teamGroupGUID).Limit(1).Find(&teamGroups).Error; err != nil {
+		return nil, fmt.Errorf("failed to get team group: %w", err)
+	}
+	if len(teamGroups) == 0 {
+		return nil, nil
+	}
+	return &teamGroups[0], nil
+}
+
+// LockTeamGroup 加行锁获取运动队，不存在时返回 nil
+func (r *teamGroupRepository) LockTeamGroup(db *gorm.DB, teamGroupGUID string) (*TeamGroup, error) {
+	return r.GetTeamGroup(db.Clauses(clause.Locking{Strength: clause.LockingStrengthUpdate}), teamGroupGUID)
+}
+
+// UpdateTeamGroup 更新运动队 Logo 并刷新 updated_at
+func (r *teamGroupRepository) UpdateTeamGroup(db *gorm.DB, teamGroupGUID, logo string) error {
+	err := db.Model(&TeamGroup{}).Where("guid = ?", teamGroupGUID).
+		UpdateColumns(map[string]interface{}{
+			"logo":       logo,
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		}).Error
+	if err != nil {
+		return fmt.Errorf("failed to update team group: %w", err)
+	}
+	return nil
+}
+
+// DeleteTeamGroup 软删除运动队，多语言名称保留到清理任务硬删除时一并删除
+func (r *teamGroupRepository) DeleteTeamGroup(db *gorm.DB, teamGroupGUID string) error {
+	if err := softDelete[TeamGroup](db, teamGroupGUID); err != nil {
+		return fmt.Errorf("failed to delete team group: %w", err)
+	}
+	return nil
+}
+
+// LockDeletedTeamGroup 加行锁获取已软删除的运动队，不存在或未删除时返回 nil
+func (r *teamGroupRepository) LockDeletedTeamGroup(db *gorm.DB, teamGroupGUID string) (*TeamGroup, error) {
+	deleted, err := lockDeleted[TeamGroup](db, teamGroupGUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted team group: %w", err)
+	}
+	return deleted, nil
+}
+
+// RestoreTeamGroup 恢复已软删除的运动队并刷新 updated_at
+func (r *teamGroupRepository) RestoreTeamGroup(db *gorm.DB, teamGroupGUID string) error {
+	if err := restoreDeleted[TeamGroup](db, teamGroupGUID); err != nil {
+		return fmt.Errorf("failed to restore team group: %w", err)
+	}
+	return nil
+}
+
+// SearchTeamGroups 按名称（任意语言，不区分大小写）模糊搜索运动队，按创建时间倒序分页
+func (r *teamGroupRepository) SearchTeamGroups(db *gorm.DB, query string, page, limit int) ([]TeamGroup, int64, error) {
+	search := db.Model(&TeamGroup{})
+	if query != "" {
+		search = search.Where(
+			"EXISTS (SELECT 1 FROM team_group_language l WHERE l.team_group_guid = team_group.guid AND l.name ILIKE ?)",
+			"%"+escapeLike(query)+"%",
+		)
+	}
+
+	var total int64
+	if err := search.Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("failed to count team groups: %w", err)
+	}
+
+	var teamGroups []TeamGroup
+	err := search.Order("created_at DESC, guid DESC").
+		Offset((page - 1) * limit).
+		Limit(limit).
+		Find(&teamGroups).Error
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to search team groups: %w", err)
+	}
+	return teamGroups, total, nil
+}
+
+// GetTeamGroupLanguages 获取运动队在全部语言下的名称
+func (r *teamGroupRepository) GetTeamGroupLanguages(db *gorm.DB, teamGroupGUIDs []string) ([]TeamGroupLanguage, error) {
+	if len(teamGroupGUIDs) == 0 {
+		return nil, nil
+	}
+	var teamGroupLangs []TeamGroupLanguage
+	err := db.Where("team_group_guid IN ?", teamGroupGUIDs).
+		Order("team_group_guid ASC, language_guid ASC").
+		Find(&teamGroupLangs).Error
+	if err != nil {
+		return nil, fmt.Errorf("failed to get team group languages: %w", err)
+	}
+	return teamGroupLangs, nil
+}
+
+// UpsertTeamGroupLanguage 创建或更新运动队在某语言下的名称（依赖 (team_group_guid, language_guid) 唯一索引）
+func (r *teamGroupRepository) UpsertTeamGroupLanguage(db *gorm.DB, teamGroupLang *TeamGroupLanguage) error {
+	err := db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "team_group_guid"}, {Name: "language_guid"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"name":       gorm.Expr("excluded.name"),
+			"updated_at": gorm.Expr("CURRENT_TIMESTAMP"),
+		}),
+	}).Create(teamGroupLang).Error
+	if err != nil {
+		return fmt.Errorf("failed to upsert team group language: %w", err)
+	}
+	return nil
+}
+
+// DeleteTeamGroupLanguages 删除运动队在指定语言下的名称
+func (r *teamGroupRepository) DeleteTeamGroupLanguages(db *gorm.DB, teamGroupGUID string, languageGUIDs []string) error {
+	if len(languageGUIDs) == 0 {
+		return nil
+	}
+	err := db.Where("team_group_guid = ? AND language_guid IN ?", teamGroupGUID, languageGUIDs).
+		Delete(&TeamGroupLanguage{}).Error
+	if err != nil {
+		return fmt.Errorf("failed to delete team group languages: %w", err)
+	}
+	return nil
+}
+
+// CountTeamGroupEvents 统计以该运动队为主队或客队的事件数量
+func (r *teamGroupRepository) CountTeamGroupEvents(db *gorm.DB, teamGroupGUID string) (int64, error) {
+	var count int64
+	err := db.Model(&Event{}).
+		Where("main_team_group_guid = ? OR cluster_team_group_guid = ?", teamGroupGUID, teamGroupGUID).
+		Count(&count).Error
+	if err != nil {
+		return 0, fmt.Errorf("failed to count team group events: %w", err)
+	}
+	return count, nil
+}
+
+// escapeLike 转义 LIKE 模式中的通配符
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
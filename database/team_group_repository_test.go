@@ -0,0 +1,14 @@
+package database
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscapeLike(t *testing.T) {
+	require.Equal(t, "Real Madrid", escapeLike("Real Madrid"))
+	require.Equal(t, `100\%`, escapeLike("100%"))
+	require.Equal(t, `a\_b`, escapeLike("a_b"))
+	require.Equal(t, `c:\\d`, escapeLike(`c:\d`))
+}
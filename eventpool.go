@@ -14,11 +14,24 @@ import (
 
 	"github.com/ethereum/go-ethereum/log"
 
+	"github.com/multimarket-labs/event-pod-services/common/clock"
 	"github.com/multimarket-labs/event-pod-services/common/httputil"
 	"github.com/multimarket-labs/event-pod-services/config"
 	"github.com/multimarket-labs/event-pod-services/crawler"
 	"github.com/multimarket-labs/event-pod-services/database"
 	"github.com/multimarket-labs/event-pod-services/metrics"
+	"github.com/multimarket-labs/event-pod-services/scheduler"
+)
+
+const (
+	// eventSchedulerInterval 事件调度器检查开盘、开始、收盘时间的间隔
+	eventSchedulerInterval = 10 * time.Second
+	// chanceSnapshotInterval 方向概率的采样间隔，与最小的时间桶（1 分钟）一致
+	chanceSnapshotInterval = time.Minute
+	// softDeletePurgeInterval 软删除记录的清理间隔
+	softDeletePurgeInterval = time.Hour
+	// idempotencyPurgeInterval 过期幂等键的清理间隔
+	idempotencyPurgeInterval = time.Hour
 )
 
 type EventPool struct {
@@ -27,6 +40,10 @@ type EventPool struct {
 	metricsRegistry  *prometheus.Registry
 	eventPoolMetrics *metrics.EventPoolMetrics
 	Crawler          *crawler.Crawler
+	EventScheduler   *scheduler.EventScheduler
+	ChanceSnapshot   *scheduler.ChanceSnapshotJob
+	SoftDeletePurge  *scheduler.SoftDeletePurgeJob
+	IdempotencyPurge *scheduler.IdempotencyPurgeJob
 	wsServer         *httputil.HTTPServer
 	shutdown         context.CancelCauseFunc
 	stopped          atomic.Bool
@@ -63,11 +80,39 @@ func (as *EventPool) Start(ctx context.Context) error {
 		log.Error("start crawler handle fail", "err", errWorker)
 		return errWorker
 	}
+	as.EventScheduler.Start()
+	as.ChanceSnapshot.Start()
+	as.SoftDeletePurge.Start()
+	as.IdempotencyPurge.Start()
 	return nil
 }
 
 func (as *EventPool) Stop(ctx context.Context) error {
 	var result error
+	if as.IdempotencyPurge != nil {
+		if err := as.IdempotencyPurge.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close idempotency key purge job: %w", err))
+		}
+	}
+
+	if as.SoftDeletePurge != nil {
+		if err := as.SoftDeletePurge.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close soft delete purge job: %w", err))
+		}
+	}
+
+	if as.ChanceSnapshot != nil {
+		if err := as.ChanceSnapshot.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close chance snapshot job: %w", err))
+		}
+	}
+
+	if as.EventScheduler != nil {
+		if err := as.EventScheduler.Close(); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to close event scheduler: %w", err))
+		}
+	}
+
 	if as.DB != nil {
 		if err := as.DB.Close(); err != nil {
 			result = errors.Join(result, fmt.Errorf("failed to close DB: %w", err))
@@ -110,6 +155,13 @@ func (as *EventPool) initFromConfig(ctx context.Context, cfg *config.Config) err
 		return fmt.Errorf("failed to init crawler processor: %w", err)
 	}
 
+	as.EventScheduler = scheduler.NewEventScheduler(scheduler.NewDBEventStore(as.DB), clock.SystemClock, eventSchedulerInterval)
+	as.ChanceSnapshot = scheduler.NewChanceSnapshotJob(scheduler.NewDBChanceStatStore(as.DB), clock.SystemClock, chanceSnapshotInterval)
+	as.SoftDeletePurge = scheduler.NewSoftDeletePurgeJob(scheduler.NewDBSoftDeleteStore(as.DB), clock.SystemClock,
+		softDeletePurgeInterval, time.Duration(cfg.SoftDeleteRetentionDays)*24*time.Hour)
+	as.IdempotencyPurge = scheduler.NewIdempotencyPurgeJob(scheduler.NewDBIdempotencyKeyStore(as.DB), clock.SystemClock,
+		idempotencyPurgeInterval, time.Duration(cfg.IdempotencyKeyTTLHours)*time.Hour)
+
 	err := as.startMetricsServer(cfg.MetricsServer)
 	if err != nil {
 		log.Error("start metrics server fail", "err", err)
@@ -0,0 +1,7 @@
+-- ============================================
+-- 回滚：事件生命周期 (Event Lifecycle)
+-- ============================================
+
+DROP TABLE IF EXISTS event_status_history;
+DROP INDEX IF EXISTS idx_event_status;
+ALTER TABLE event DROP COLUMN IF EXISTS status;
@@ -0,0 +1,30 @@
+-- ============================================
+-- 事件生命周期 (Event Lifecycle)
+-- draft → upcoming → live → ended → resolved / cancelled
+-- ============================================
+
+-- 事件生命周期状态：draft=草稿, upcoming=已上线未开始, live=进行中, ended=已结束, resolved=已结算, cancelled=已取消
+ALTER TABLE event ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'draft';
+CREATE INDEX IF NOT EXISTS idx_event_status ON event(status);
+
+-- 根据 is_online / is_live 回填已有事件的状态（只处理仍为默认值的记录）
+UPDATE event SET status = CASE
+        WHEN is_live = 2 THEN 'ended'
+        WHEN is_live = 0 THEN 'live'
+        WHEN is_online THEN 'upcoming'
+        ELSE 'draft'
+    END
+WHERE status = 'draft';
+
+-- 事件状态流转记录表 --
+CREATE TABLE IF NOT EXISTS event_status_history (
+    guid               TEXT PRIMARY KEY DEFAULT replace(uuid_generate_v4()::text, '-', ''),
+    event_guid         VARCHAR(500) NOT NULL,                   -- 事件 GUID
+    from_status        VARCHAR(20) NOT NULL,                    -- 流转前状态
+    to_status          VARCHAR(20) NOT NULL,                    -- 流转后状态
+    actor              VARCHAR(255) NOT NULL,                   -- 操作人
+    reason             VARCHAR(500) NOT NULL DEFAULT '',        -- 操作原因
+    created_at         TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP,  -- 流转时间
+    updated_at         TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_event_status_history_event_guid ON event_status_history(event_guid, created_at);
@@ -0,0 +1,9 @@
+-- ============================================
+-- 回滚：市场结算 (Market Resolution)
+-- 升级时清除的未结算子事件 is_win 不会恢复
+-- ============================================
+
+DROP TABLE IF EXISTS event_resolution;
+ALTER TABLE sub_event_direction ALTER COLUMN is_win SET DEFAULT TRUE;
+DROP INDEX IF EXISTS idx_sub_event_resolution;
+ALTER TABLE sub_event DROP COLUMN IF EXISTS resolution;
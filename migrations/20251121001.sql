@@ -0,0 +1,26 @@
+-- ============================================
+-- 市场结算 (Market Resolution)
+-- ============================================
+
+-- 子事件结算状态：pending=待结算, resolved=已结算, void=作废（全部方向不计胜负）
+ALTER TABLE sub_event ADD COLUMN IF NOT EXISTS resolution VARCHAR(20) NOT NULL DEFAULT 'pending';
+CREATE INDEX IF NOT EXISTS idx_sub_event_resolution ON sub_event(resolution);
+
+-- 未结算的方向不应视为胜出
+ALTER TABLE sub_event_direction ALTER COLUMN is_win SET DEFAULT FALSE;
+UPDATE sub_event_direction SET is_win = FALSE
+WHERE is_win = TRUE AND sub_event_guid IN (SELECT guid FROM sub_event WHERE resolution = 'pending');
+
+-- 事件结算记录表（每次结算/重新结算一条，用于审计）--
+CREATE TABLE IF NOT EXISTS event_resolution (
+    guid               TEXT PRIMARY KEY DEFAULT replace(uuid_generate_v4()::text, '-', ''),
+    event_guid         VARCHAR(500) NOT NULL,                   -- 事件 GUID
+    actor              VARCHAR(255) NOT NULL,                   -- 操作人
+    result             TEXT NOT NULL DEFAULT '',                -- 结算结果说明（写入 event.experiment_result）
+    reason             VARCHAR(500) NOT NULL DEFAULT '',        -- 重新结算原因
+    is_re_resolve      BOOLEAN NOT NULL DEFAULT FALSE,          -- 是否为重新结算
+    detail             JSONB NOT NULL DEFAULT '{}'::jsonb,      -- 各子事件的结算明细
+    created_at         TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP,
+    updated_at         TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_event_resolution_event_guid ON event_resolution(event_guid, created_at);
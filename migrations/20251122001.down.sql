@@ -0,0 +1,5 @@
+-- ============================================
+-- 回滚：幂等请求 (Idempotency-Key)
+-- ============================================
+
+DROP TABLE IF EXISTS idempotency_key;
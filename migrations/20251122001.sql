@@ -0,0 +1,15 @@
+-- ============================================
+-- 幂等请求 (Idempotency-Key)
+-- ============================================
+
+-- 幂等键表：同一 scope 下相同的 Idempotency-Key 只执行一次，重试时返回首次的响应 --
+CREATE TABLE IF NOT EXISTS idempotency_key (
+    guid               TEXT PRIMARY KEY DEFAULT replace(uuid_generate_v4()::text, '-', ''),
+    scope              VARCHAR(64) NOT NULL,                    -- 接口范围，例如 create_event
+    idempotency_key    VARCHAR(255) NOT NULL,                   -- 客户端提供的 Idempotency-Key
+    request_hash       VARCHAR(64) NOT NULL,                    -- 请求体的 SHA-256，用于识别同一个键被用于不同请求
+    response           TEXT NOT NULL DEFAULT '',                -- 首次请求的响应（JSON）
+    created_at         TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP,
+    updated_at         TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_idempotency_key_scope_key ON idempotency_key(scope, idempotency_key);
@@ -0,0 +1,11 @@
+-- ============================================
+-- 回滚：事件调度时间 (Event Scheduling)
+-- ============================================
+
+DROP INDEX IF EXISTS idx_event_status_open_at;
+DROP INDEX IF EXISTS idx_event_status_start_at;
+DROP INDEX IF EXISTS idx_event_status_close_at;
+ALTER TABLE event DROP COLUMN IF EXISTS open_at;
+ALTER TABLE event DROP COLUMN IF EXISTS start_at;
+ALTER TABLE event DROP COLUMN IF EXISTS close_at;
+ALTER TABLE sub_event ALTER COLUMN end_at SET DEFAULT CURRENT_TIMESTAMP;
@@ -0,0 +1,25 @@
+-- ============================================
+-- 事件调度时间 (Event Scheduling)
+-- ============================================
+
+-- 事件开盘/开始/收盘时间（UTC），由 index 进程的调度器按时间自动上线、进入进行中、停止交易
+ALTER TABLE event ADD COLUMN IF NOT EXISTS open_at TIMESTAMP(0);   -- 开盘时间：draft → upcoming
+ALTER TABLE event ADD COLUMN IF NOT EXISTS start_at TIMESTAMP(0);  -- 开始时间：upcoming → live（为空时取 open_at）
+ALTER TABLE event ADD COLUMN IF NOT EXISTS close_at TIMESTAMP(0);  -- 收盘时间：live → ended
+CREATE INDEX IF NOT EXISTS idx_event_status_open_at ON event(status, open_at) WHERE open_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_event_status_start_at ON event(status, start_at) WHERE start_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_event_status_close_at ON event(status, close_at) WHERE close_at IS NOT NULL;
+
+-- 子事件截止时间（初始建表时默认取创建时间，未设置应为 NULL）
+ALTER TABLE sub_event ADD COLUMN IF NOT EXISTS end_at TIMESTAMP(0);
+ALTER TABLE sub_event ALTER COLUMN end_at DROP DEFAULT;
+
+-- 旧数据：open_time 为 RFC3339 字符串时回填 open_at
+UPDATE event SET open_at = (open_time::timestamptz AT TIME ZONE 'UTC')
+WHERE open_at IS NULL AND open_time ~ '^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$';
+
+-- 后台接口创建的事件曾把 start_date/end_date（Unix 秒）保存在 info 中
+UPDATE event SET start_at = (to_timestamp((info->>'start_date')::bigint) AT TIME ZONE 'UTC')
+WHERE start_at IS NULL AND info->>'start_date' ~ '^\d+$';
+UPDATE event SET close_at = (to_timestamp((info->>'end_date')::bigint) AT TIME ZONE 'UTC')
+WHERE close_at IS NULL AND info->>'end_date' ~ '^\d+$';
@@ -0,0 +1,5 @@
+-- ============================================
+-- 回滚：子事件概率走势 (Sub Event Chance History)
+-- ============================================
+
+DROP TABLE IF EXISTS sub_event_chance_stat;
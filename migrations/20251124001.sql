@@ -0,0 +1,19 @@
+-- ============================================
+-- 子事件概率走势 (Sub Event Chance History)
+-- ============================================
+
+-- 方向概率快照：index 进程定时采样，按 stat_way 降采样到不同大小的时间桶 --
+-- stat_way: 0=1h(1 分钟), 2=6h(5 分钟), 3=1d(15 分钟), 4=1w(1 小时), 5=全部(1 天)
+CREATE TABLE IF NOT EXISTS sub_event_chance_stat (
+    guid               TEXT PRIMARY KEY DEFAULT replace(uuid_generate_v4()::text, '-', ''),
+    sub_event_guid     VARCHAR(500) NOT NULL,                   -- 子事件 GUID
+    direction_guid     VARCHAR(500) NOT NULL,                   -- 方向 GUID
+    chance             SMALLINT NOT NULL,                       -- 时间桶内最后一次采样的概率
+    datetime           VARCHAR(500) NOT NULL,                   -- 时间桶起点，RFC3339 (UTC)
+    stat_way           SMALLINT NOT NULL,                       -- 统计周期
+    created_at         TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP,
+    updated_at         TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_sub_event_chance_stat_bucket ON sub_event_chance_stat(direction_guid, stat_way, datetime);
+CREATE INDEX IF NOT EXISTS idx_sub_event_chance_stat_sub_event_guid ON sub_event_chance_stat(sub_event_guid, stat_way, datetime);
+CREATE INDEX IF NOT EXISTS idx_sub_event_chance_stat_way_datetime ON sub_event_chance_stat(stat_way, datetime);
@@ -0,0 +1,7 @@
+-- ============================================
+-- 回滚：子事件做市定价 (LMSR Market Maker)
+-- 升级时写入的买卖价保留
+-- ============================================
+
+ALTER TABLE sub_event_direction DROP COLUMN IF EXISTS shares;
+ALTER TABLE sub_event DROP COLUMN IF EXISTS liquidity;
@@ -0,0 +1,46 @@
+-- ============================================
+-- 子事件做市定价 (LMSR Market Maker)
+-- ============================================
+
+-- 流动性参数 b：越大价格对交易越不敏感，做市商最大亏损为 b·ln(方向数) --
+ALTER TABLE sub_event ADD COLUMN IF NOT EXISTS liquidity NUMERIC(32,16) NOT NULL DEFAULT 100;
+
+-- 做市商已售出的份额 q：价格 p_i = exp(q_i/b) / Σ exp(q_j/b) --
+ALTER TABLE sub_event_direction ADD COLUMN IF NOT EXISTS shares NUMERIC(32,16) NOT NULL DEFAULT 0;
+
+-- 为尚未定价的子事件（全部方向买卖价和份额为 0）按现有概率初始化份额和买卖价 --
+-- 概率低于 0.5% 的方向按 0.5% 处理；买卖价为买入/卖出 1 份的均价：
+--   ask = b·ln(1 + p·(exp(1/b) - 1))，bid = -b·ln(1 + p·(exp(-1/b) - 1))
+WITH unpriced AS (
+    SELECT sub_event_guid
+    FROM sub_event_direction
+    GROUP BY sub_event_guid
+    HAVING bool_and(new_ask_price = 0 AND new_bid_price = 0 AND shares = 0)
+),
+weights AS (
+    SELECT d.guid,
+           d.sub_event_guid,
+           s.liquidity AS b,
+           CASE WHEN SUM(GREATEST(d.chance, 0)) OVER w = 0 THEN 1.0
+                ELSE GREATEST(GREATEST(d.chance, 0)::NUMERIC / SUM(GREATEST(d.chance, 0)) OVER w, 0.005)
+           END AS weight
+    FROM sub_event_direction d
+    JOIN unpriced u ON u.sub_event_guid = d.sub_event_guid
+    JOIN sub_event s ON s.guid = d.sub_event_guid
+    WINDOW w AS (PARTITION BY d.sub_event_guid)
+),
+priced AS (
+    SELECT guid,
+           b,
+           b * ln(weight / MIN(weight) OVER p) AS shares,
+           weight / SUM(weight) OVER p AS price
+    FROM weights
+    WINDOW p AS (PARTITION BY sub_event_guid)
+)
+UPDATE sub_event_direction d
+SET shares        = priced.shares,
+    new_ask_price = round(priced.b * ln(1 + priced.price * (exp(1 / priced.b) - 1)), 8),
+    new_bid_price = round(-priced.b * ln(1 + priced.price * (exp(-1 / priced.b) - 1)), 8),
+    updated_at    = CURRENT_TIMESTAMP
+FROM priced
+WHERE d.guid = priced.guid;
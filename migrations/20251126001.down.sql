@@ -0,0 +1,11 @@
+-- ============================================
+-- 回滚：订单、成交、持仓和余额 (Orders & Positions Ledger)
+-- 会删除全部订单、成交、持仓和余额记录
+-- event.trade_volume 由本迁移添加，回滚时一并删除；sub_event.trade_volume 由初始建表创建，保留
+-- ============================================
+
+DROP TABLE IF EXISTS user_position;
+DROP TABLE IF EXISTS trade_fill;
+DROP TABLE IF EXISTS trade_order;
+DROP TABLE IF EXISTS user_balance;
+ALTER TABLE event DROP COLUMN IF EXISTS trade_volume;
@@ -0,0 +1,75 @@
+-- ============================================
+-- 订单、成交、持仓和余额 (Orders & Positions Ledger)
+-- ============================================
+
+-- 成交额按 NUMERIC(38,18) 精确记账 --
+ALTER TABLE event ADD COLUMN IF NOT EXISTS trade_volume NUMERIC(38,18) NOT NULL DEFAULT 0;
+ALTER TABLE event ALTER COLUMN trade_volume TYPE NUMERIC(38,18);
+ALTER TABLE sub_event ADD COLUMN IF NOT EXISTS trade_volume NUMERIC(38,18) NOT NULL DEFAULT 0;
+ALTER TABLE sub_event ALTER COLUMN trade_volume TYPE NUMERIC(38,18);
+
+-- 用户余额：重新结算冲正时可能短暂为负，下单扣款要求余额充足 --
+CREATE TABLE IF NOT EXISTS user_balance (
+    guid               TEXT PRIMARY KEY DEFAULT replace(uuid_generate_v4()::text, '-', ''),
+    user_address       VARCHAR(100) NOT NULL,                   -- 钱包地址（小写）
+    available          NUMERIC(38,18) NOT NULL DEFAULT 0,       -- 可用余额
+    created_at         TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP,
+    updated_at         TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_user_balance_user_address ON user_balance(user_address);
+
+-- 订单：直接与做市商成交，下单成功即全部成交 --
+CREATE TABLE IF NOT EXISTS trade_order (
+    guid               TEXT PRIMARY KEY DEFAULT replace(uuid_generate_v4()::text, '-', ''),
+    user_address       VARCHAR(100) NOT NULL,
+    event_guid         VARCHAR(500) NOT NULL,
+    sub_event_guid     VARCHAR(500) NOT NULL,
+    direction_guid     VARCHAR(500) NOT NULL,
+    side               VARCHAR(10) NOT NULL,                    -- buy / sell
+    shares             NUMERIC(38,18) NOT NULL,                 -- 份额
+    limit_price        NUMERIC(38,18),                          -- 限价：买入均价上限 / 卖出均价下限
+    amount             NUMERIC(38,18) NOT NULL,                 -- 买入支付 / 卖出所得
+    average_price      NUMERIC(38,18) NOT NULL,                 -- 成交均价
+    status             VARCHAR(20) NOT NULL DEFAULT 'filled',
+    created_at         TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP,
+    updated_at         TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_trade_order_user_address ON trade_order(user_address, created_at);
+CREATE INDEX IF NOT EXISTS idx_trade_order_sub_event_guid ON trade_order(sub_event_guid);
+
+-- 成交记录 --
+CREATE TABLE IF NOT EXISTS trade_fill (
+    guid               TEXT PRIMARY KEY DEFAULT replace(uuid_generate_v4()::text, '-', ''),
+    order_guid         VARCHAR(500) NOT NULL,
+    user_address       VARCHAR(100) NOT NULL,
+    sub_event_guid     VARCHAR(500) NOT NULL,
+    direction_guid     VARCHAR(500) NOT NULL,
+    side               VARCHAR(10) NOT NULL,
+    shares             NUMERIC(38,18) NOT NULL,
+    price              NUMERIC(38,18) NOT NULL,                 -- 成交均价
+    amount             NUMERIC(38,18) NOT NULL,                 -- 成交金额
+    price_before       NUMERIC(38,18) NOT NULL,                 -- 成交前该方向的价格
+    price_after        NUMERIC(38,18) NOT NULL,                 -- 成交后该方向的价格
+    created_at         TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_trade_fill_order_guid ON trade_fill(order_guid);
+CREATE INDEX IF NOT EXISTS idx_trade_fill_direction_guid ON trade_fill(direction_guid, created_at);
+
+-- 持仓：每个用户在每个方向上一条记录，子事件结算时派彩 --
+CREATE TABLE IF NOT EXISTS user_position (
+    guid               TEXT PRIMARY KEY DEFAULT replace(uuid_generate_v4()::text, '-', ''),
+    user_address       VARCHAR(100) NOT NULL,
+    event_guid         VARCHAR(500) NOT NULL,
+    sub_event_guid     VARCHAR(500) NOT NULL,
+    direction_guid     VARCHAR(500) NOT NULL,
+    shares             NUMERIC(38,18) NOT NULL DEFAULT 0,       -- 持有份额
+    cost_basis         NUMERIC(38,18) NOT NULL DEFAULT 0,       -- 持有份额的买入成本
+    realized_pnl       NUMERIC(38,18) NOT NULL DEFAULT 0,       -- 卖出已实现盈亏
+    payout             NUMERIC(38,18) NOT NULL DEFAULT 0,       -- 结算派彩
+    status             VARCHAR(20) NOT NULL DEFAULT 'open',     -- open / settled
+    settled_at         TIMESTAMP(0),
+    created_at         TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP,
+    updated_at         TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_user_position_direction ON user_position(user_address, direction_guid);
+CREATE INDEX IF NOT EXISTS idx_user_position_sub_event_guid ON user_position(sub_event_guid);
@@ -0,0 +1,6 @@
+-- ============================================
+-- 回滚：运动类事件比分推送 (Live Score Feed)
+-- ============================================
+
+DROP TABLE IF EXISTS event_score_history;
+ALTER TABLE event DROP COLUMN IF EXISTS sport;
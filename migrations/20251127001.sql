@@ -0,0 +1,21 @@
+-- ============================================
+-- 运动类事件比分推送 (Live Score Feed)
+-- ============================================
+
+-- 运动项目：决定比赛阶段的顺序，例如 basketball 为 Q1 → Q2 → HT → Q3 → Q4 → OT → FT --
+ALTER TABLE event ADD COLUMN IF NOT EXISTS sport VARCHAR(32) NOT NULL DEFAULT '';
+
+-- 比分变更记录：每次推送带来的比分或阶段变化记录一条 --
+CREATE TABLE IF NOT EXISTS event_score_history (
+    guid                 TEXT PRIMARY KEY DEFAULT replace(uuid_generate_v4()::text, '-', ''),
+    event_guid           VARCHAR(500) NOT NULL,                   -- 事件 GUID
+    from_main_score      UINT256 NOT NULL,                        -- 变更前主队得分
+    from_cluster_score   UINT256 NOT NULL,                        -- 变更前客队得分
+    from_stage           VARCHAR(20) NOT NULL,                    -- 变更前比赛阶段
+    main_score           UINT256 NOT NULL,                        -- 变更后主队得分
+    cluster_score        UINT256 NOT NULL,                        -- 变更后客队得分
+    stage                VARCHAR(20) NOT NULL,                    -- 变更后比赛阶段
+    source               VARCHAR(100) NOT NULL DEFAULT '',        -- 比分源
+    created_at           TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP   -- 变更时间
+);
+CREATE INDEX IF NOT EXISTS idx_event_score_history_event_guid ON event_score_history(event_guid, created_at);
@@ -0,0 +1,8 @@
+-- ============================================
+-- 回滚：运动队管理 (Team Groups)
+-- 升级时删除的重复多语言记录不会恢复
+-- ============================================
+
+DROP INDEX IF EXISTS uq_team_group_language_team_lang;
+DROP INDEX IF EXISTS idx_event_main_team_group_guid;
+DROP INDEX IF EXISTS idx_event_cluster_team_group_guid;
@@ -0,0 +1,15 @@
+-- ============================================
+-- 运动队管理 (Team Groups)
+-- ============================================
+
+-- 同一运动队在同一语言下只保留最近更新的一条名称，再建立唯一索引供名称 upsert 使用 --
+DELETE FROM team_group_language t
+USING team_group_language newer
+WHERE t.team_group_guid = newer.team_group_guid
+  AND t.language_guid = newer.language_guid
+  AND (COALESCE(t.updated_at, 'epoch'), t.guid) < (COALESCE(newer.updated_at, 'epoch'), newer.guid);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_team_group_language_team_lang ON team_group_language(team_group_guid, language_guid);
+
+-- 按主队、客队查询事件（列表过滤和删除运动队前的引用检查） --
+CREATE INDEX IF NOT EXISTS idx_event_main_team_group_guid ON event(main_team_group_guid);
+CREATE INDEX IF NOT EXISTS idx_event_cluster_team_group_guid ON event(cluster_team_group_guid);
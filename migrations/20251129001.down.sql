@@ -0,0 +1,8 @@
+-- ============================================
+-- 回滚：分类管理 (Categories)
+-- 升级时删除的重复多语言记录不会恢复
+-- ============================================
+
+DROP INDEX IF EXISTS uq_category_language_cat_lang;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_category_language_lang_cat_not_deleted ON category_language(language_guid, category_guid)
+    WHERE deleted_at IS NULL; -- 确保同一种 language 下，同一个 category 只能有 1 条
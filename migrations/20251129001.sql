@@ -0,0 +1,13 @@
+-- ============================================
+-- 分类管理 (Categories)
+-- ============================================
+
+-- 同一分类在同一语言下只保留最近更新的一条，再建立唯一索引供多语言信息 upsert 使用；
+-- 替换初始建表语句中带 deleted_at 条件的同列唯一索引（category_language 没有 deleted_at 列，ON CONFLICT 也无法推断部分索引）--
+DELETE FROM category_language t
+USING category_language newer
+WHERE t.category_guid = newer.category_guid
+  AND t.language_guid = newer.language_guid
+  AND (COALESCE(t.updated_at, 'epoch'), t.guid) < (COALESCE(newer.updated_at, 'epoch'), newer.guid);
+DROP INDEX IF EXISTS uq_category_language_lang_cat_not_deleted;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_category_language_cat_lang ON category_language(category_guid, language_guid);
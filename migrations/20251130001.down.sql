@@ -0,0 +1,10 @@
+-- ============================================
+-- 回滚：生态、时间标签管理 (Ecosystems & Event Periods)
+-- 升级时删除的重复多语言记录不会恢复；事件数为 0 的生态不满足原约束，约束以 NOT VALID 恢复
+-- ============================================
+
+DROP INDEX IF EXISTS uq_ecosystem_language_eco_lang;
+DROP INDEX IF EXISTS uq_event_period_language_period_lang;
+DROP INDEX IF EXISTS idx_event_event_period_guid;
+ALTER TABLE ecosystem ALTER COLUMN event_num DROP DEFAULT;
+ALTER TABLE ecosystem ADD CONSTRAINT ecosystem_event_num_check CHECK (event_num > 0) NOT VALID;
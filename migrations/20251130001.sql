@@ -0,0 +1,37 @@
+-- ============================================
+-- 生态、时间标签管理 (Ecosystems & Event Periods)
+-- ============================================
+
+-- event_num 改为应用维护的事件计数：允许为 0，新建生态默认为 0 --
+ALTER TABLE ecosystem DROP CONSTRAINT IF EXISTS ecosystem_event_num_check;
+ALTER TABLE ecosystem ALTER COLUMN event_num SET DEFAULT 0;
+
+-- 按现有事件重算计数，之后由事件的创建、移动、删除在同一事务中维护 --
+UPDATE ecosystem e
+SET event_num = counts.event_num
+FROM (
+    SELECT g.guid, COUNT(ev.guid) AS event_num
+    FROM ecosystem g
+    LEFT JOIN event ev ON ev.ecosystem_guid = g.guid
+    GROUP BY g.guid
+) counts
+WHERE e.guid = counts.guid
+  AND e.event_num IS DISTINCT FROM counts.event_num;
+
+-- 同一生态、时间标签在同一语言下只保留最近更新的一条，再建立唯一索引供多语言信息 upsert 使用 --
+DELETE FROM ecosystem_language t
+USING ecosystem_language newer
+WHERE t.ecosystem_guid = newer.ecosystem_guid
+  AND t.language_guid = newer.language_guid
+  AND (COALESCE(t.updated_at, 'epoch'), t.guid) < (COALESCE(newer.updated_at, 'epoch'), newer.guid);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_ecosystem_language_eco_lang ON ecosystem_language(ecosystem_guid, language_guid);
+
+DELETE FROM event_period_language t
+USING event_period_language newer
+WHERE t.event_period_guid = newer.event_period_guid
+  AND t.language_guid = newer.language_guid
+  AND (COALESCE(t.updated_at, 'epoch'), t.guid) < (COALESCE(newer.updated_at, 'epoch'), newer.guid);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_event_period_language_period_lang ON event_period_language(event_period_guid, language_guid);
+
+-- 按时间标签统计事件（删除前的引用检查） --
+CREATE INDEX IF NOT EXISTS idx_event_event_period_guid ON event(event_period_guid);
@@ -0,0 +1,6 @@
+-- ============================================
+-- 回滚：语言管理 (Languages)
+-- ============================================
+
+DROP INDEX IF EXISTS uq_languages_single_default;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_languages_default ON languages (is_default) WHERE is_default = TRUE AND deleted_at IS NULL;  -- 确保只有一个默认语言
@@ -0,0 +1,18 @@
+-- ============================================
+-- 语言管理 (Languages)
+-- ============================================
+
+-- 存在多个默认语言时只保留最早创建的一个 --
+UPDATE languages l
+SET is_default = FALSE, updated_at = CURRENT_TIMESTAMP
+WHERE l.is_default
+  AND EXISTS (
+      SELECT 1 FROM languages earlier
+      WHERE earlier.is_default
+        AND (COALESCE(earlier.created_at, 'epoch'), earlier.guid) < (COALESCE(l.created_at, 'epoch'), l.guid)
+  );
+
+-- 同一时刻只能有一个默认语言，切换默认语言时先取消原默认语言再设置；
+-- 替换初始建表语句中同列的 uq_languages_default，避免两个唯一索引重复维护 --
+DROP INDEX IF EXISTS uq_languages_default;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_languages_single_default ON languages (is_default) WHERE is_default;
@@ -0,0 +1,16 @@
+-- ============================================
+-- 回滚：软删除 (Soft Delete)
+-- 已软删除的事件和运动队会重新可见；其余表的 deleted_at 被初始建表语句中的部分索引引用，保留
+-- ============================================
+
+DROP INDEX IF EXISTS idx_event_period_active_sort;
+CREATE INDEX IF NOT EXISTS idx_event_period_active_sort ON event_period(is_active, sort_order, created_at) WHERE deleted_at IS NULL;  -- 复合索引：优化列表查询
+
+DROP INDEX IF EXISTS idx_languages_deleted_at;
+DROP INDEX IF EXISTS idx_category_deleted_at;
+DROP INDEX IF EXISTS idx_ecosystem_deleted_at;
+DROP INDEX IF EXISTS idx_event_period_deleted_at;
+DROP INDEX IF EXISTS idx_team_group_deleted_at;
+DROP INDEX IF EXISTS idx_event_deleted_at;
+ALTER TABLE team_group DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE event DROP COLUMN IF EXISTS deleted_at;
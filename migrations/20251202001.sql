@@ -0,0 +1,26 @@
+-- ============================================
+-- 软删除 (Soft Delete)
+-- 删除只写入 deleted_at，可通过恢复接口撤销；index 进程的清理任务定期硬删除超过保留期的记录
+-- ============================================
+
+-- 为支持软删除的表及初始建表语句中按 deleted_at 建立部分索引的表补充 deleted_at 列 --
+ALTER TABLE sys_roles ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP(0);
+ALTER TABLE sys_users ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP(0);
+ALTER TABLE languages ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP(0);
+ALTER TABLE category ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP(0);
+ALTER TABLE ecosystem ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP(0);
+ALTER TABLE event_period ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP(0);
+ALTER TABLE team_group ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP(0);
+ALTER TABLE event ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMP(0);
+
+-- 清理任务按 deleted_at 查找超过保留期的记录，部分索引只包含已删除的记录 --
+CREATE INDEX IF NOT EXISTS idx_languages_deleted_at ON languages(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_category_deleted_at ON category(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_ecosystem_deleted_at ON ecosystem(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_event_period_deleted_at ON event_period(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_team_group_deleted_at ON team_group(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_event_deleted_at ON event(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- event_period 没有 sort_order 列，按启用状态和创建时间重建列表索引 --
+DROP INDEX IF EXISTS idx_event_period_active_sort;
+CREATE INDEX IF NOT EXISTS idx_event_period_active_sort ON event_period(is_active, created_at) WHERE deleted_at IS NULL;  -- 复合索引：优化列表查询
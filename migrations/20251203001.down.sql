@@ -0,0 +1,6 @@
+-- ============================================
+-- 回滚：后台入账记录 (Balance Credit Audit)
+-- 会删除全部入账记录，余额本身不受影响
+-- ============================================
+
+DROP TABLE IF EXISTS balance_credit;
@@ -0,0 +1,14 @@
+-- ============================================
+-- 后台入账记录 (Balance Credit Audit)
+-- ============================================
+
+-- 后台入账记录：每次入账记录一条，operator 为签发令牌中的操作人 --
+CREATE TABLE IF NOT EXISTS balance_credit (
+    guid               TEXT PRIMARY KEY DEFAULT replace(uuid_generate_v4()::text, '-', ''),
+    user_address       VARCHAR(100) NOT NULL,                   -- 钱包地址（小写）
+    amount             NUMERIC(38,18) NOT NULL,                 -- 入账金额
+    operator           VARCHAR(100) NOT NULL,                   -- 操作人
+    created_at         TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP
+);
+CREATE INDEX IF NOT EXISTS idx_balance_credit_user_address ON balance_credit(user_address, created_at);
+CREATE INDEX IF NOT EXISTS idx_balance_credit_operator ON balance_credit(operator, created_at);
@@ -0,0 +1,5 @@
+-- ============================================
+-- 回滚：幂等键过期清理 (Idempotency Key TTL)
+-- ============================================
+
+DROP INDEX IF EXISTS idx_idempotency_key_created_at;
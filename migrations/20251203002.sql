@@ -0,0 +1,6 @@
+-- ============================================
+-- 幂等键过期清理 (Idempotency Key TTL)
+-- ============================================
+
+-- 清理任务按创建时间删除过期的幂等键 --
+CREATE INDEX IF NOT EXISTS idx_idempotency_key_created_at ON idempotency_key(created_at);
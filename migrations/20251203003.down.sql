@@ -0,0 +1,7 @@
+-- ============================================
+-- 回滚：事件标签 (Event Tags)
+-- 会删除全部标签及事件与标签的关联
+-- ============================================
+
+DROP TABLE IF EXISTS event_tag;
+DROP TABLE IF EXISTS tag;
@@ -0,0 +1,22 @@
+-- ============================================
+-- 事件标签 (Event Tags)
+-- ============================================
+
+-- 标签：名称唯一，创建事件时同名标签复用 --
+CREATE TABLE IF NOT EXISTS tag (
+    guid               TEXT PRIMARY KEY DEFAULT replace(uuid_generate_v4()::text, '-', ''),
+    name               VARCHAR(100) NOT NULL,                   -- 标签名称
+    created_at         TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP,
+    updated_at         TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_tag_name ON tag(name);
+
+-- 事件与标签的关联：随事件一起清理 --
+CREATE TABLE IF NOT EXISTS event_tag (
+    guid               TEXT PRIMARY KEY DEFAULT replace(uuid_generate_v4()::text, '-', ''),
+    event_guid         VARCHAR(500) NOT NULL,
+    tag_guid           VARCHAR(500) NOT NULL,
+    created_at         TIMESTAMP(0) DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX IF NOT EXISTS uq_event_tag_event_tag ON event_tag(event_guid, tag_guid);
+CREATE INDEX IF NOT EXISTS idx_event_tag_tag_guid ON event_tag(tag_guid);
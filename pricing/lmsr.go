@@ -0,0 +1,174 @@
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	// DefaultLiquidity 默认流动性参数 b：b 越大价格对交易越不敏感，做市商最大亏损为 b·ln(方向数)
+	DefaultLiquidity = 100.0
+	// QuoteUnit 计算买卖报价时使用的交易份额
+	QuoteUnit = 1.0
+	// minSeedProbability 初始化市场时方向的最小概率，LMSR 无法表示 0 概率
+	minSeedProbability = 0.005
+)
+
+var (
+	// ErrInvalidLiquidity 流动性参数必须为正数
+	ErrInvalidLiquidity = errors.New("liquidity must be positive")
+	// ErrInvalidOutcome 方向下标越界
+	ErrInvalidOutcome = errors.New("outcome index out of range")
+)
+
+// LMSR 对数市场评分规则 (Logarithmic Market Scoring Rule) 做市商
+// 成本函数 C(q) = b·ln(Σ exp(q_i/b))，方向 i 的价格 p_i = exp(q_i/b) / Σ exp(q_j/b)
+// 价格总是在 (0, 1) 之间且总和为 1，可以直接作为概率
+type LMSR struct {
+	Liquidity float64 // 流动性参数 b
+}
+
+// NewLMSR 创建做市商，liquidity 必须为正数
+func NewLMSR(liquidity float64) (*LMSR, error) {
+	if !(liquidity > 0) || math.IsInf(liquidity, 0) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidLiquidity, liquidity)
+	}
+	return &LMSR{Liquidity: liquidity}, nil
+}
+
+// Cost 成本函数 C(q)，使用 log-sum-exp 避免溢出
+func (m *LMSR) Cost(shares []float64) float64 {
+	if len(shares) == 0 {
+		return 0
+	}
+	maxScaled := math.Inf(-1)
+	for _, q := range shares {
+		maxScaled = math.Max(maxScaled, q/m.Liquidity)
+	}
+	var sum float64
+	for _, q := range shares {
+		sum += math.Exp(q/m.Liquidity - maxScaled)
+	}
+	return m.Liquidity * (maxScaled + math.Log(sum))
+}
+
+// Prices 各方向的瞬时价格，总和为 1
+func (m *LMSR) Prices(shares []float64) []float64 {
+	prices := make([]float64, len(shares))
+	if len(shares) == 0 {
+		return prices
+	}
+	maxScaled := math.Inf(-1)
+	for _, q := range shares {
+		maxScaled = math.Max(maxScaled, q/m.Liquidity)
+	}
+	var sum float64
+	for i, q := range shares {
+		prices[i] = math.Exp(q/m.Liquidity - maxScaled)
+		sum += prices[i]
+	}
+	for i := range prices {
+		prices[i] /= sum
+	}
+	return prices
+}
+
+// TradeCost 买入 amount 份方向 outcome 需要支付的金额，amount 为负数表示卖出，返回值为负数表示卖出所得
+func (m *LMSR) TradeCost(shares []float64, outcome int, amount float64) (float64, error) {
+	if outcome < 0 || outcome >= len(shares) {
+		return 0, fmt.Errorf("%w: %d", ErrInvalidOutcome, outcome)
+	}
+	after := make([]float64, len(shares))
+	copy(after, shares)
+	after[outcome] += amount
+	return m.Cost(after) - m.Cost(shares), nil
+}
+
+// Quote 方向 outcome 按 unit 份交易的买价（ask，每份买入均价）和卖价（bid，每份卖出均价）
+// 满足 0 < bid < 价格 < ask < 1
+func (m *LMSR) Quote(shares []float64, outcome int, unit float64) (ask, bid float64, err error) {
+	buyCost, err := m.TradeCost(shares, outcome, unit)
+	if err != nil {
+		return 0, 0, err
+	}
+	sellCost, err := m.TradeCost(shares, outcome, -unit)
+	if err != nil {
+		return 0, 0, err
+	}
+	return buyCost / unit, -sellCost / unit, nil
+}
+
+// SeedShares 生成使各方向价格等于给定概率的初始份额，概率会先归一化
+// 概率不大于 0 的方向按最小概率处理；最小份额为 0
+func (m *LMSR) SeedShares(probabilities []float64) []float64 {
+	shares := make([]float64, len(probabilities))
+	if len(probabilities) == 0 {
+		return shares
+	}
+
+	var total float64
+	for _, p := range probabilities {
+		if p > 0 {
+			total += p
+		}
+	}
+	normalized := make([]float64, len(probabilities))
+	for i, p := range probabilities {
+		switch {
+		case total == 0:
+			normalized[i] = 1 / float64(len(probabilities))
+		case p > 0:
+			normalized[i] = math.Max(p/total, minSeedProbability)
+		default:
+			normalized[i] = minSeedProbability
+		}
+	}
+
+	// p_i ∝ exp(q_i/b)，取 q_i = b·ln(p_i) 再整体平移使最小份额为 0（平移不改变价格）
+	minShares := math.Inf(1)
+	for i, p := range normalized {
+		shares[i] = m.Liquidity * math.Log(p)
+		minShares = math.Min(minShares, shares[i])
+	}
+	for i := range shares {
+		shares[i] -= minShares
+	}
+	return shares
+}
+
+// EntryShares 向已有方向的市场加入新方向时，使新方向价格为 probability 所需的份额
+// 新方向加入后原有方向的价格等比例摊薄；probability 会被限制在 [最小概率, 1-最小概率] 之间
+func (m *LMSR) EntryShares(shares []float64, probability float64) float64 {
+	p := math.Min(math.Max(probability, minSeedProbability), 1-minSeedProbability)
+	// exp(q/b) / (Σ exp(q_j/b) + exp(q/b)) = p  =>  q = C(shares) + b·ln(p/(1-p))
+	return m.Cost(shares) + m.Liquidity*math.Log(p/(1-p))
+}
+
+// Chances 将价格换算为百分比概率，使用最大余数法保证总和为 100
+func Chances(prices []float64) []int16 {
+	chances := make([]int16, len(prices))
+	if len(prices) == 0 {
+		return chances
+	}
+
+	remainders := make([]float64, len(prices))
+	allocated := 0
+	for i, p := range prices {
+		scaled := p * 100
+		chances[i] = int16(math.Floor(scaled))
+		remainders[i] = scaled - math.Floor(scaled)
+		allocated += int(chances[i])
+	}
+	for ; allocated < 100; allocated++ {
+		best := 0
+		for i := range remainders {
+			if remainders[i] > remainders[best] {
+				best = i
+			}
+		}
+		chances[best]++
+		remainders[best] = -1
+	}
+	return chances
+}
@@ -0,0 +1,244 @@
+package pricing
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	propertyRuns = 1000
+	epsilon      = 1e-9
+)
+
+// randomMarket 生成随机的流动性参数和各方向份额
+func randomMarket(r *rand.Rand) (*LMSR, []float64) {
+	m, err := NewLMSR(1 + r.Float64()*1000)
+	if err != nil {
+		panic(err)
+	}
+	shares := make([]float64, 2+r.Intn(6))
+	for i := range shares {
+		shares[i] = (r.Float64() - 0.3) * 5 * m.Liquidity
+	}
+	return m, shares
+}
+
+func sum(values []float64) float64 {
+	var total float64
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
+
+func TestNewLMSRRejectsInvalidLiquidity(t *testing.T) {
+	for _, liquidity := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		_, err := NewLMSR(liquidity)
+		require.ErrorIs(t, err, ErrInvalidLiquidity, "liquidity %v", liquidity)
+	}
+}
+
+func TestPricesSumToOne(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < propertyRuns; i++ {
+		m, shares := randomMarket(r)
+		prices := m.Prices(shares)
+		for _, p := range prices {
+			require.Greater(t, p, 0.0)
+			require.Less(t, p, 1.0)
+		}
+		require.InDelta(t, 1, sum(prices), epsilon)
+	}
+}
+
+func TestPricesAreTranslationInvariant(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for i := 0; i < propertyRuns; i++ {
+		m, shares := randomMarket(r)
+		shift := (r.Float64() - 0.5) * 10 * m.Liquidity
+		shifted := make([]float64, len(shares))
+		for j := range shares {
+			shifted[j] = shares[j] + shift
+		}
+		require.InDeltaSlice(t, m.Prices(shares), m.Prices(shifted), epsilon)
+	}
+}
+
+func TestBuyingRaisesOwnPriceAndLowersOthers(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for i := 0; i < propertyRuns; i++ {
+		m, shares := randomMarket(r)
+		outcome := r.Intn(len(shares))
+		before := m.Prices(shares)
+		shares[outcome] += (0.01 + r.Float64()) * m.Liquidity
+		after := m.Prices(shares)
+		for j := range before {
+			if j == outcome {
+				require.Greater(t, after[j], before[j])
+			} else {
+				require.Less(t, after[j], before[j])
+			}
+		}
+	}
+}
+
+func TestQuoteBracketsPrice(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+	for i := 0; i < propertyRuns; i++ {
+		m, shares := randomMarket(r)
+		prices := m.Prices(shares)
+		for outcome := range shares {
+			ask, bid, err := m.Quote(shares, outcome, QuoteUnit)
+			require.NoError(t, err)
+			require.GreaterOrEqual(t, bid, 0.0)
+			require.LessOrEqual(t, bid, prices[outcome]+epsilon)
+			require.GreaterOrEqual(t, ask, prices[outcome]-epsilon)
+			require.LessOrEqual(t, ask, 1.0)
+		}
+	}
+}
+
+func TestTradeCostIsPathIndependent(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	for i := 0; i < propertyRuns; i++ {
+		m, shares := randomMarket(r)
+		initial := append([]float64(nil), shares...)
+
+		var paid float64
+		for step := 0; step < 10; step++ {
+			outcome := r.Intn(len(shares))
+			amount := (r.Float64() - 0.4) * m.Liquidity
+			cost, err := m.TradeCost(shares, outcome, amount)
+			require.NoError(t, err)
+			paid += cost
+			shares[outcome] += amount
+		}
+		require.InDelta(t, m.Cost(shares)-m.Cost(initial), paid, 1e-6*m.Liquidity)
+	}
+}
+
+func TestRoundTripTradeIsFree(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+	for i := 0; i < propertyRuns; i++ {
+		m, shares := randomMarket(r)
+		outcome := r.Intn(len(shares))
+		amount := r.Float64() * m.Liquidity
+
+		buy, err := m.TradeCost(shares, outcome, amount)
+		require.NoError(t, err)
+		require.Greater(t, buy, 0.0)
+		require.Less(t, buy, amount)
+		shares[outcome] += amount
+		sell, err := m.TradeCost(shares, outcome, -amount)
+		require.NoError(t, err)
+		require.InDelta(t, 0, buy+sell, 1e-6*m.Liquidity)
+	}
+}
+
+func TestMarketMakerLossIsBounded(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+	for i := 0; i < propertyRuns; i++ {
+		m, _ := randomMarket(r)
+		n := 2 + r.Intn(6)
+		shares := make([]float64, n)
+
+		// 从均匀初始状态开始，任意交易后无论哪个方向胜出，做市商亏损不超过 b·ln(n)
+		var collected float64
+		for step := 0; step < 20; step++ {
+			outcome := r.Intn(n)
+			amount := r.Float64() * 3 * m.Liquidity
+			cost, err := m.TradeCost(shares, outcome, amount)
+			require.NoError(t, err)
+			collected += cost
+			shares[outcome] += amount
+		}
+		for winner := range shares {
+			loss := shares[winner] - collected
+			require.LessOrEqual(t, loss, m.Liquidity*math.Log(float64(n))+epsilon*m.Liquidity)
+		}
+	}
+}
+
+func TestSeedSharesReproducesProbabilities(t *testing.T) {
+	r := rand.New(rand.NewSource(8))
+	for i := 0; i < propertyRuns; i++ {
+		m, _ := randomMarket(r)
+		probabilities := make([]float64, 2+r.Intn(6))
+		for j := range probabilities {
+			probabilities[j] = 0.05 + r.Float64()
+		}
+		total := sum(probabilities)
+
+		shares := m.SeedShares(probabilities)
+		require.InDelta(t, 0, slices.Min(shares), epsilon)
+		prices := m.Prices(shares)
+		for j := range probabilities {
+			require.InDelta(t, probabilities[j]/total, prices[j], 1e-9)
+		}
+	}
+}
+
+func TestSeedSharesHandlesZeroProbabilities(t *testing.T) {
+	m, err := NewLMSR(DefaultLiquidity)
+	require.NoError(t, err)
+
+	prices := m.Prices(m.SeedShares([]float64{0, 0, 0, 0}))
+	require.InDeltaSlice(t, []float64{0.25, 0.25, 0.25, 0.25}, prices, epsilon)
+
+	prices = m.Prices(m.SeedShares([]float64{100, 0}))
+	require.Greater(t, prices[1], 0.0)
+	require.InDelta(t, 1, sum(prices), epsilon)
+}
+
+func TestEntrySharesPricesNewOutcome(t *testing.T) {
+	r := rand.New(rand.NewSource(10))
+	for i := 0; i < propertyRuns; i++ {
+		m, shares := randomMarket(r)
+		before := m.Prices(shares)
+		probability := 0.01 + r.Float64()*0.98
+
+		after := m.Prices(append(shares, m.EntryShares(shares, probability)))
+		require.InDelta(t, probability, after[len(shares)], 1e-9)
+		// 原有方向按比例摊薄，相对价格不变
+		for j := range before {
+			require.InDelta(t, before[j]*(1-probability), after[j], 1e-9)
+		}
+	}
+}
+
+func TestChancesSumToHundred(t *testing.T) {
+	r := rand.New(rand.NewSource(9))
+	for i := 0; i < propertyRuns; i++ {
+		m, shares := randomMarket(r)
+		prices := m.Prices(shares)
+		chances := Chances(prices)
+
+		var total int
+		for j, c := range chances {
+			require.GreaterOrEqual(t, c, int16(0))
+			// 最大余数法下每个方向与精确百分比的误差小于 1
+			require.Less(t, math.Abs(float64(c)-prices[j]*100), 1.0)
+			total += int(c)
+		}
+		require.Equal(t, 100, total)
+	}
+}
+
+func TestChancesKeepsOrder(t *testing.T) {
+	require.Equal(t, []int16{34, 33, 33}, Chances([]float64{1.0 / 3, 1.0 / 3, 1.0 / 3}))
+	require.Equal(t, []int16{60, 40}, Chances([]float64{0.6, 0.4}))
+	require.Empty(t, Chances(nil))
+}
+
+func TestTradeCostRejectsUnknownOutcome(t *testing.T) {
+	m, err := NewLMSR(DefaultLiquidity)
+	require.NoError(t, err)
+	_, err = m.TradeCost([]float64{0, 0}, 2, 1)
+	require.ErrorIs(t, err, ErrInvalidOutcome)
+	_, _, err = m.Quote([]float64{0, 0}, -1, QuoteUnit)
+	require.ErrorIs(t, err, ErrInvalidOutcome)
+}
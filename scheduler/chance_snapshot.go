@@ -0,0 +1,125 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/multimarket-labs/event-pod-services/common/clock"
+	"github.com/multimarket-labs/event-pod-services/database"
+)
+
+// ChanceStatStore 概率快照任务的数据接口
+type ChanceStatStore interface {
+	// ListTradingDirections 获取交易中事件下的全部方向
+	ListTradingDirections(ctx context.Context) ([]database.SubEventDirection, error)
+	// UpsertChanceStats 写入概率快照，同一时间桶覆盖为最新概率
+	UpsertChanceStats(ctx context.Context, stats []database.SubEventChanceStat) error
+	// DeleteChanceStatsBefore 删除某统计周期中早于 before 的时间桶
+	DeleteChanceStatsBefore(ctx context.Context, statWay int16, before time.Time) error
+}
+
+// ChanceSnapshotJob 定时记录每个方向的概率，并降采样到各统计周期的时间桶
+type ChanceSnapshotJob struct {
+	store    ChanceStatStore
+	clock    clock.Clock
+	interval time.Duration
+	loop     *clock.LoopFn
+}
+
+// NewChanceSnapshotJob 创建概率快照任务，每隔 interval 采样一次
+func NewChanceSnapshotJob(store ChanceStatStore, clk clock.Clock, interval time.Duration) *ChanceSnapshotJob {
+	return &ChanceSnapshotJob{
+		store:    store,
+		clock:    clk,
+		interval: interval,
+	}
+}
+
+// Start 启动采样循环
+func (j *ChanceSnapshotJob) Start() {
+	j.loop = clock.NewLoopFn(j.clock, j.tick, nil, j.interval)
+	log.Info("chance snapshot job started", "interval", j.interval)
+}
+
+// Close 停止采样循环并等待进行中的一轮结束
+func (j *ChanceSnapshotJob) Close() error {
+	if j.loop == nil {
+		return nil
+	}
+	return j.loop.Close()
+}
+
+func (j *ChanceSnapshotJob) tick(ctx context.Context) {
+	if err := j.RunOnce(ctx); err != nil {
+		log.Error("chance snapshot failed", "err", err)
+	}
+}
+
+// RunOnce 按当前时间采样一次：写入各统计周期的当前时间桶，并清理超出窗口的旧时间桶
+func (j *ChanceSnapshotJob) RunOnce(ctx context.Context) error {
+	now := j.clock.Now()
+
+	directions, err := j.store.ListTradingDirections(ctx)
+	if err != nil {
+		return err
+	}
+
+	stats := make([]database.SubEventChanceStat, 0, len(directions)*len(database.ChanceStatWindows))
+	for _, window := range database.ChanceStatWindows {
+		bucket := window.Bucket(now).Format(time.RFC3339)
+		for _, direction := range directions {
+			stats = append(stats, database.SubEventChanceStat{
+				SubEventGUID:  direction.SubEventGUID,
+				DirectionGUID: direction.GUID,
+				Chance:        direction.Chance,
+				Datetime:      bucket,
+				StatWay:       window.StatWay,
+			})
+		}
+	}
+	if err := j.store.UpsertChanceStats(ctx, stats); err != nil {
+		return err
+	}
+
+	var result error
+	for _, window := range database.ChanceStatWindows {
+		since := window.Since(now)
+		if since == nil {
+			continue
+		}
+		if err := j.store.DeleteChanceStatsBefore(ctx, window.StatWay, *since); err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to prune %s chance stats: %w", window.Name, err))
+		}
+	}
+
+	log.Debug("chance snapshot recorded", "directions", len(directions), "at", now)
+	return result
+}
+
+// dbChanceStatStore 基于数据库的 ChanceStatStore 实现
+type dbChanceStatStore struct {
+	db   *database.DB
+	repo database.ChanceStatRepository
+}
+
+// NewDBChanceStatStore 创建基于数据库的 ChanceStatStore
+func NewDBChanceStatStore(db *database.DB) ChanceStatStore {
+	return &dbChanceStatStore{db: db, repo: database.NewChanceStatRepository()}
+}
+
+func (s *dbChanceStatStore) ListTradingDirections(ctx context.Context) ([]database.SubEventDirection, error) {
+	return s.repo.ListTradingDirections(s.db.GetGorm().WithContext(ctx))
+}
+
+func (s *dbChanceStatStore) UpsertChanceStats(ctx context.Context, stats []database.SubEventChanceStat) error {
+	return s.repo.UpsertChanceStats(s.db.GetGorm().WithContext(ctx), stats)
+}
+
+func (s *dbChanceStatStore) DeleteChanceStatsBefore(ctx context.Context, statWay int16, before time.Time) error {
+	_, err := s.repo.DeleteChanceStatsBefore(s.db.GetGorm().WithContext(ctx), statWay, before)
+	return err
+}
@@ -0,0 +1,143 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/multimarket-labs/event-pod-services/common/clock"
+	"github.com/multimarket-labs/event-pod-services/database"
+)
+
+type chanceStatKey struct {
+	directionGUID string
+	statWay       int16
+	datetime      string
+}
+
+// fakeChanceStatStore 内存中的 ChanceStatStore
+type fakeChanceStatStore struct {
+	mu         sync.Mutex
+	directions []database.SubEventDirection
+	stats      map[chanceStatKey]int16
+}
+
+func newFakeChanceStatStore(directions ...database.SubEventDirection) *fakeChanceStatStore {
+	return &fakeChanceStatStore{directions: directions, stats: make(map[chanceStatKey]int16)}
+}
+
+func (s *fakeChanceStatStore) ListTradingDirections(context.Context) ([]database.SubEventDirection, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]database.SubEventDirection(nil), s.directions...), nil
+}
+
+func (s *fakeChanceStatStore) UpsertChanceStats(_ context.Context, stats []database.SubEventChanceStat) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, stat := range stats {
+		s.stats[chanceStatKey{stat.DirectionGUID, stat.StatWay, stat.Datetime}] = stat.Chance
+	}
+	return nil
+}
+
+func (s *fakeChanceStatStore) DeleteChanceStatsBefore(_ context.Context, statWay int16, before time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key := range s.stats {
+		if key.statWay == statWay && key.datetime < before.UTC().Format(time.RFC3339) {
+			delete(s.stats, key)
+		}
+	}
+	return nil
+}
+
+func (s *fakeChanceStatStore) setChance(directionGUID string, chance int16) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.directions {
+		if s.directions[i].GUID == directionGUID {
+			s.directions[i].Chance = chance
+		}
+	}
+}
+
+// points 返回某方向某统计周期的时间桶数量
+func (s *fakeChanceStatStore) points(directionGUID string, statWay int16) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := 0
+	for key := range s.stats {
+		if key.directionGUID == directionGUID && key.statWay == statWay {
+			n++
+		}
+	}
+	return n
+}
+
+func (s *fakeChanceStatStore) chance(directionGUID string, statWay int16, bucket time.Time) (int16, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	chance, ok := s.stats[chanceStatKey{directionGUID, statWay, bucket.UTC().Format(time.RFC3339)}]
+	return chance, ok
+}
+
+func TestChanceSnapshotDownsamplesIntoBuckets(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cl := clock.NewDeterministicClock(start)
+	store := newFakeChanceStatStore(
+		database.SubEventDirection{GUID: "yes", SubEventGUID: "sub", Chance: 50},
+		database.SubEventDirection{GUID: "no", SubEventGUID: "sub", Chance: 50},
+	)
+	job := NewChanceSnapshotJob(store, cl, time.Minute)
+
+	require.NoError(t, job.RunOnce(context.Background()))
+
+	// 同一时间桶内的后一次采样覆盖前一次
+	cl.AdvanceTime(30 * time.Second)
+	store.setChance("yes", 60)
+	require.NoError(t, job.RunOnce(context.Background()))
+
+	for _, window := range database.ChanceStatWindows {
+		require.Equal(t, 1, store.points("yes", window.StatWay), window.Name)
+		chance, ok := store.chance("yes", window.StatWay, window.Bucket(start))
+		require.True(t, ok, window.Name)
+		require.EqualValues(t, 60, chance, window.Name)
+	}
+
+	// 进入下一个 1 分钟桶，1h 周期多一个点，其余周期仍在同一个桶
+	cl.AdvanceTime(30 * time.Second)
+	store.setChance("yes", 70)
+	require.NoError(t, job.RunOnce(context.Background()))
+	require.Equal(t, 2, store.points("yes", database.ChanceStatWay1h))
+	require.Equal(t, 1, store.points("yes", database.ChanceStatWay6h))
+	chance, _ := store.chance("yes", database.ChanceStatWay1h, start)
+	require.EqualValues(t, 60, chance)
+	chance, _ = store.chance("yes", database.ChanceStatWay1h, start.Add(time.Minute))
+	require.EqualValues(t, 70, chance)
+}
+
+func TestChanceSnapshotPrunesOutsideWindow(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cl := clock.NewDeterministicClock(start)
+	store := newFakeChanceStatStore(database.SubEventDirection{GUID: "yes", SubEventGUID: "sub", Chance: 50})
+	job := NewChanceSnapshotJob(store, cl, time.Minute)
+
+	// 每分钟采样一次，持续 3 小时
+	for i := 1; i <= 180; i++ {
+		cl.AdvanceTime(time.Minute)
+		require.NoError(t, job.RunOnce(context.Background()))
+		// 1h 窗口最多保留 61 个 1 分钟桶（含窗口起点所在的桶）
+		require.Equal(t, min(i, 61), store.points("yes", database.ChanceStatWay1h), "minute %d", i)
+	}
+
+	require.Equal(t, 37, store.points("yes", database.ChanceStatWay6h))
+	require.Equal(t, 13, store.points("yes", database.ChanceStatWay1d))
+	require.Equal(t, 4, store.points("yes", database.ChanceStatWay1w))
+	require.Equal(t, 1, store.points("yes", database.ChanceStatWayAll))
+	_, ok := store.chance("yes", database.ChanceStatWay1h, start)
+	require.False(t, ok, "buckets older than the 1h window are pruned")
+}
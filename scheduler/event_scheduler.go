@@ -0,0 +1,174 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/multimarket-labs/event-pod-services/common/clock"
+	"github.com/multimarket-labs/event-pod-services/database"
+)
+
+const (
+	// schedulerActor 调度器写入状态流转记录时的操作人
+	schedulerActor = "scheduler"
+	// defaultBatchSize 每个调度步骤每轮最多处理的事件数，剩余的留到下一轮
+	defaultBatchSize = 100
+)
+
+// scheduleStep 调度步骤：处于 from 状态且调度时间点已到的事件流转到 to
+type scheduleStep struct {
+	from     string
+	to       string
+	schedule string
+	reason   string
+}
+
+// scheduleSteps 按生命周期顺序执行，同一轮内错过多个时间点的事件会依次流转
+var scheduleSteps = []scheduleStep{
+	{from: database.EventStatusDraft, to: database.EventStatusUpcoming, schedule: database.EventScheduleOpen, reason: "open_at reached"},
+	{from: database.EventStatusUpcoming, to: database.EventStatusLive, schedule: database.EventScheduleStart, reason: "start_at reached"},
+	{from: database.EventStatusLive, to: database.EventStatusEnded, schedule: database.EventScheduleClose, reason: "close_at reached"},
+}
+
+// EventStore 调度器读取到期事件并流转状态的数据接口
+type EventStore interface {
+	// ListDueEvents 获取处于 status 且调度时间点 schedule 不晚于 now 的事件
+	ListDueEvents(ctx context.Context, status, schedule string, now time.Time, limit int) ([]database.Event, error)
+	// TransitionEvent 仅当事件仍处于 fromStatus 时流转到 toStatus 并记录流转，返回是否命中
+	TransitionEvent(ctx context.Context, eventGUID, fromStatus, toStatus, reason string) (bool, error)
+}
+
+// EventScheduler 按开盘、开始、收盘时间自动上线事件、进入进行中和停止交易
+type EventScheduler struct {
+	store     EventStore
+	clock     clock.Clock
+	interval  time.Duration
+	batchSize int
+	loop      *clock.LoopFn
+}
+
+// NewEventScheduler 创建事件调度器，每隔 interval 检查一次到期事件
+func NewEventScheduler(store EventStore, clk clock.Clock, interval time.Duration) *EventScheduler {
+	return &EventScheduler{
+		store:     store,
+		clock:     clk,
+		interval:  interval,
+		batchSize: defaultBatchSize,
+	}
+}
+
+// Start 启动调度循环
+func (s *EventScheduler) Start() {
+	s.loop = clock.NewLoopFn(s.clock, s.tick, nil, s.interval)
+	log.Info("event scheduler started", "interval", s.interval)
+}
+
+// Close 停止调度循环并等待进行中的一轮结束
+func (s *EventScheduler) Close() error {
+	if s.loop == nil {
+		return nil
+	}
+	return s.loop.Close()
+}
+
+func (s *EventScheduler) tick(ctx context.Context) {
+	if err := s.RunOnce(ctx); err != nil {
+		log.Error("event scheduler run failed", "err", err)
+	}
+}
+
+// RunOnce 按当前时间执行一轮调度，单个事件失败不影响其他事件，返回合并后的错误
+func (s *EventScheduler) RunOnce(ctx context.Context) error {
+	now := s.clock.Now()
+	var result error
+	for _, step := range scheduleSteps {
+		if err := s.runStep(ctx, step, now); err != nil {
+			result = errors.Join(result, err)
+		}
+		if ctx.Err() != nil {
+			return errors.Join(result, ctx.Err())
+		}
+	}
+	return result
+}
+
+// runStep 处理一个调度步骤的到期事件
+func (s *EventScheduler) runStep(ctx context.Context, step scheduleStep, now time.Time) error {
+	events, err := s.store.ListDueEvents(ctx, step.from, step.schedule, now, s.batchSize)
+	if err != nil {
+		return fmt.Errorf("failed to list %s events due to %s: %w", step.from, step.to, err)
+	}
+
+	var result error
+	for _, event := range events {
+		if ctx.Err() != nil {
+			return result
+		}
+		updated, err := s.store.TransitionEvent(ctx, event.GUID, step.from, step.to, step.reason)
+		if err != nil {
+			result = errors.Join(result, fmt.Errorf("failed to move event %s to %s: %w", event.GUID, step.to, err))
+			continue
+		}
+		if !updated {
+			// 状态已被人工或其他实例修改
+			log.Debug("event status changed before scheduling", "event_guid", event.GUID, "expected", step.from)
+			continue
+		}
+		log.Info("event status scheduled", "event_guid", event.GUID, "from", step.from, "to", step.to)
+	}
+	return result
+}
+
+// dbEventStore 基于数据库的 EventStore 实现
+type dbEventStore struct {
+	db   *database.DB
+	repo database.EventRepository
+}
+
+// NewDBEventStore 创建基于数据库的 EventStore
+func NewDBEventStore(db *database.DB) EventStore {
+	return &dbEventStore{db: db, repo: database.NewEventRepository()}
+}
+
+func (s *dbEventStore) ListDueEvents(ctx context.Context, status, schedule string, now time.Time, limit int) ([]database.Event, error) {
+	return s.repo.ListDueEvents(s.db.GetGorm().WithContext(ctx), status, schedule, now, limit)
+}
+
+// TransitionEvent 在事务中按状态条件流转并写入流转记录，同步更新 is_online / is_live
+func (s *dbEventStore) TransitionEvent(ctx context.Context, eventGUID, fromStatus, toStatus, reason string) (bool, error) {
+	columns, ok := database.EventStatusColumns(toStatus)
+	if !ok {
+		return false, fmt.Errorf("unknown event status %q", toStatus)
+	}
+
+	var updated bool
+	err := s.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm().WithContext(ctx)
+
+		var err error
+		updated, err = s.repo.TransitionEventStatus(db, eventGUID, fromStatus, toStatus, columns)
+		if err != nil || !updated {
+			return err
+		}
+
+		history := &database.EventStatusHistory{
+			EventGUID:  eventGUID,
+			FromStatus: fromStatus,
+			ToStatus:   toStatus,
+			Actor:      schedulerActor,
+			Reason:     reason,
+		}
+		if err := s.repo.CreateEventStatusHistory(db, history); err != nil {
+			return fmt.Errorf("failed to record event status history: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return false, err
+	}
+	return updated, nil
+}
@@ -0,0 +1,255 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/multimarket-labs/event-pod-services/common/clock"
+	"github.com/multimarket-labs/event-pod-services/database"
+)
+
+// transition 一次状态流转
+type transition struct {
+	eventGUID string
+	from      string
+	to        string
+}
+
+// fakeEventStore 内存中的 EventStore，按与数据库相同的规则选出到期事件
+type fakeEventStore struct {
+	mu          sync.Mutex
+	events      map[string]*database.Event
+	transitions []transition
+	failGUIDs   map[string]bool // 流转时返回错误的事件
+}
+
+func newFakeEventStore(events ...database.Event) *fakeEventStore {
+	store := &fakeEventStore{events: make(map[string]*database.Event), failGUIDs: make(map[string]bool)}
+	for i := range events {
+		store.events[events[i].GUID] = &events[i]
+	}
+	return store
+}
+
+func (s *fakeEventStore) ListDueEvents(_ context.Context, status, schedule string, now time.Time, limit int) ([]database.Event, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var due []database.Event
+	for _, event := range s.events {
+		if event.Status != status {
+			continue
+		}
+		at := scheduleTime(event, schedule)
+		if at != nil && !at.After(now) {
+			due = append(due, *event)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool { return due[i].GUID < due[j].GUID })
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+func (s *fakeEventStore) TransitionEvent(_ context.Context, eventGUID, fromStatus, toStatus, _ string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failGUIDs[eventGUID] {
+		return false, errors.New("transition failed")
+	}
+	event, ok := s.events[eventGUID]
+	if !ok || event.Status != fromStatus {
+		return false, nil
+	}
+	event.Status = toStatus
+	s.transitions = append(s.transitions, transition{eventGUID: eventGUID, from: fromStatus, to: toStatus})
+	return true, nil
+}
+
+// setStatus 模拟人工修改状态
+func (s *fakeEventStore) setStatus(eventGUID, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events[eventGUID].Status = status
+}
+
+func (s *fakeEventStore) recorded() []transition {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]transition(nil), s.transitions...)
+}
+
+func (s *fakeEventStore) status(eventGUID string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.events[eventGUID].Status
+}
+
+func scheduleTime(event *database.Event, schedule string) *time.Time {
+	switch schedule {
+	case database.EventScheduleOpen:
+		return event.OpenAt
+	case database.EventScheduleStart:
+		if event.StartAt != nil {
+			return event.StartAt
+		}
+		return event.OpenAt
+	case database.EventScheduleClose:
+		return event.CloseAt
+	}
+	return nil
+}
+
+func at(t time.Time, d time.Duration) *time.Time {
+	v := t.Add(d)
+	return &v
+}
+
+func TestEventSchedulerRunOnce(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		event database.Event
+		want  string
+	}{
+		{
+			name:  "draft before open_at stays draft",
+			event: database.Event{Status: database.EventStatusDraft, OpenAt: at(now, time.Minute)},
+			want:  database.EventStatusDraft,
+		},
+		{
+			name:  "draft without schedule stays draft",
+			event: database.Event{Status: database.EventStatusDraft},
+			want:  database.EventStatusDraft,
+		},
+		{
+			name:  "draft goes online at open_at",
+			event: database.Event{Status: database.EventStatusDraft, OpenAt: at(now, 0), StartAt: at(now, time.Hour)},
+			want:  database.EventStatusUpcoming,
+		},
+		{
+			name:  "upcoming goes live at start_at",
+			event: database.Event{Status: database.EventStatusUpcoming, OpenAt: at(now, -time.Hour), StartAt: at(now, -time.Minute)},
+			want:  database.EventStatusLive,
+		},
+		{
+			name:  "start_at falls back to open_at",
+			event: database.Event{Status: database.EventStatusDraft, OpenAt: at(now, -time.Minute)},
+			want:  database.EventStatusLive,
+		},
+		{
+			name:  "live closes at close_at",
+			event: database.Event{Status: database.EventStatusLive, StartAt: at(now, -time.Hour), CloseAt: at(now, -time.Second)},
+			want:  database.EventStatusEnded,
+		},
+		{
+			name: "missed schedule catches up in one run",
+			event: database.Event{Status: database.EventStatusDraft,
+				OpenAt: at(now, -3*time.Hour), StartAt: at(now, -2*time.Hour), CloseAt: at(now, -time.Hour)},
+			want: database.EventStatusEnded,
+		},
+		{
+			name:  "cancelled events are left alone",
+			event: database.Event{Status: database.EventStatusCancelled, OpenAt: at(now, -time.Hour), CloseAt: at(now, -time.Minute)},
+			want:  database.EventStatusCancelled,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.event.GUID = "event"
+			store := newFakeEventStore(tt.event)
+			s := NewEventScheduler(store, clock.NewDeterministicClock(now), time.Minute)
+
+			require.NoError(t, s.RunOnce(context.Background()))
+			require.Equal(t, tt.want, store.status("event"))
+		})
+	}
+}
+
+func TestEventSchedulerRunOnceContinuesAfterFailure(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := newFakeEventStore(
+		database.Event{GUID: "a", Status: database.EventStatusDraft, OpenAt: at(now, -time.Minute), StartAt: at(now, time.Hour)},
+		database.Event{GUID: "b", Status: database.EventStatusDraft, OpenAt: at(now, -time.Minute), StartAt: at(now, time.Hour)},
+	)
+	store.failGUIDs["a"] = true
+	s := NewEventScheduler(store, clock.NewDeterministicClock(now), time.Minute)
+
+	err := s.RunOnce(context.Background())
+	require.ErrorContains(t, err, "event a")
+	require.Equal(t, database.EventStatusDraft, store.status("a"))
+	require.Equal(t, database.EventStatusUpcoming, store.status("b"))
+}
+
+func TestEventSchedulerBatchSize(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	store := newFakeEventStore(
+		database.Event{GUID: "a", Status: database.EventStatusLive, CloseAt: at(now, -time.Minute)},
+		database.Event{GUID: "b", Status: database.EventStatusLive, CloseAt: at(now, -time.Minute)},
+		database.Event{GUID: "c", Status: database.EventStatusLive, CloseAt: at(now, -time.Minute)},
+	)
+	s := NewEventScheduler(store, clock.NewDeterministicClock(now), time.Minute)
+	s.batchSize = 2
+
+	require.NoError(t, s.RunOnce(context.Background()))
+	require.Len(t, store.recorded(), 2)
+	require.NoError(t, s.RunOnce(context.Background()))
+	require.Len(t, store.recorded(), 3)
+	for _, guid := range []string{"a", "b", "c"} {
+		require.Equal(t, database.EventStatusEnded, store.status(guid))
+	}
+}
+
+func TestEventSchedulerLoop(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cl := clock.NewDeterministicClock(start)
+	store := newFakeEventStore(database.Event{
+		GUID:    "event",
+		Status:  database.EventStatusDraft,
+		OpenAt:  at(start, 30*time.Second),
+		StartAt: at(start, 90*time.Second),
+		CloseAt: at(start, 150*time.Second),
+	})
+	s := NewEventScheduler(store, cl, time.Minute)
+	s.Start()
+	defer func() { require.NoError(t, s.Close()) }()
+
+	for _, want := range []string{database.EventStatusUpcoming, database.EventStatusLive, database.EventStatusEnded} {
+		cl.AdvanceTime(time.Minute)
+		require.Eventually(t, func() bool { return store.status("event") == want }, time.Second, time.Millisecond, want)
+	}
+
+	require.Equal(t, []transition{
+		{eventGUID: "event", from: database.EventStatusDraft, to: database.EventStatusUpcoming},
+		{eventGUID: "event", from: database.EventStatusUpcoming, to: database.EventStatusLive},
+		{eventGUID: "event", from: database.EventStatusLive, to: database.EventStatusEnded},
+	}, store.recorded())
+}
+
+func TestEventSchedulerSkipsManualChanges(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	cl := clock.NewDeterministicClock(start)
+	store := newFakeEventStore(database.Event{
+		GUID:    "event",
+		Status:  database.EventStatusDraft,
+		OpenAt:  at(start, 30*time.Second),
+		CloseAt: at(start, 150*time.Second),
+	})
+	s := NewEventScheduler(store, cl, time.Minute)
+
+	// 到达开盘时间前被人工取消
+	store.setStatus("event", database.EventStatusCancelled)
+	cl.AdvanceTime(3 * time.Minute)
+	require.NoError(t, s.RunOnce(context.Background()))
+	require.Equal(t, database.EventStatusCancelled, store.status("event"))
+	require.Empty(t, store.recorded())
+}
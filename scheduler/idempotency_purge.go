@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/multimarket-labs/event-pod-services/common/clock"
+	"github.com/multimarket-labs/event-pod-services/database"
+)
+
+// idempotencyPurgeBatchSize 每个事务最多删除的幂等键数
+const idempotencyPurgeBatchSize = 1000
+
+// IdempotencyKeyStore 幂等键清理任务的数据接口
+type IdempotencyKeyStore interface {
+	// PurgeExpired 删除创建超过 ttl 的幂等键，每次最多 limit 条，返回删除的记录数
+	PurgeExpired(ctx context.Context, ttl time.Duration, limit int) (int, error)
+}
+
+// IdempotencyPurgeJob 定时删除过期的幂等键，过期后相同的 Idempotency-Key 会被当作新请求
+// 钱包登录随机数也记录在幂等键表中，ttl 需长于随机数的有效期，否则过期前的随机数可以再次登录
+type IdempotencyPurgeJob struct {
+	store    IdempotencyKeyStore
+	clock    clock.Clock
+	interval time.Duration
+	ttl      time.Duration
+	loop     *clock.LoopFn
+}
+
+// NewIdempotencyPurgeJob 创建幂等键清理任务，每隔 interval 清理一次；ttl 不大于 0 时不清理
+func NewIdempotencyPurgeJob(store IdempotencyKeyStore, clk clock.Clock, interval, ttl time.Duration) *IdempotencyPurgeJob {
+	return &IdempotencyPurgeJob{
+		store:    store,
+		clock:    clk,
+		interval: interval,
+		ttl:      ttl,
+	}
+}
+
+// Start 启动清理循环，未配置 ttl 时不启动
+func (j *IdempotencyPurgeJob) Start() {
+	if j.ttl <= 0 {
+		log.Info("idempotency key purge job disabled")
+		return
+	}
+	j.loop = clock.NewLoopFn(j.clock, j.tick, nil, j.interval)
+	log.Info("idempotency key purge job started", "interval", j.interval, "ttl", j.ttl)
+}
+
+// Close 停止清理循环并等待进行中的一轮结束
+func (j *IdempotencyPurgeJob) Close() error {
+	if j.loop == nil {
+		return nil
+	}
+	return j.loop.Close()
+}
+
+func (j *IdempotencyPurgeJob) tick(ctx context.Context) {
+	if err := j.RunOnce(ctx); err != nil {
+		log.Error("idempotency key purge failed", "err", err)
+	}
+}
+
+// RunOnce 分批删除过期的幂等键，直到没有剩余
+func (j *IdempotencyPurgeJob) RunOnce(ctx context.Context) error {
+	if j.ttl <= 0 {
+		return nil
+	}
+
+	total := 0
+	defer func() {
+		if total > 0 {
+			log.Info("purged expired idempotency keys", "count", total)
+		}
+	}()
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		n, err := j.store.PurgeExpired(ctx, j.ttl, idempotencyPurgeBatchSize)
+		if err != nil {
+			return err
+		}
+		total += n
+		if n < idempotencyPurgeBatchSize {
+			return nil
+		}
+	}
+}
+
+// dbIdempotencyKeyStore 基于数据库的 IdempotencyKeyStore 实现
+type dbIdempotencyKeyStore struct {
+	db   *database.DB
+	repo database.IdempotencyRepository
+}
+
+// NewDBIdempotencyKeyStore 创建基于数据库的 IdempotencyKeyStore
+func NewDBIdempotencyKeyStore(db *database.DB) IdempotencyKeyStore {
+	return &dbIdempotencyKeyStore{db: db, repo: database.NewIdempotencyRepository()}
+}
+
+// PurgeExpired 删除一批过期的幂等键
+func (s *dbIdempotencyKeyStore) PurgeExpired(ctx context.Context, ttl time.Duration, limit int) (int, error) {
+	return s.repo.PurgeExpired(s.db.GetGorm().WithContext(ctx), ttl, limit)
+}
@@ -0,0 +1,107 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/multimarket-labs/event-pod-services/common/clock"
+)
+
+// fakeIdempotencyKeyStore 内存中的 IdempotencyKeyStore，记录各幂等键的创建时间
+type fakeIdempotencyKeyStore struct {
+	mu      sync.Mutex
+	clock   clock.Clock
+	created map[string]time.Time
+	err     error
+	calls   int
+}
+
+func newFakeIdempotencyKeyStore(clk clock.Clock) *fakeIdempotencyKeyStore {
+	return &fakeIdempotencyKeyStore{clock: clk, created: make(map[string]time.Time)}
+}
+
+func (s *fakeIdempotencyKeyStore) PurgeExpired(_ context.Context, ttl time.Duration, limit int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls++
+	if s.err != nil {
+		return 0, s.err
+	}
+	cutoff := s.clock.Now().Add(-ttl)
+	n := 0
+	for key, createdAt := range s.created {
+		if n == limit {
+			break
+		}
+		if createdAt.Before(cutoff) {
+			delete(s.created, key)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// claim 以当前时间创建 count 个幂等键
+func (s *fakeIdempotencyKeyStore) claim(prefix string, count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < count; i++ {
+		s.created[fmt.Sprintf("%s-%d", prefix, i)] = s.clock.Now()
+	}
+}
+
+func (s *fakeIdempotencyKeyStore) remaining() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.created)
+}
+
+func TestIdempotencyPurgeJobPurgesExpiredKeys(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Date(2025, 12, 3, 0, 0, 0, 0, time.UTC))
+	store := newFakeIdempotencyKeyStore(cl)
+	job := NewIdempotencyPurgeJob(store, cl, time.Hour, 24*time.Hour)
+
+	store.claim("old", 2*idempotencyPurgeBatchSize+1)
+	cl.AdvanceTime(12 * time.Hour)
+	store.claim("new", 2)
+
+	// 未过期，不清理
+	require.NoError(t, job.RunOnce(context.Background()))
+	require.Equal(t, 2*idempotencyPurgeBatchSize+3, store.remaining())
+
+	cl.AdvanceTime(13 * time.Hour)
+	require.NoError(t, job.RunOnce(context.Background()))
+	require.Equal(t, 2, store.remaining())
+}
+
+func TestIdempotencyPurgeJobReturnsStoreError(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Date(2025, 12, 3, 0, 0, 0, 0, time.UTC))
+	store := newFakeIdempotencyKeyStore(cl)
+	job := NewIdempotencyPurgeJob(store, cl, time.Hour, time.Hour)
+
+	boom := errors.New("boom")
+	store.err = boom
+	require.ErrorIs(t, job.RunOnce(context.Background()), boom)
+	require.Equal(t, 1, store.calls)
+}
+
+func TestIdempotencyPurgeJobDisabledWithoutTTL(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Date(2025, 12, 3, 0, 0, 0, 0, time.UTC))
+	store := newFakeIdempotencyKeyStore(cl)
+	job := NewIdempotencyPurgeJob(store, cl, time.Hour, 0)
+
+	store.claim("old", 1)
+	cl.AdvanceTime(365 * 24 * time.Hour)
+
+	job.Start()
+	require.NoError(t, job.RunOnce(context.Background()))
+	require.NoError(t, job.Close())
+	require.Equal(t, 1, store.remaining())
+	require.Zero(t, store.calls)
+}
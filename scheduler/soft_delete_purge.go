@@ -0,0 +1,115 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/multimarket-labs/event-pod-services/common/clock"
+	"github.com/multimarket-labs/event-pod-services/database"
+)
+
+// softDeletePurgeBatchSize 每个事务最多硬删除的记录数，避免长时间持有锁
+const softDeletePurgeBatchSize = 500
+
+// SoftDeleteStore 软删除清理任务的数据接口
+type SoftDeleteStore interface {
+	// PurgeDeleted 硬删除表中软删除超过 retention 的记录及其子表记录，每次最多 limit 条，返回删除的记录数
+	PurgeDeleted(ctx context.Context, table string, retention time.Duration, limit int) (int, error)
+}
+
+// SoftDeletePurgeJob 定时硬删除软删除超过保留期的记录，之后不能再恢复
+type SoftDeletePurgeJob struct {
+	store     SoftDeleteStore
+	clock     clock.Clock
+	interval  time.Duration
+	retention time.Duration
+	loop      *clock.LoopFn
+}
+
+// NewSoftDeletePurgeJob 创建软删除清理任务，每隔 interval 清理一次；retention 不大于 0 时不清理
+func NewSoftDeletePurgeJob(store SoftDeleteStore, clk clock.Clock, interval, retention time.Duration) *SoftDeletePurgeJob {
+	return &SoftDeletePurgeJob{
+		store:     store,
+		clock:     clk,
+		interval:  interval,
+		retention: retention,
+	}
+}
+
+// Start 启动清理循环，未配置保留期时不启动
+func (j *SoftDeletePurgeJob) Start() {
+	if j.retention <= 0 {
+		log.Info("soft delete purge job disabled")
+		return
+	}
+	j.loop = clock.NewLoopFn(j.clock, j.tick, nil, j.interval)
+	log.Info("soft delete purge job started", "interval", j.interval, "retention", j.retention)
+}
+
+// Close 停止清理循环并等待进行中的一轮结束
+func (j *SoftDeletePurgeJob) Close() error {
+	if j.loop == nil {
+		return nil
+	}
+	return j.loop.Close()
+}
+
+func (j *SoftDeletePurgeJob) tick(ctx context.Context) {
+	if err := j.RunOnce(ctx); err != nil {
+		log.Error("soft delete purge failed", "err", err)
+	}
+}
+
+// RunOnce 依次清理各软删除表中超过保留期的记录，每张表分批删除直到没有剩余
+// 某张表清理失败时继续清理其余的表，返回合并后的错误
+func (j *SoftDeletePurgeJob) RunOnce(ctx context.Context) error {
+	if j.retention <= 0 {
+		return nil
+	}
+
+	var result error
+	for _, table := range database.SoftDeleteTables {
+		total := 0
+		for ctx.Err() == nil {
+			n, err := j.store.PurgeDeleted(ctx, table, j.retention, softDeletePurgeBatchSize)
+			if err != nil {
+				result = errors.Join(result, fmt.Errorf("failed to purge %s: %w", table, err))
+				break
+			}
+			total += n
+			if n < softDeletePurgeBatchSize {
+				break
+			}
+		}
+		if total > 0 {
+			log.Info("purged soft deleted records", "table", table, "count", total)
+		}
+	}
+	return errors.Join(result, ctx.Err())
+}
+
+// dbSoftDeleteStore 基于数据库的 SoftDeleteStore 实现
+type dbSoftDeleteStore struct {
+	db   *database.DB
+	repo database.SoftDeleteRepository
+}
+
+// NewDBSoftDeleteStore 创建基于数据库的 SoftDeleteStore
+func NewDBSoftDeleteStore(db *database.DB) SoftDeleteStore {
+	return &dbSoftDeleteStore{db: db, repo: database.NewSoftDeleteRepository()}
+}
+
+// PurgeDeleted 在一个事务中删除一批主记录及其子表记录
+func (s *dbSoftDeleteStore) PurgeDeleted(ctx context.Context, table string, retention time.Duration, limit int) (int, error) {
+	var purged []string
+	err := s.db.Transaction(func(txDB *database.DB) error {
+		var err error
+		purged, err = s.repo.PurgeDeleted(txDB.GetGorm().WithContext(ctx), table, retention, limit)
+		return err
+	})
+	return len(purged), err
+}
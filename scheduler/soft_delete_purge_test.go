@@ -0,0 +1,122 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/multimarket-labs/event-pod-services/common/clock"
+)
+
+// fakeSoftDeleteStore 内存中的 SoftDeleteStore，记录各表已软删除记录的删除时间
+type fakeSoftDeleteStore struct {
+	mu      sync.Mutex
+	clock   clock.Clock
+	deleted map[string]map[string]time.Time
+	failing map[string]error
+	calls   map[string]int
+}
+
+func newFakeSoftDeleteStore(clk clock.Clock) *fakeSoftDeleteStore {
+	return &fakeSoftDeleteStore{
+		clock:   clk,
+		deleted: make(map[string]map[string]time.Time),
+		failing: make(map[string]error),
+		calls:   make(map[string]int),
+	}
+}
+
+func (s *fakeSoftDeleteStore) PurgeDeleted(_ context.Context, table string, retention time.Duration, limit int) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calls[table]++
+	if err := s.failing[table]; err != nil {
+		return 0, err
+	}
+	cutoff := s.clock.Now().Add(-retention)
+	n := 0
+	for guid, deletedAt := range s.deleted[table] {
+		if n == limit {
+			break
+		}
+		if deletedAt.Before(cutoff) {
+			delete(s.deleted[table], guid)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// softDelete 以当前时间软删除 count 条记录
+func (s *fakeSoftDeleteStore) softDelete(table, prefix string, count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.deleted[table] == nil {
+		s.deleted[table] = make(map[string]time.Time)
+	}
+	for i := 0; i < count; i++ {
+		s.deleted[table][fmt.Sprintf("%s-%d", prefix, i)] = s.clock.Now()
+	}
+}
+
+func (s *fakeSoftDeleteStore) remaining(table string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.deleted[table])
+}
+
+func TestSoftDeletePurgeJobPurgesExpiredRecords(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Date(2025, 12, 2, 0, 0, 0, 0, time.UTC))
+	store := newFakeSoftDeleteStore(cl)
+	job := NewSoftDeletePurgeJob(store, cl, time.Hour, 30*24*time.Hour)
+
+	store.softDelete("event", "old", 2*softDeletePurgeBatchSize+1)
+	store.softDelete("category", "old", 3)
+	cl.AdvanceTime(20 * 24 * time.Hour)
+	store.softDelete("event", "new", 2)
+
+	// 保留期未到，不清理
+	require.NoError(t, job.RunOnce(context.Background()))
+	require.Equal(t, 2*softDeletePurgeBatchSize+3, store.remaining("event"))
+	require.Equal(t, 3, store.remaining("category"))
+
+	cl.AdvanceTime(11 * 24 * time.Hour)
+	require.NoError(t, job.RunOnce(context.Background()))
+	require.Equal(t, 2, store.remaining("event"))
+	require.Equal(t, 0, store.remaining("category"))
+}
+
+func TestSoftDeletePurgeJobContinuesAfterTableFailure(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Date(2025, 12, 2, 0, 0, 0, 0, time.UTC))
+	store := newFakeSoftDeleteStore(cl)
+	job := NewSoftDeletePurgeJob(store, cl, time.Hour, 24*time.Hour)
+
+	boom := errors.New("boom")
+	store.failing["event"] = boom
+	store.softDelete("languages", "old", 1)
+	cl.AdvanceTime(48 * time.Hour)
+
+	err := job.RunOnce(context.Background())
+	require.ErrorIs(t, err, boom)
+	require.Equal(t, 0, store.remaining("languages"))
+}
+
+func TestSoftDeletePurgeJobDisabledWithoutRetention(t *testing.T) {
+	cl := clock.NewDeterministicClock(time.Date(2025, 12, 2, 0, 0, 0, 0, time.UTC))
+	store := newFakeSoftDeleteStore(cl)
+	job := NewSoftDeletePurgeJob(store, cl, time.Hour, 0)
+
+	store.softDelete("event", "old", 1)
+	cl.AdvanceTime(365 * 24 * time.Hour)
+
+	job.Start()
+	require.NoError(t, job.RunOnce(context.Background()))
+	require.NoError(t, job.Close())
+	require.Equal(t, 1, store.remaining("event"))
+	require.Empty(t, store.calls)
+}
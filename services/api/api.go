@@ -124,9 +124,9 @@ func (a *API) initRouter(ctx context.Context, cfg *config.Config) {
 
 	// Add CORS middleware
 	corsOptions := cors.Options{
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "Idempotency-Key"},
+		ExposedHeaders:   []string{"Link", "Idempotent-Replayed"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}
@@ -144,7 +144,7 @@ func (a *API) initRouter(ctx context.Context, cfg *config.Config) {
 	apiRouter.Use(middleware.Heartbeat(HealthPath))
 
 	// Register routes AFTER all middlewares are defined
-	_ = routes.NewRoutes(apiRouter, svc)
+	_ = routes.NewRoutes(apiRouter, svc, cfg.JWTSecret, cfg.ScoreFeedToken)
 
 	apiRouter.NotFound(func(w http.ResponseWriter, r *http.Request) {
 		log.Warn("NotFoundHandler hit", "path", r.URL.Path, "method", r.Method)
@@ -0,0 +1,26 @@
+package models
+
+// ============================================
+// 钱包登录 (Wallet Login)
+// 下单等用户接口通过钱包令牌确定用户地址，不再信任请求体中的地址
+// ============================================
+
+// WalletNonceResponse 登录随机数响应
+type WalletNonceResponse struct {
+	Nonce     string `json:"nonce"`      // 登录随机数，只能使用一次
+	Message   string `json:"message"`    // 需要钱包签名的登录消息
+	ExpiresAt int64  `json:"expires_at"` // 随机数过期时间，Unix 时间戳（秒）
+}
+
+// WalletLoginRequest 钱包登录请求
+type WalletLoginRequest struct {
+	Message   string `json:"message"`   // 登录消息，格式为 "Login to DappLink with nonce: <nonce>"
+	Signature string `json:"signature"` // 钱包对登录消息的 personal_sign 签名（0x 开头的十六进制）
+}
+
+// WalletLoginResponse 钱包登录响应
+type WalletLoginResponse struct {
+	Token       string `json:"token"`        // 钱包令牌，请求时放在 Authorization: Bearer <token>
+	UserAddress string `json:"user_address"` // 签名的钱包地址（小写）
+	ExpiresAt   int64  `json:"expires_at"`   // 令牌过期时间，Unix 时间戳（秒）
+}
@@ -0,0 +1,78 @@
+package models
+
+// ============================================
+// 接口 N: 分类管理 (Categories)
+// 分类可多级嵌套，parent_guid 为空表示一级分类；translations 的 key 为语言 GUID
+// ============================================
+
+// TaxonomyTranslation 分类、生态、时间标签在某语言下的名称和描述
+type TaxonomyTranslation struct {
+	Name        string `json:"name"`        // 名称（最多 50 个字符）
+	Description string `json:"description"` // 描述（最多 200 个字符）
+}
+
+// CreateCategoryRequest 创建分类请求
+type CreateCategoryRequest struct {
+	Code         string                         `json:"code"`         // 业务编码（可选，唯一）
+	ParentGUID   string                         `json:"parent_guid"`  // 父分类 GUID，为空表示一级分类
+	SortOrder    *int32                         `json:"sort_order"`   // 同级排序，不传时排在同级末尾
+	IsActive     *bool                          `json:"is_active"`    // 是否启用，默认 true
+	Remark       string                         `json:"remark"`       // 运营备注
+	Translations map[string]TaxonomyTranslation `json:"translations"` // 各语言的名称和描述，至少一种语言
+}
+
+// UpdateCategoryRequest 更新分类请求，只更新提供的字段
+type UpdateCategoryRequest struct {
+	GUID         string                          `json:"-"`            // 分类 GUID（来自路径）
+	Code         *string                         `json:"code"`         // 业务编码，空字符串表示清除
+	ParentGUID   *string                         `json:"parent_guid"`  // 移动到新的父分类，空字符串表示移为一级分类
+	SortOrder    *int32                          `json:"sort_order"`   // 同级排序
+	IsActive     *bool                           `json:"is_active"`    // 启用或停用
+	Remark       *string                         `json:"remark"`       // 运营备注
+	Translations map[string]*TaxonomyTranslation `json:"translations"` // 新增或修改的翻译，值为 null 表示删除该语言
+}
+
+// ReorderCategoriesRequest 拖拽排序请求：按新的顺序给出父分类下的全部子分类
+type ReorderCategoriesRequest struct {
+	ParentGUID string   `json:"parent_guid"` // 父分类 GUID，为空表示一级分类
+	GUIDs      []string `json:"guids"`       // 子分类 GUID，按新的顺序排列，必须包含全部子分类
+}
+
+// CategoryDetailResponse 分类详情（后台，包含全部语言）
+type CategoryDetailResponse struct {
+	GUID         string                         `json:"guid"`         // 分类 GUID
+	Code         string                         `json:"code"`         // 业务编码
+	ParentGUID   string                         `json:"parent_guid"`  // 父分类 GUID，一级分类为空
+	Level        int16                          `json:"level"`        // 层级，0 为一级分类
+	SortOrder    int32                          `json:"sort_order"`   // 同级排序
+	IsActive     bool                           `json:"is_active"`    // 是否启用
+	Remark       string                         `json:"remark"`       // 运营备注
+	Translations map[string]TaxonomyTranslation `json:"translations"` // 各语言的名称和描述
+	CreatedAt    string                         `json:"created_at"`   // 创建时间（RFC3339）
+	UpdatedAt    string                         `json:"updated_at"`   // 更新时间（RFC3339）
+}
+
+// ListCategoriesRequest 分类列表查询请求
+type ListCategoriesRequest struct {
+	LanguageGUID    string `json:"language_guid"`    // 返回名称的语言（由 LanguageMiddleware 解析）
+	Tree            bool   `json:"tree"`             // true 时按层级嵌套返回，否则按层级先序展开为列表
+	IncludeInactive bool   `json:"include_inactive"` // 是否包含停用的分类（仅后台）
+}
+
+// CategoryResponse 分类响应（名称多语言）
+type CategoryResponse struct {
+	GUID        string              `json:"guid"`               // 分类 GUID
+	Code        string              `json:"code"`               // 业务编码
+	ParentGUID  string              `json:"parent_guid"`        // 父分类 GUID，一级分类为空
+	Level       int16               `json:"level"`              // 层级，0 为一级分类
+	SortOrder   int32               `json:"sort_order"`         // 同级排序
+	IsActive    bool                `json:"is_active"`          // 是否启用
+	Name        string              `json:"name"`               // 名称（请求语言，缺少时回退到默认语言）
+	Description string              `json:"description"`        // 描述
+	Children    []*CategoryResponse `json:"children,omitempty"` // 子分类（仅 tree=true）
+}
+
+// ListCategoriesResponse 分类列表响应
+type ListCategoriesResponse struct {
+	Categories []*CategoryResponse `json:"categories"` // tree=true 时为一级分类，否则为全部分类
+}
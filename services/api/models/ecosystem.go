@@ -0,0 +1,68 @@
+package models
+
+// ============================================
+// 接口 O: 生态管理 (Ecosystems)
+// 生态属于某个分类；translations 的 key 为语言 GUID；event_num 为引用该生态的事件数
+// ============================================
+
+// CreateEcosystemRequest 创建生态请求
+type CreateEcosystemRequest struct {
+	CategoryGUID string                         `json:"category_guid"` // 所属分类 GUID
+	Code         string                         `json:"code"`          // 业务编码（可选，唯一）
+	SortOrder    int32                          `json:"sort_order"`    // 排序，越小越靠前
+	IsActive     *bool                          `json:"is_active"`     // 是否启用，默认 true
+	Remark       string                         `json:"remark"`        // 运营备注
+	Extra        map[string]interface{}         `json:"extra"`         // 扩展字段（JSON 对象）
+	Translations map[string]TaxonomyTranslation `json:"translations"`  // 各语言的名称和描述，至少一种语言
+}
+
+// UpdateEcosystemRequest 更新生态请求，只更新提供的字段
+type UpdateEcosystemRequest struct {
+	GUID         string                          `json:"-"`             // 生态 GUID（来自路径）
+	CategoryGUID *string                         `json:"category_guid"` // 所属分类，仍被事件引用时不能修改
+	Code         *string                         `json:"code"`          // 业务编码，空字符串表示清除
+	SortOrder    *int32                          `json:"sort_order"`    // 排序
+	IsActive     *bool                           `json:"is_active"`     // 启用或停用
+	Remark       *string                         `json:"remark"`        // 运营备注
+	Extra        map[string]interface{}          `json:"extra"`         // 扩展字段，提供时整体替换
+	Translations map[string]*TaxonomyTranslation `json:"translations"`  // 新增或修改的翻译，值为 null 表示删除该语言
+}
+
+// EcosystemDetailResponse 生态详情（后台，包含全部语言）
+type EcosystemDetailResponse struct {
+	GUID         string                         `json:"guid"`          // 生态 GUID
+	CategoryGUID string                         `json:"category_guid"` // 所属分类 GUID
+	Code         string                         `json:"code"`          // 业务编码
+	SortOrder    int32                          `json:"sort_order"`    // 排序
+	IsActive     bool                           `json:"is_active"`     // 是否启用
+	Remark       string                         `json:"remark"`        // 运营备注
+	Extra        map[string]interface{}         `json:"extra"`         // 扩展字段
+	EventNum     string                         `json:"event_num"`     // 引用该生态的事件数（十进制字符串）
+	Translations map[string]TaxonomyTranslation `json:"translations"`  // 各语言的名称和描述
+	CreatedAt    string                         `json:"created_at"`    // 创建时间（RFC3339）
+	UpdatedAt    string                         `json:"updated_at"`    // 更新时间（RFC3339）
+}
+
+// ListEcosystemsRequest 生态列表查询请求
+type ListEcosystemsRequest struct {
+	LanguageGUID    string `json:"language_guid"`    // 返回名称的语言（由 LanguageMiddleware 解析）
+	CategoryGUID    string `json:"category_guid"`    // 按分类过滤（可选）
+	IncludeInactive bool   `json:"include_inactive"` // 是否包含停用的生态（仅后台）
+}
+
+// EcosystemResponse 生态响应（名称多语言）
+type EcosystemResponse struct {
+	GUID         string `json:"guid"`          // 生态 GUID
+	CategoryGUID string `json:"category_guid"` // 所属分类 GUID
+	Code         string `json:"code"`          // 业务编码
+	SortOrder    int32  `json:"sort_order"`    // 排序
+	IsActive     bool   `json:"is_active"`     // 是否启用
+	EventNum     string `json:"event_num"`     // 引用该生态的事件数（十进制字符串）
+	Name         string `json:"name"`          // 名称（请求语言，缺少时回退到默认语言）
+	Description  string `json:"description"`   // 描述
+}
+
+// ListEcosystemsResponse 生态列表响应
+type ListEcosystemsResponse struct {
+	Ecosystems []EcosystemResponse `json:"ecosystems"` // 按排序值、创建时间升序
+}
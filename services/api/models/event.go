@@ -1,5 +1,7 @@
 package models
 
+import "github.com/multimarket-labs/event-pod-services/common/decimal"
+
 // ============================================
 // 接口 A: 生成预测事件 (Create Event)
 // ============================================
@@ -11,8 +13,17 @@ type SubEventDirectionRequest struct {
 
 // SubEventRequest 子事件请求
 type SubEventRequest struct {
-	Title      string                     `json:"title" binding:"required"`      // 子事件标题
-	Directions []SubEventDirectionRequest `json:"directions" binding:"required"` // 方向列表
+	Title        string                     `json:"title"`                         // 子事件标题（主语言），可由 translations 提供
+	Translations map[string]string          `json:"translations"`                  // 各语言的子事件标题，key 为语言 GUID
+	Directions   []SubEventDirectionRequest `json:"directions" binding:"required"` // 方向列表
+	EndAt        *int64                     `json:"end_at"`                        // 子事件截止时间，Unix 时间戳（秒，可选）
+	Liquidity    *float64                   `json:"liquidity"`                     // LMSR 流动性参数 b（可选，默认 100），越大价格对交易越不敏感
+}
+
+// EventTranslation 事件在某语言下的标题与规则
+type EventTranslation struct {
+	Title string `json:"title"` // 事件标题
+	Rules string `json:"rules"` // 规则说明
 }
 
 // CreateEventRequest 创建事件请求
@@ -23,11 +34,20 @@ type CreateEventRequest struct {
 	MainTeamGroupGUID    string            `json:"main_team_group_guid"`                 // 主队 GUID（非运动类为空或"0"）
 	ClusterTeamGroupGUID string            `json:"cluster_team_group_guid"`              // 客队 GUID（非运动类为空或"0"）
 	Logo                 string            `json:"logo"`                                 // Logo URL
-	Title                string            `json:"title" binding:"required"`             // 事件标题
-	Rules                string            `json:"rules"`                                // 规则说明
-	LanguageGUID         string            `json:"language_guid" binding:"required"`     // 语言 GUID
+	Title                string            `json:"title"`                                // 事件标题（主语言），可由 translations 提供
+	Rules                string            `json:"rules"`                                // 规则说明（主语言）
+	LanguageGUID         string            `json:"language_guid" binding:"required"`     // 主语言 GUID，子事件标题以该语言写入 sub_event.title
 	SubEvents            []SubEventRequest `json:"sub_events" binding:"required,min=1"`  // 子事件列表
 	IsSports             bool              `json:"is_sports"`                            // 是否为运动类事件
+	Sport                string            `json:"sport"`                                // 运动项目（仅运动类，可选）：basketball、american_football、soccer、ice_hockey、tennis
+
+	OpenAt  *int64 `json:"open_at"`  // 开盘时间，到达后自动上线，Unix 时间戳（秒，可选）
+	StartAt *int64 `json:"start_at"` // 开始时间，到达后进入进行中，未设置时取 open_at（可选）
+	CloseAt *int64 `json:"close_at"` // 收盘时间，到达后停止交易（可选）
+
+	Translations map[string]EventTranslation `json:"translations"` // 各语言的标题与规则，key 为语言 GUID
+
+	IdempotencyKey string `json:"-"` // Idempotency-Key 请求头，相同键的重试返回首次的响应
 }
 
 // SubEventDirectionResponse 子事件方向响应
@@ -35,8 +55,9 @@ type SubEventDirectionResponse struct {
 	GUID        string `json:"guid"`          // 方向 GUID
 	Direction   string `json:"direction"`     // 方向名称
 	Chance      int16  `json:"chance"`        // 概率
-	NewAskPrice string `json:"new_ask_price"` // 卖价
-	NewBidPrice string `json:"new_bid_price"` // 买价
+	NewAskPrice string `json:"new_ask_price"` // 卖价：向做市商买入 1 份的均价
+	NewBidPrice string `json:"new_bid_price"` // 买价：向做市商卖出 1 份的均价
+	IsWin       bool   `json:"is_win"`        // 是否胜出（结算后有效）
 }
 
 // SubEventResponse 子事件响应
@@ -44,6 +65,9 @@ type SubEventResponse struct {
 	GUID       string                      `json:"guid"`       // 子事件 GUID
 	Title      string                      `json:"title"`      // 子事件标题
 	Logo       string                      `json:"logo"`       // Logo URL
+	Resolution string                      `json:"resolution"` // 结算状态：pending、resolved、void
+	EndAt      *int64                      `json:"end_at"`     // 子事件截止时间，Unix 时间戳（秒），未设置时为 null
+	Liquidity  float64                     `json:"liquidity"`  // LMSR 流动性参数 b
 	Directions []SubEventDirectionResponse `json:"directions"` // 方向列表
 }
 
@@ -55,6 +79,8 @@ type CreateEventResponse struct {
 	Logo      string             `json:"logo"`       // Logo URL
 	SubEvents []SubEventResponse `json:"sub_events"` // 子事件列表
 	CreatedAt string             `json:"created_at"` // 创建时间
+
+	Replayed bool `json:"-"` // 是否为幂等重放的首次响应
 }
 
 // ============================================
@@ -63,28 +89,49 @@ type CreateEventResponse struct {
 
 // ListEventsRequest 事件列表查询请求
 type ListEventsRequest struct {
-	Page         int    `json:"page"`          // 页码，默认1
-	Limit        int    `json:"limit"`         // 每页数量，默认20，最大100
-	LanguageGUID string `json:"language_guid"` // 语言 GUID（必需，用于多语言查询）
-	CategoryGUID string `json:"category_guid"` // 分类 GUID（可选）
-	IsLive       *int16 `json:"is_live"`       // 状态过滤：0-进行中, 1-预热, 2-已结束（可选）
+	Page         int     `json:"page"`          // 页码，默认1（仅页码分页）
+	Limit        int     `json:"limit"`         // 每页数量，默认20，最大100
+	LanguageGUID string  `json:"language_guid"` // 语言 GUID（必需，用于多语言查询）
+	CategoryGUID string  `json:"category_guid"` // 分类 GUID（可选）
+	IsLive       *int16  `json:"is_live"`       // 状态过滤：0-进行中, 1-预热, 2-已结束（可选）
+	Cursor       *string `json:"cursor"`        // 游标（可选）：传入时使用游标分页，首页传空字符串
+
+	EcosystemGUID   string `json:"ecosystem_guid"`    // 生态 GUID（可选）
+	EventPeriodGUID string `json:"event_period_guid"` // 时间标签 GUID（可选）
+	TeamGroupGUID   string `json:"team_group_guid"`   // 运动队 GUID，匹配主队或客队（可选）
+	IsSports        *bool  `json:"is_sports"`         // 是否为运动类事件（可选）
+	IsOnline        *bool  `json:"is_online"`         // 是否上线（可选）
+	OrderType       *int16 `json:"order_type"`        // 排序类型：0-热门话题, 1-突发, 2-最新（可选）
+	CreatedFrom     *int64 `json:"created_from"`      // 创建时间下限，Unix 时间戳（秒，可选）
+	CreatedTo       *int64 `json:"created_to"`        // 创建时间上限，Unix 时间戳（秒，可选）
+	OpenTimeFrom    *int64 `json:"open_time_from"`    // 开盘时间下限，Unix 时间戳（秒，可选）
+	OpenTimeTo      *int64 `json:"open_time_to"`      // 开盘时间上限，Unix 时间戳（秒，可选）
+	SortBy          string `json:"sort_by"`           // 排序字段：trade_volume、created_at、open_time，默认 created_at
+	Order           string `json:"order"`             // 排序方向：asc、desc，默认 desc
 }
 
 // EventListItem 事件列表项
 type EventListItem struct {
-	GUID            string             `json:"guid"`              // 事件 GUID
-	Title           string             `json:"title"`             // 事件标题（多语言）
-	Rules           string             `json:"rules"`             // 规则说明（多语言）
-	Logo            string             `json:"logo"`              // Logo URL
-	CategoryGUID    string             `json:"category_guid"`     // 分类 GUID
-	EcosystemGUID   string             `json:"ecosystem_guid"`    // 生态 GUID
-	EventPeriodGUID string             `json:"event_period_guid"` // 时间标签 GUID
-	IsLive          int16              `json:"is_live"`           // 状态：0-进行中, 1-预热, 2-已结束
-	IsSports        bool               `json:"is_sports"`         // 是否为运动类事件
-	OpenTime        string             `json:"open_time"`         // 开盘时间
-	TradeVolume     float64            `json:"trade_volume"`      // 交易量
-	SubEvents       []SubEventResponse `json:"sub_events"`        // 子事件列表（包含方向）
-	CreatedAt       string             `json:"created_at"`        // 创建时间
+	GUID             string             `json:"guid"`                         // 事件 GUID
+	Title            string             `json:"title"`                        // 事件标题（多语言）
+	Rules            string             `json:"rules"`                        // 规则说明（多语言）
+	LanguageGUID     string             `json:"language_guid"`                // 实际返回的语言 GUID（缺少请求语言时为回退语言）
+	Logo             string             `json:"logo"`                         // Logo URL
+	CategoryGUID     string             `json:"category_guid"`                // 分类 GUID
+	EcosystemGUID    string             `json:"ecosystem_guid"`               // 生态 GUID
+	EventPeriodGUID  string             `json:"event_period_guid"`            // 时间标签 GUID
+	IsLive           int16              `json:"is_live"`                      // 状态：0-进行中, 1-预热, 2-已结束
+	Status           string             `json:"status"`                       // 生命周期状态
+	IsSports         bool               `json:"is_sports"`                    // 是否为运动类事件
+	MainTeamGroup    *TeamGroupResponse `json:"main_team_group,omitempty"`    // 主队（仅运动类，名称多语言）
+	ClusterTeamGroup *TeamGroupResponse `json:"cluster_team_group,omitempty"` // 客队（仅运动类，名称多语言）
+	OpenTime         string             `json:"open_time"`                    // 开盘时间
+	OpenAt           *int64             `json:"open_at"`                      // 开盘时间，Unix 时间戳（秒），未设置时为 null
+	StartAt          *int64             `json:"start_at"`                     // 开始时间，Unix 时间戳（秒），未设置时为 null
+	CloseAt          *int64             `json:"close_at"`                     // 收盘时间，Unix 时间戳（秒），未设置时为 null
+	TradeVolume      decimal.Decimal    `json:"trade_volume"`                 // 成交额（十进制字符串）
+	SubEvents        []SubEventResponse `json:"sub_events"`                   // 子事件列表（包含方向）
+	CreatedAt        string             `json:"created_at"`                   // 创建时间
 }
 
 // PaginationInfo 分页信息
@@ -97,12 +144,389 @@ type PaginationInfo struct {
 
 // ListEventsResponse 事件列表响应
 type ListEventsResponse struct {
-	Events     []EventListItem `json:"events"`     // 事件列表
-	Pagination PaginationInfo  `json:"pagination"` // 分页信息
+	Events     []EventListItem `json:"events"`                // 事件列表
+	Pagination *PaginationInfo `json:"pagination,omitempty"`  // 分页信息（仅页码分页）
+	NextCursor string          `json:"next_cursor,omitempty"` // 下一页游标（仅游标分页，没有更多数据时为空）
+}
+
+// ============================================
+// 接口 C: 查询事件详情 (Get Event Detail)
+// ============================================
+
+// GetEventDetailRequest 事件详情查询请求
+type GetEventDetailRequest struct {
+	GUID         string `json:"guid"`          // 事件 GUID
+	LanguageGUID string `json:"language_guid"` // 语言 GUID（必需，用于多语言查询）
+}
+
+// TeamGroupResponse 运动队响应
+type TeamGroupResponse struct {
+	GUID string `json:"guid"` // 运动队 GUID
+	Name string `json:"name"` // 运动队名称（多语言）
+	Logo string `json:"logo"` // Logo URL
+}
+
+// EventDetailResponse 事件详情响应
+type EventDetailResponse struct {
+	GUID             string             `json:"guid"`                         // 事件 GUID
+	Title            string             `json:"title"`                        // 事件标题（多语言）
+	Rules            string             `json:"rules"`                        // 规则说明（多语言）
+	LanguageGUID     string             `json:"language_guid"`                // 实际返回的语言 GUID（缺少请求语言时为回退语言）
+	Logo             string             `json:"logo"`                         // Logo URL
+	CategoryGUID     string             `json:"category_guid"`                // 分类 GUID
+	CategoryName     string             `json:"category_name"`                // 分类名称（多语言）
+	EcosystemGUID    string             `json:"ecosystem_guid"`               // 生态 GUID
+	EcosystemName    string             `json:"ecosystem_name"`               // 生态名称（多语言）
+	EventPeriodGUID  string             `json:"event_period_guid"`            // 时间标签 GUID
+	EventPeriodName  string             `json:"event_period_name"`            // 时间标签名称（多语言）
+	MainTeamGroup    *TeamGroupResponse `json:"main_team_group,omitempty"`    // 主队（仅运动类）
+	ClusterTeamGroup *TeamGroupResponse `json:"cluster_team_group,omitempty"` // 客队（仅运动类）
+	MainScore        string             `json:"main_score"`                   // 主队得分
+	ClusterScore     string             `json:"cluster_score"`                // 客队得分
+	Stage            string             `json:"stage"`                        // 比赛阶段
+	Sport            string             `json:"sport"`                        // 运动项目（非运动类为空）
+	OrderType        int16              `json:"order_type"`                   // 排序类型：0-热门话题, 1-突发, 2-最新
+	IsOnline         bool               `json:"is_online"`                    // 是否上线
+	IsLive           int16              `json:"is_live"`                      // 状态：0-进行中, 1-预热, 2-已结束
+	Status           string             `json:"status"`                       // 生命周期状态
+	IsSports         bool               `json:"is_sports"`                    // 是否为运动类事件
+	OpenTime         string             `json:"open_time"`                    // 开盘时间
+	OpenAt           *int64             `json:"open_at"`                      // 开盘时间，Unix 时间戳（秒），未设置时为 null
+	StartAt          *int64             `json:"start_at"`                     // 开始时间，Unix 时间戳（秒），未设置时为 null
+	CloseAt          *int64             `json:"close_at"`                     // 收盘时间，Unix 时间戳（秒），未设置时为 null
+	TradeVolume      decimal.Decimal    `json:"trade_volume"`                 // 成交额（十进制字符串）
+	ExperimentResult string             `json:"experiment_result"`            // 事件结果
+	SubEvents        []SubEventResponse `json:"sub_events"`                   // 子事件列表（包含方向）
+	CreatedAt        string             `json:"created_at"`                   // 创建时间
+	UpdatedAt        string             `json:"updated_at"`                   // 更新时间
+}
+
+// ============================================
+// 接口 D: 更新事件 (Update Event)
+// ============================================
+
+// EventLanguageRequest 事件多语言标题与规则
+type EventLanguageRequest struct {
+	LanguageGUID string `json:"language_guid"` // 语言 GUID
+	Title        string `json:"title"`         // 事件标题
+	Rules        string `json:"rules"`         // 规则说明
+}
+
+// AddSubEventDirectionsRequest 为已有子事件新增方向
+type AddSubEventDirectionsRequest struct {
+	SubEventGUID string                     `json:"sub_event_guid"` // 子事件 GUID
+	Directions   []SubEventDirectionRequest `json:"directions"`     // 新增的方向列表
+}
+
+// UpdateEventRequest 更新事件请求
+// PUT 需要提供全部事件字段；PATCH 只更新提供的字段。两者都可以修改多语言内容和增删子事件、方向
+type UpdateEventRequest struct {
+	GUID      string `json:"-"`          // 事件 GUID（来自路径）
+	Replace   bool   `json:"-"`          // 是否为 PUT 全量更新
+	UpdatedAt string `json:"updated_at"` // 读取事件时的 updated_at（RFC3339），用于乐观锁（必需）
+
+	CategoryGUID         *string `json:"category_guid"`           // 分类 GUID
+	EcosystemGUID        *string `json:"ecosystem_guid"`          // 生态 GUID
+	EventPeriodGUID      *string `json:"event_period_guid"`       // 时间标签 GUID
+	MainTeamGroupGUID    *string `json:"main_team_group_guid"`    // 主队 GUID
+	ClusterTeamGroupGUID *string `json:"cluster_team_group_guid"` // 客队 GUID
+	Logo                 *string `json:"logo"`                    // Logo URL
+	OrderType            *int16  `json:"order_type"`              // 排序类型：0-热门话题, 1-突发, 2-最新
+	OpenTime             *string `json:"open_time"`               // 开盘时间
+	IsSports             *bool   `json:"is_sports"`               // 是否为运动类事件
+	Sport                *string `json:"sport"`                   // 运动项目，空字符串表示清除
+
+	OpenAt  *int64 `json:"open_at"`  // 开盘时间，Unix 时间戳（秒），0 表示清除
+	StartAt *int64 `json:"start_at"` // 开始时间，Unix 时间戳（秒），0 表示清除
+	CloseAt *int64 `json:"close_at"` // 收盘时间，Unix 时间戳（秒），0 表示清除
+
+	Languages            []EventLanguageRequest         `json:"languages"`              // 新增或修改的多语言标题与规则
+	Translations         map[string]EventTranslation    `json:"translations"`           // 新增或修改的多语言标题与规则，key 为语言 GUID
+	SubEventTranslations map[string]map[string]string   `json:"sub_event_translations"` // 已有子事件的多语言标题：子事件 GUID → 语言 GUID → 标题
+	AddSubEvents         []SubEventRequest              `json:"add_sub_events"`         // 新增的子事件
+	RemoveSubEventGUIDs  []string                       `json:"remove_sub_event_guids"` // 删除的子事件
+	AddDirections        []AddSubEventDirectionsRequest `json:"add_directions"`         // 为已有子事件新增的方向
+	RemoveDirectionGUIDs []string                       `json:"remove_direction_guids"` // 删除的方向
+}
+
+// UpdateEventResponse 更新事件响应
+type UpdateEventResponse struct {
+	GUID      string             `json:"guid"`       // 事件 GUID
+	UpdatedAt string             `json:"updated_at"` // 新的 updated_at，下次更新时需带上
+	SubEvents []SubEventResponse `json:"sub_events"` // 更新后的子事件列表（包含方向）
+}
+
+// ============================================
+// 接口 E: 事件状态流转 (Event Lifecycle)
+// ============================================
+
+// TransitionEventRequest 事件状态流转请求
+type TransitionEventRequest struct {
+	GUID     string `json:"-"`         // 事件 GUID（来自路径）
+	ToStatus string `json:"to_status"` // 目标状态：draft、upcoming、live、ended、cancelled（结算使用接口 F）
+	Actor    string `json:"-"`         // 操作人（来自令牌）
+	Reason   string `json:"reason"`    // 操作原因
+}
+
+// EventTransitionResponse 事件状态流转记录
+type EventTransitionResponse struct {
+	GUID       string `json:"guid"`        // 记录 GUID
+	EventGUID  string `json:"event_guid"`  // 事件 GUID
+	FromStatus string `json:"from_status"` // 流转前状态
+	ToStatus   string `json:"to_status"`   // 流转后状态
+	Actor      string `json:"actor"`       // 操作人
+	Reason     string `json:"reason"`      // 操作原因
+	CreatedAt  string `json:"created_at"`  // 流转时间
+}
+
+// ListEventTransitionsResponse 事件状态流转记录列表
+type ListEventTransitionsResponse struct {
+	EventGUID   string                    `json:"event_guid"`  // 事件 GUID
+	Status      string                    `json:"status"`      // 当前状态
+	Transitions []EventTransitionResponse `json:"transitions"` // 流转记录（按时间顺序）
+}
+
+// ============================================
+// 接口 F: 事件结算 (Resolve Event)
+// ============================================
+
+// ResolveSubEventRequest 单个子事件的结算结果
+type ResolveSubEventRequest struct {
+	SubEventGUID          string   `json:"sub_event_guid"`          // 子事件 GUID
+	WinningDirectionGUIDs []string `json:"winning_direction_guids"` // 胜出的方向 GUID（作废时留空）
+	Void                  bool     `json:"void"`                    // 是否作废该子事件
+}
+
+// ResolveEventRequest 事件结算请求，需要覆盖事件下的全部子事件
+type ResolveEventRequest struct {
+	GUID      string                   `json:"-"`          // 事件 GUID（来自路径）
+	Actor     string                   `json:"-"`          // 操作人（来自令牌）
+	Result    string                   `json:"result"`     // 结算结果说明，写入 experiment_result（必需）
+	SubEvents []ResolveSubEventRequest `json:"sub_events"` // 各子事件的结算结果
+	ReResolve bool                     `json:"re_resolve"` // 是否重新结算已结算的事件
+	Reason    string                   `json:"reason"`     // 重新结算原因（重新结算时必需）
+}
+
+// ResolveEventResponse 事件结算响应
+type ResolveEventResponse struct {
+	EventGUID   string                   `json:"event_guid"`    // 事件 GUID
+	Status      string                   `json:"status"`        // 结算后的事件状态
+	Result      string                   `json:"result"`        // 结算结果说明
+	IsReResolve bool                     `json:"is_re_resolve"` // 是否为重新结算
+	SubEvents   []ResolveSubEventRequest `json:"sub_events"`    // 各子事件的结算结果
+	ResolvedAt  string                   `json:"resolved_at"`   // 结算时间
+}
+
+// ============================================
+// 接口 G: 批量导入事件 (Bulk Import Events)
+// ============================================
+
+// 批量导入模式
+const (
+	ImportModeAllOrNothing = "all_or_nothing" // 任意一行失败则全部不导入
+	ImportModeBestEffort   = "best_effort"    // 导入成功的行，跳过失败的行
+)
+
+// 导入文件格式
+const (
+	ImportFormatJSONL = "jsonl" // 每行一个 CreateEventRequest JSON
+	ImportFormatCSV   = "csv"   // 首行为表头，sub_events、translations 列为 JSON
+)
+
+// 单行导入结果状态
+const (
+	ImportStatusCreated    = "created"     // 已创建
+	ImportStatusValid      = "valid"       // dry-run 校验通过，未创建
+	ImportStatusRolledBack = "rolled_back" // 本行有效，但 all_or_nothing 模式下其他行失败，未创建
+	ImportStatusFailed     = "failed"      // 解析或校验失败
+)
+
+// ImportEventLine 导入文件中的一行
+type ImportEventLine struct {
+	Line       int                 // 行号（从 1 开始）
+	Request    *CreateEventRequest // 解析出的创建请求，解析失败时为 nil
+	ParseError string              // 解析错误
+}
+
+// ImportEventsRequest 批量导入事件请求
+type ImportEventsRequest struct {
+	Mode   string            // 导入模式：all_or_nothing（默认）、best_effort
+	DryRun bool              // 仅校验，不写入
+	Lines  []ImportEventLine // 待导入的行
+}
+
+// ImportEventResult 单行导入结果
+type ImportEventResult struct {
+	Line      int          `json:"line"`                 // 行号
+	Status    string       `json:"status"`               // created、valid、rolled_back、failed
+	EventGUID string       `json:"event_guid,omitempty"` // 创建的事件 GUID
+	Error     string       `json:"error,omitempty"`      // 错误信息
+	Fields    []FieldError `json:"fields,omitempty"`     // 字段级校验错误
+}
+
+// ImportEventsResponse 批量导入事件响应
+type ImportEventsResponse struct {
+	Mode      string              `json:"mode"`      // 导入模式
+	DryRun    bool                `json:"dry_run"`   // 是否仅校验
+	Committed bool                `json:"committed"` // 是否已写入数据库
+	Total     int                 `json:"total"`     // 总行数
+	Succeeded int                 `json:"succeeded"` // 成功（或校验通过）的行数
+	Failed    int                 `json:"failed"`    // 失败的行数
+	Results   []ImportEventResult `json:"results"`   // 每行的结果
+}
+
+// ============================================
+// 接口 H: 后台创建事件 (Admin Create Event)
+// 兼容 docs/API_CREATE_EVENT.md 中的请求与响应格式
+// ============================================
+
+// AdminOutcomeRequest 结果选项请求
+type AdminOutcomeRequest struct {
+	Name  string `json:"name"`  // 结果名称
+	Color string `json:"color"` // 结果颜色（十六进制）
+	Idx   int    `json:"idx"`   // 排序索引
+}
+
+// AdminSubEventRequest 子事件请求
+type AdminSubEventRequest struct {
+	Question string                `json:"question"` // 问题内容
+	Outcomes []AdminOutcomeRequest `json:"outcomes"` // 结果选项（至少两个）
+}
+
+// AdminCreateEventRequest 后台创建事件请求
+type AdminCreateEventRequest struct {
+	Title           string                 `json:"title"`             // 事件标题
+	Description     string                 `json:"description"`       // 事件描述
+	ImageURL        string                 `json:"image_url"`         // 事件图片 URL
+	StartDate       int64                  `json:"start_date"`        // 开始时间（Unix 秒）
+	EndDate         int64                  `json:"end_date"`          // 结束时间（Unix 秒）
+	Tags            []string               `json:"tags"`              // 标签列表
+	SubEvents       []AdminSubEventRequest `json:"sub_events"`        // 子事件列表（至少一个）
+	CategoryGUID    string                 `json:"category_guid"`     // 分类 GUID（可选，与生态、时间标签同时提供）
+	EcosystemGUID   string                 `json:"ecosystem_guid"`    // 生态 GUID（可选）
+	EventPeriodGUID string                 `json:"event_period_guid"` // 时间标签 GUID（可选）
+}
+
+// AdminTagResponse 标签响应
+type AdminTagResponse struct {
+	GUID    string `json:"guid"`    // 标签 GUID
+	Name    string `json:"name"`    // 标签名称
+	Created int64  `json:"created"` // 创建时间（Unix 秒）
+	Updated int64  `json:"updated"` // 更新时间（Unix 秒）
+}
+
+// AdminOutcomeResponse 结果选项响应
+type AdminOutcomeResponse struct {
+	GUID         string `json:"guid"`           // 方向 GUID
+	SubEventGUID string `json:"sub_event_guid"` // 子事件 GUID
+	Name         string `json:"name"`           // 结果名称
+	Color        string `json:"color"`          // 结果颜色
+	Idx          int    `json:"idx"`            // 排序索引
+	Created      int64  `json:"created"`        // 创建时间（Unix 秒）
+	Updated      int64  `json:"updated"`        // 更新时间（Unix 秒）
+}
+
+// AdminSubEventResponse 子事件响应
+type AdminSubEventResponse struct {
+	GUID      string                 `json:"guid"`       // 子事件 GUID
+	EventGUID string                 `json:"event_guid"` // 事件 GUID
+	Question  string                 `json:"question"`   // 问题内容
+	Outcomes  []AdminOutcomeResponse `json:"outcomes"`   // 结果选项，按 idx 升序
+	Created   int64                  `json:"created"`    // 创建时间（Unix 秒）
+	Updated   int64                  `json:"updated"`    // 更新时间（Unix 秒）
+}
+
+// AdminEventResponse 后台创建事件响应
+type AdminEventResponse struct {
+	GUID        string                  `json:"guid"`        // 事件 GUID
+	Title       string                  `json:"title"`       // 事件标题
+	Description string                  `json:"description"` // 事件描述
+	ImageURL    string                  `json:"image_url"`   // 事件图片 URL
+	StartDate   int64                   `json:"start_date"`  // 开始时间（Unix 秒）
+	EndDate     int64                   `json:"end_date"`    // 结束时间（Unix 秒）
+	Tags        []AdminTagResponse      `json:"tags"`        // 标签列表
+	SubEvents   []AdminSubEventResponse `json:"sub_events"`  // 子事件列表
+	Created     int64                   `json:"created"`     // 创建时间（Unix 秒）
+	Updated     int64                   `json:"updated"`     // 更新时间（Unix 秒）
+}
+
+// ============================================
+// 接口 I: 子事件概率走势 (Chance History)
+// ============================================
+
+// ChanceHistoryRequest 概率走势查询请求
+type ChanceHistoryRequest struct {
+	SubEventGUID string `json:"sub_event_guid"` // 子事件 GUID（来自路径）
+	Window       string `json:"window"`         // 时间窗口：1h、6h、1d、1w、all，默认 1d
+}
+
+// ChanceHistoryPoint 概率走势中的一个点
+type ChanceHistoryPoint struct {
+	Time   int64 `json:"t"`      // 时间桶起点，Unix 时间戳（秒）
+	Chance int16 `json:"chance"` // 该时间桶内最后一次采样的概率
+}
+
+// DirectionChanceHistory 单个方向的概率走势
+type DirectionChanceHistory struct {
+	DirectionGUID string               `json:"direction_guid"` // 方向 GUID
+	Direction     string               `json:"direction"`      // 方向名称
+	Chance        int16                `json:"chance"`         // 当前概率
+	Points        []ChanceHistoryPoint `json:"points"`         // 按时间升序的走势
+}
+
+// ChanceHistoryResponse 概率走势响应
+type ChanceHistoryResponse struct {
+	SubEventGUID    string                   `json:"sub_event_guid"`   // 子事件 GUID
+	Window          string                   `json:"window"`           // 时间窗口
+	IntervalSeconds int64                    `json:"interval_seconds"` // 采样间隔（秒）
+	Directions      []DirectionChanceHistory `json:"directions"`       // 各方向的走势
+}
+
+// ============================================
+// 接口 J: 做市报价 (Market Quote)
+// ============================================
+
+// MarketQuoteRequest 做市报价请求
+type MarketQuoteRequest struct {
+	SubEventGUID  string  `json:"sub_event_guid"` // 子事件 GUID（来自路径）
+	DirectionGUID string  `json:"direction_guid"` // 方向 GUID
+	Amount        float64 `json:"amount"`         // 交易份额，正数买入、负数卖出
+}
+
+// MarketQuoteResponse 做市报价响应
+type MarketQuoteResponse struct {
+	SubEventGUID  string                      `json:"sub_event_guid"` // 子事件 GUID
+	DirectionGUID string                      `json:"direction_guid"` // 方向 GUID
+	Amount        float64                     `json:"amount"`         // 交易份额
+	Liquidity     float64                     `json:"liquidity"`      // LMSR 流动性参数 b
+	Cost          float64                     `json:"cost"`           // 成交金额，卖出时为负数
+	AveragePrice  float64                     `json:"average_price"`  // 每份成交均价
+	PriceBefore   float64                     `json:"price_before"`   // 成交前该方向的价格
+	PriceAfter    float64                     `json:"price_after"`    // 成交后该方向的价格
+	Directions    []SubEventDirectionResponse `json:"directions"`     // 成交后各方向的概率和买卖价
+}
+
+// ============================================
+// 接口 R: 删除与恢复事件 (Delete & Restore Events)
+// 只有草稿事件可以删除；删除为软删除，清理任务硬删除前可以恢复
+// ============================================
+
+// RestoreEventResponse 恢复事件响应
+type RestoreEventResponse struct {
+	GUID      string `json:"guid"`       // 事件 GUID
+	Status    string `json:"status"`     // 事件状态（恢复后仍为草稿）
+	UpdatedAt string `json:"updated_at"` // 新的 updated_at，下次更新时需带上
+}
+
+// FieldError 字段级校验错误
+type FieldError struct {
+	Field   string `json:"field"`   // 字段路径，例如 sub_events[0].title
+	Message string `json:"message"` // 错误原因
 }
 
 // ErrorResponse 错误响应
 type ErrorResponse struct {
-	Error   string `json:"error"`             // 错误代码
-	Message string `json:"message,omitempty"` // 错误详细信息
+	Error   string       `json:"error"`             // 错误代码
+	Message string       `json:"message,omitempty"` // 错误详细信息
+	Fields  []FieldError `json:"fields,omitempty"`  // 字段级校验错误
 }
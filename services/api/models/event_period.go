@@ -0,0 +1,62 @@
+package models
+
+// ============================================
+// 接口 P: 时间标签管理 (Event Periods)
+// 时间标签属于某个分类；translations 的 key 为语言 GUID
+// ============================================
+
+// CreateEventPeriodRequest 创建时间标签请求
+type CreateEventPeriodRequest struct {
+	CategoryGUID string                         `json:"category_guid"` // 所属分类 GUID
+	Code         string                         `json:"code"`          // 业务编码（可选，唯一）
+	IsActive     *bool                          `json:"is_active"`     // 是否启用，默认 true
+	Remark       string                         `json:"remark"`        // 运营备注
+	Extra        map[string]interface{}         `json:"extra"`         // 扩展字段（JSON 对象）
+	Translations map[string]TaxonomyTranslation `json:"translations"`  // 各语言的名称和描述，至少一种语言
+}
+
+// UpdateEventPeriodRequest 更新时间标签请求，只更新提供的字段
+type UpdateEventPeriodRequest struct {
+	GUID         string                          `json:"-"`             // 时间标签 GUID（来自路径）
+	CategoryGUID *string                         `json:"category_guid"` // 所属分类，仍被事件引用时不能修改
+	Code         *string                         `json:"code"`          // 业务编码，空字符串表示清除
+	IsActive     *bool                           `json:"is_active"`     // 启用或停用
+	Remark       *string                         `json:"remark"`        // 运营备注
+	Extra        map[string]interface{}          `json:"extra"`         // 扩展字段，提供时整体替换
+	Translations map[string]*TaxonomyTranslation `json:"translations"`  // 新增或修改的翻译，值为 null 表示删除该语言
+}
+
+// EventPeriodDetailResponse 时间标签详情（后台，包含全部语言）
+type EventPeriodDetailResponse struct {
+	GUID         string                         `json:"guid"`          // 时间标签 GUID
+	CategoryGUID string                         `json:"category_guid"` // 所属分类 GUID
+	Code         string                         `json:"code"`          // 业务编码
+	IsActive     bool                           `json:"is_active"`     // 是否启用
+	Remark       string                         `json:"remark"`        // 运营备注
+	Extra        map[string]interface{}         `json:"extra"`         // 扩展字段
+	Translations map[string]TaxonomyTranslation `json:"translations"`  // 各语言的名称和描述
+	CreatedAt    string                         `json:"created_at"`    // 创建时间（RFC3339）
+	UpdatedAt    string                         `json:"updated_at"`    // 更新时间（RFC3339）
+}
+
+// ListEventPeriodsRequest 时间标签列表查询请求
+type ListEventPeriodsRequest struct {
+	LanguageGUID    string `json:"language_guid"`    // 返回名称的语言（由 LanguageMiddleware 解析）
+	CategoryGUID    string `json:"category_guid"`    // 按分类过滤（可选）
+	IncludeInactive bool   `json:"include_inactive"` // 是否包含停用的时间标签（仅后台）
+}
+
+// EventPeriodResponse 时间标签响应（名称多语言）
+type EventPeriodResponse struct {
+	GUID         string `json:"guid"`          // 时间标签 GUID
+	CategoryGUID string `json:"category_guid"` // 所属分类 GUID
+	Code         string `json:"code"`          // 业务编码
+	IsActive     bool   `json:"is_active"`     // 是否启用
+	Name         string `json:"name"`          // 名称（请求语言，缺少时回退到默认语言）
+	Description  string `json:"description"`   // 描述
+}
+
+// ListEventPeriodsResponse 时间标签列表响应
+type ListEventPeriodsResponse struct {
+	EventPeriods []EventPeriodResponse `json:"event_periods"` // 按创建时间升序
+}
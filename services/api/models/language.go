@@ -0,0 +1,57 @@
+package models
+
+// ============================================
+// 接口 Q: 语言管理 (Languages)
+// 同一时刻只有一个默认语言；默认语言必须启用，不能停用
+// ============================================
+
+// CreateLanguageRequest 创建语言请求
+type CreateLanguageRequest struct {
+	LanguageName  string `json:"language_name"`  // 语言代码（如 zh、en、zh-tw），保存为小写，唯一
+	LanguageLabel string `json:"language_label"` // 语言显示名称（如 中文、English）
+	IsDefault     bool   `json:"is_default"`     // 是否设为默认语言（原默认语言自动取消）
+	IsActive      *bool  `json:"is_active"`      // 是否启用，默认 true
+}
+
+// UpdateLanguageRequest 更新语言请求，只更新提供的字段；启用、停用使用单独的接口
+type UpdateLanguageRequest struct {
+	GUID          string  `json:"-"`              // 语言 GUID（来自路径）
+	LanguageLabel *string `json:"language_label"` // 语言显示名称
+	IsDefault     *bool   `json:"is_default"`     // 只能设为 true：设为默认语言，原默认语言自动取消
+}
+
+// LanguageResponse 语言响应
+type LanguageResponse struct {
+	GUID          string `json:"guid"`           // 语言 GUID
+	LanguageName  string `json:"language_name"`  // 语言代码
+	LanguageLabel string `json:"language_label"` // 语言显示名称
+	IsDefault     bool   `json:"is_default"`     // 是否为默认语言
+	IsActive      bool   `json:"is_active"`      // 是否启用
+	CreatedAt     string `json:"created_at"`     // 创建时间（RFC3339）
+	UpdatedAt     string `json:"updated_at"`     // 更新时间（RFC3339）
+}
+
+// ListLanguagesResponse 语言列表响应
+type ListLanguagesResponse struct {
+	Languages []LanguageResponse `json:"languages"` // 默认语言在前，其余按语言代码升序
+}
+
+// MissingTranslationsResponse 缺少翻译的记录
+type MissingTranslationsResponse struct {
+	Total int64    `json:"total"` // 缺少翻译的记录总数
+	GUIDs []string `json:"guids"` // 按创建时间升序的前若干个 GUID
+}
+
+// TranslationGapsResponse 事件、分类、运动队缺少某语言翻译的报告
+type TranslationGapsResponse struct {
+	LanguageGUID string                      `json:"language_guid"` // 语言 GUID
+	Events       MissingTranslationsResponse `json:"events"`        // 缺少标题和规则的事件
+	Categories   MissingTranslationsResponse `json:"categories"`    // 缺少名称的分类
+	TeamGroups   MissingTranslationsResponse `json:"team_groups"`   // 缺少名称的运动队
+}
+
+// ActivateLanguageResponse 启用语言响应
+type ActivateLanguageResponse struct {
+	Language            LanguageResponse        `json:"language"`             // 启用后的语言
+	MissingTranslations TranslationGapsResponse `json:"missing_translations"` // 仍缺少该语言翻译的记录
+}
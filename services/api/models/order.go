@@ -0,0 +1,121 @@
+package models
+
+import "github.com/multimarket-labs/event-pod-services/common/decimal"
+
+// ============================================
+// 接口 K: 下单与持仓 (Orders & Positions)
+// 金额、份额和价格均为十进制字符串，按 NUMERIC(38,18) 精确记账
+// ============================================
+
+// PlaceOrderRequest 下单请求：按做市商当前价格买入或卖出某方向的份额
+type PlaceOrderRequest struct {
+	IdempotencyKey string           `json:"-"`              // Idempotency-Key 请求头，相同键的重试返回首次的响应
+	UserAddress    string           `json:"-"`              // 用户钱包地址（来自钱包令牌）
+	SubEventGUID   string           `json:"sub_event_guid"` // 子事件 GUID
+	DirectionGUID  string           `json:"direction_guid"` // 方向 GUID
+	Side           string           `json:"side"`           // buy 或 sell
+	Shares         decimal.Decimal  `json:"shares"`         // 份额，最多 6 位小数
+	LimitPrice     *decimal.Decimal `json:"limit_price"`    // 限价（可选）：买入均价不高于 / 卖出均价不低于该价格
+}
+
+// OrderResponse 订单
+type OrderResponse struct {
+	GUID          string           `json:"guid"`           // 订单 GUID
+	UserAddress   string           `json:"user_address"`   // 用户钱包地址
+	EventGUID     string           `json:"event_guid"`     // 事件 GUID
+	SubEventGUID  string           `json:"sub_event_guid"` // 子事件 GUID
+	DirectionGUID string           `json:"direction_guid"` // 方向 GUID
+	Side          string           `json:"side"`           // buy 或 sell
+	Shares        decimal.Decimal  `json:"shares"`         // 份额
+	LimitPrice    *decimal.Decimal `json:"limit_price"`    // 限价，未设置时为 null
+	Amount        decimal.Decimal  `json:"amount"`         // 买入支付 / 卖出所得
+	AveragePrice  decimal.Decimal  `json:"average_price"`  // 成交均价
+	Status        string           `json:"status"`         // 订单状态
+	CreatedAt     string           `json:"created_at"`     // 创建时间（RFC3339）
+}
+
+// FillResponse 成交记录
+type FillResponse struct {
+	GUID        string          `json:"guid"`         // 成交 GUID
+	OrderGUID   string          `json:"order_guid"`   // 订单 GUID
+	Shares      decimal.Decimal `json:"shares"`       // 成交份额
+	Price       decimal.Decimal `json:"price"`        // 成交均价
+	Amount      decimal.Decimal `json:"amount"`       // 成交金额
+	PriceBefore decimal.Decimal `json:"price_before"` // 成交前该方向的价格
+	PriceAfter  decimal.Decimal `json:"price_after"`  // 成交后该方向的价格
+	CreatedAt   string          `json:"created_at"`   // 成交时间（RFC3339）
+}
+
+// PositionResponse 持仓
+type PositionResponse struct {
+	GUID          string          `json:"guid"`           // 持仓 GUID
+	UserAddress   string          `json:"user_address"`   // 用户钱包地址
+	EventGUID     string          `json:"event_guid"`     // 事件 GUID
+	SubEventGUID  string          `json:"sub_event_guid"` // 子事件 GUID
+	DirectionGUID string          `json:"direction_guid"` // 方向 GUID
+	Shares        decimal.Decimal `json:"shares"`         // 持有份额
+	CostBasis     decimal.Decimal `json:"cost_basis"`     // 持有份额的买入成本
+	RealizedPnl   decimal.Decimal `json:"realized_pnl"`   // 卖出已实现盈亏
+	Payout        decimal.Decimal `json:"payout"`         // 结算派彩
+	Status        string          `json:"status"`         // open 或 settled
+	SettledAt     *int64          `json:"settled_at"`     // 结算时间，Unix 时间戳（秒），未结算时为 null
+}
+
+// BalanceResponse 用户余额
+type BalanceResponse struct {
+	UserAddress string          `json:"user_address"` // 用户钱包地址
+	Available   decimal.Decimal `json:"available"`    // 可用余额
+}
+
+// PlaceOrderResponse 下单响应
+type PlaceOrderResponse struct {
+	Order      OrderResponse               `json:"order"`      // 订单
+	Fill       FillResponse                `json:"fill"`       // 成交记录
+	Position   PositionResponse            `json:"position"`   // 成交后的持仓
+	Balance    BalanceResponse             `json:"balance"`    // 成交后的余额
+	Directions []SubEventDirectionResponse `json:"directions"` // 成交后各方向的概率和买卖价
+
+	Replayed bool `json:"-"` // 是否为幂等重放的首次响应
+}
+
+// CreditBalanceRequest 后台入账请求
+type CreditBalanceRequest struct {
+	UserAddress string          `json:"user_address"` // 用户钱包地址
+	Amount      decimal.Decimal `json:"amount"`       // 入账金额，必须为正数
+	Operator    string          `json:"-"`            // 操作人（来自令牌）
+}
+
+// CreditBalanceResponse 后台入账响应
+type CreditBalanceResponse struct {
+	CreditGUID  string          `json:"credit_guid"`  // 入账记录 GUID
+	UserAddress string          `json:"user_address"` // 用户钱包地址
+	Amount      decimal.Decimal `json:"amount"`       // 入账金额
+	Operator    string          `json:"operator"`     // 操作人
+	Available   decimal.Decimal `json:"available"`    // 入账后的可用余额
+}
+
+// ListOrdersRequest 订单列表查询请求
+type ListOrdersRequest struct {
+	UserAddress string `json:"user_address"` // 用户钱包地址（来自路径）
+	Page        int    `json:"page"`         // 页码，默认 1
+	Limit       int    `json:"limit"`        // 每页数量，默认 20，最大 100
+}
+
+// ListOrdersResponse 订单列表响应
+type ListOrdersResponse struct {
+	Orders     []OrderResponse `json:"orders"`     // 订单列表（按时间倒序）
+	Pagination *PaginationInfo `json:"pagination"` // 分页信息
+}
+
+// ListPositionsRequest 持仓查询请求
+type ListPositionsRequest struct {
+	UserAddress string `json:"user_address"` // 用户钱包地址（来自路径）
+	Status      string `json:"status"`       // 持仓状态过滤：open、settled（可选）
+}
+
+// ListPositionsResponse 持仓查询响应
+type ListPositionsResponse struct {
+	UserAddress string             `json:"user_address"` // 用户钱包地址
+	Balance     decimal.Decimal    `json:"balance"`      // 可用余额
+	Positions   []PositionResponse `json:"positions"`    // 持仓列表
+}
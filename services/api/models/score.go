@@ -0,0 +1,49 @@
+package models
+
+// ============================================
+// 接口 L: 运动类事件比分推送 (Live Score Feed)
+// 得分为非负整数的十进制字符串（UINT256）
+// ============================================
+
+// UpdateEventScoreRequest 比分推送请求，只更新提供的字段
+type UpdateEventScoreRequest struct {
+	GUID         string  `json:"-"`             // 事件 GUID（来自路径）
+	MainScore    *string `json:"main_score"`    // 主队得分
+	ClusterScore *string `json:"cluster_score"` // 客队得分
+	Stage        *string `json:"stage"`         // 比赛阶段，只能停留在当前阶段或按运动项目的顺序前进
+	Source       string  `json:"source"`        // 比分源（必需）
+}
+
+// EventScoreResponse 比分推送响应
+type EventScoreResponse struct {
+	EventGUID    string                     `json:"event_guid"`    // 事件 GUID
+	Sport        string                     `json:"sport"`         // 运动项目
+	MainScore    string                     `json:"main_score"`    // 主队得分
+	ClusterScore string                     `json:"cluster_score"` // 客队得分
+	Stage        string                     `json:"stage"`         // 比赛阶段
+	UpdatedAt    string                     `json:"updated_at"`    // 事件的 updated_at（RFC3339）
+	Change       *EventScoreHistoryResponse `json:"change"`        // 本次推送的变更记录，比分和阶段都未变化时为 null
+}
+
+// EventScoreHistoryResponse 比分变更记录
+type EventScoreHistoryResponse struct {
+	GUID             string `json:"guid"`               // 记录 GUID
+	FromMainScore    string `json:"from_main_score"`    // 变更前主队得分
+	FromClusterScore string `json:"from_cluster_score"` // 变更前客队得分
+	FromStage        string `json:"from_stage"`         // 变更前比赛阶段
+	MainScore        string `json:"main_score"`         // 变更后主队得分
+	ClusterScore     string `json:"cluster_score"`      // 变更后客队得分
+	Stage            string `json:"stage"`              // 变更后比赛阶段
+	Source           string `json:"source"`             // 比分源
+	CreatedAt        string `json:"created_at"`         // 变更时间（RFC3339）
+}
+
+// ListEventScoreHistoryResponse 比分变更记录列表
+type ListEventScoreHistoryResponse struct {
+	EventGUID    string                      `json:"event_guid"`    // 事件 GUID
+	Sport        string                      `json:"sport"`         // 运动项目
+	MainScore    string                      `json:"main_score"`    // 当前主队得分
+	ClusterScore string                      `json:"cluster_score"` // 当前客队得分
+	Stage        string                      `json:"stage"`         // 当前比赛阶段
+	History      []EventScoreHistoryResponse `json:"history"`       // 变更记录（按时间顺序）
+}
@@ -0,0 +1,48 @@
+package models
+
+// ============================================
+// 接口 M: 运动队管理 (Team Groups)
+// 名称按语言存储，names 的 key 为语言 GUID
+// ============================================
+
+// CreateTeamGroupRequest 创建运动队请求
+type CreateTeamGroupRequest struct {
+	Logo  string            `json:"logo"`  // Logo URL（可选，也可创建后通过上传接口设置）
+	Names map[string]string `json:"names"` // 各语言的名称，至少一种语言
+}
+
+// UpdateTeamGroupRequest 更新运动队请求，只更新提供的字段
+type UpdateTeamGroupRequest struct {
+	GUID  string            `json:"-"`     // 运动队 GUID（来自路径）
+	Logo  *string           `json:"logo"`  // Logo URL
+	Names map[string]string `json:"names"` // 新增或修改的名称，值为空字符串表示删除该语言的名称
+}
+
+// UploadTeamGroupLogoRequest 上传运动队 Logo 请求（multipart/form-data 的 file 字段）
+type UploadTeamGroupLogoRequest struct {
+	GUID string // 运动队 GUID（来自路径）
+	Data []byte // 文件内容，类型按内容识别（png、jpeg、gif、webp）
+}
+
+// TeamGroupDetailResponse 运动队详情（包含全部语言的名称）
+type TeamGroupDetailResponse struct {
+	GUID      string            `json:"guid"`       // 运动队 GUID
+	Logo      string            `json:"logo"`       // Logo URL
+	Names     map[string]string `json:"names"`      // 各语言的名称，key 为语言 GUID
+	CreatedAt string            `json:"created_at"` // 创建时间（RFC3339）
+	UpdatedAt string            `json:"updated_at"` // 更新时间（RFC3339）
+}
+
+// ListTeamGroupsRequest 运动队列表查询请求
+type ListTeamGroupsRequest struct {
+	LanguageGUID string `json:"language_guid"` // 返回名称的语言（由 LanguageMiddleware 解析）
+	Query        string `json:"q"`             // 按名称模糊搜索（任意语言，不区分大小写）
+	Page         int    `json:"page"`          // 页码，默认 1
+	Limit        int    `json:"limit"`         // 每页数量，默认 20，最大 100
+}
+
+// ListTeamGroupsResponse 运动队列表响应
+type ListTeamGroupsResponse struct {
+	TeamGroups []TeamGroupResponse `json:"team_groups"` // 运动队列表（名称多语言，按创建时间倒序）
+	Pagination *PaginationInfo     `json:"pagination"`  // 分页信息
+}
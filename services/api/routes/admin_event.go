@@ -0,0 +1,91 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// CreateAdminEventHandler 处理 POST /api/v1/admin/events
+// 请求与响应格式见 docs/API_CREATE_EVENT.md
+func (rs *Routes) CreateAdminEventHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== CreateAdminEvent Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	var req models.AdminCreateEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error("failed to decode request body", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to parse request body: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	log.Info("CreateAdminEvent request summary",
+		"title", req.Title,
+		"start_date", req.StartDate,
+		"end_date", req.EndDate,
+		"tags", len(req.Tags),
+		"sub_events_count", len(req.SubEvents),
+	)
+
+	response, err := rs.svc.CreateAdminEvent(&req)
+	if err != nil {
+		log.Error("failed to create admin event", "err", err)
+		writeServiceError(w, err, "create_failed")
+		return
+	}
+
+	log.Info("Admin event created successfully", "event_guid", response.GUID, "sub_events_count", len(response.SubEvents))
+	jsonResponse(w, response, http.StatusCreated)
+	log.Info("=== CreateAdminEvent Request Completed ===")
+}
+
+// DeleteEventHandler 处理 DELETE /api/v1/admin/events/{guid}
+// 接口 R：只有草稿事件可以删除，其他状态返回 409
+func (rs *Routes) DeleteEventHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== DeleteEvent Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	guid := chi.URLParam(r, "guid")
+	if err := rs.svc.DeleteEvent(guid); err != nil {
+		log.Error("failed to delete event", "guid", guid, "err", err)
+		writeServiceError(w, err, "delete_failed")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Info("=== DeleteEvent Request Completed ===", "guid", guid)
+}
+
+// RestoreEventHandler 处理 POST /api/v1/admin/events/{guid}/restore
+// 未删除的事件返回 404；引用的分类、生态、时间标签或运动队已删除时返回 400
+func (rs *Routes) RestoreEventHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== RestoreEvent Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	guid := chi.URLParam(r, "guid")
+	response, err := rs.svc.RestoreEvent(guid)
+	if err != nil {
+		log.Error("failed to restore event", "guid", guid, "err", err)
+		writeServiceError(w, err, "restore_failed")
+		return
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== RestoreEvent Request Completed ===", "guid", guid)
+}
@@ -0,0 +1,86 @@
+package routes
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+type walletAddressContextKey struct{}
+
+// WalletAddressFromContext 获取 WalletAuth 校验通过的钱包地址（小写）
+func WalletAddressFromContext(ctx context.Context) string {
+	address, _ := ctx.Value(walletAddressContextKey{}).(string)
+	return address
+}
+
+// WalletAuth 校验用户的 Authorization: Bearer <wallet token>，钱包地址放入 context
+func (rs *Routes) WalletAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
+			jsonResponse(w, models.ErrorResponse{Error: "unauthorized", Message: "missing or invalid Authorization header"}, http.StatusUnauthorized)
+			return
+		}
+
+		address, err := rs.svc.VerifyWalletToken(parts[1])
+		if err != nil {
+			log.Warn("invalid wallet token", "path", r.URL.Path, "remote_addr", r.RemoteAddr, "err", err)
+			jsonResponse(w, models.ErrorResponse{Error: "unauthorized", Message: "invalid or expired wallet token"}, http.StatusUnauthorized)
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), walletAddressContextKey{}, address)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// WalletNonceHandler 处理 POST /api/v1/auth/nonce
+// 签发登录随机数及需要钱包签名的登录消息
+func (rs *Routes) WalletNonceHandler(w http.ResponseWriter, r *http.Request) {
+	response, err := rs.svc.IssueWalletNonce()
+	if err != nil {
+		log.Error("failed to issue wallet nonce", "err", err)
+		writeServiceError(w, err, "nonce_failed")
+		return
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// WalletLoginHandler 处理 POST /api/v1/auth/login
+// 校验钱包对登录消息的签名，签发钱包令牌
+func (rs *Routes) WalletLoginHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== WalletLogin Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	var req models.WalletLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error("failed to decode request body", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to parse request body: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	response, err := rs.svc.WalletLogin(&req)
+	if err != nil {
+		log.Warn("wallet login failed", "remote_addr", r.RemoteAddr, "err", err)
+		writeServiceError(w, err, "login_failed")
+		return
+	}
+
+	log.Info("WalletLogin succeeded", "user_address", response.UserAddress)
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== WalletLogin Request Completed ===")
+}
@@ -0,0 +1,220 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// CreateCategoryHandler 处理 POST /api/v1/admin/categories
+// 接口 N：创建分类及其各语言的名称，parent_guid 为空时为一级分类
+func (rs *Routes) CreateCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== CreateCategory Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	var req models.CreateCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error("failed to decode request body", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to parse request body: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	response, err := rs.svc.CreateCategory(&req)
+	if err != nil {
+		log.Error("failed to create category", "parent_guid", req.ParentGUID, "err", err)
+		writeServiceError(w, err, "creation_failed")
+		return
+	}
+
+	log.Info("CreateCategory succeeded",
+		"guid", response.GUID,
+		"parent_guid", response.ParentGUID,
+		"level", response.Level,
+	)
+
+	jsonResponse(w, response, http.StatusCreated)
+	log.Info("=== CreateCategory Request Completed ===")
+}
+
+// GetCategoryHandler 处理 GET /api/v1/admin/categories/{guid}
+// 返回分类在全部语言下的翻译
+func (rs *Routes) GetCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	guid := chi.URLParam(r, "guid")
+
+	response, err := rs.svc.GetCategory(guid)
+	if err != nil {
+		log.Error("failed to get category", "guid", guid, "err", err)
+		writeServiceError(w, err, "query_failed")
+		return
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// UpdateCategoryHandler 处理 PATCH /api/v1/admin/categories/{guid}
+// 只更新提供的字段；parent_guid 移动分类（子分类随之移动），is_active 启用或停用
+func (rs *Routes) UpdateCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== UpdateCategory Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	var req models.UpdateCategoryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error("failed to decode request body", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to parse request body: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	req.GUID = chi.URLParam(r, "guid")
+
+	response, err := rs.svc.UpdateCategory(&req)
+	if err != nil {
+		log.Error("failed to update category", "guid", req.GUID, "err", err)
+		writeServiceError(w, err, "update_failed")
+		return
+	}
+
+	log.Info("UpdateCategory succeeded",
+		"guid", response.GUID,
+		"parent_guid", response.ParentGUID,
+		"is_active", response.IsActive,
+	)
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== UpdateCategory Request Completed ===")
+}
+
+// DeleteCategoryHandler 处理 DELETE /api/v1/admin/categories/{guid}
+// 仍有子分类或被事件、生态、时间标签引用的分类返回 409
+func (rs *Routes) DeleteCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== DeleteCategory Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	guid := chi.URLParam(r, "guid")
+	if err := rs.svc.DeleteCategory(guid); err != nil {
+		log.Error("failed to delete category", "guid", guid, "err", err)
+		writeServiceError(w, err, "delete_failed")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Info("=== DeleteCategory Request Completed ===", "guid", guid)
+}
+
+// RestoreCategoryHandler 处理 POST /api/v1/admin/categories/{guid}/restore
+// 未删除的分类返回 404；父分类已删除或业务编码已被使用时返回 400
+func (rs *Routes) RestoreCategoryHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== RestoreCategory Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	guid := chi.URLParam(r, "guid")
+	response, err := rs.svc.RestoreCategory(guid)
+	if err != nil {
+		log.Error("failed to restore category", "guid", guid, "err", err)
+		writeServiceError(w, err, "restore_failed")
+		return
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== RestoreCategory Request Completed ===", "guid", guid)
+}
+
+// ReorderCategoriesHandler 处理 POST /api/v1/admin/categories:reorder
+// 拖拽排序：按新的顺序给出父分类下的全部子分类，sort_order 依次重写为 0、1、2…
+func (rs *Routes) ReorderCategoriesHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== ReorderCategories Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	var req models.ReorderCategoriesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error("failed to decode request body", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to parse request body: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	if err := rs.svc.ReorderCategories(&req); err != nil {
+		log.Error("failed to reorder categories", "parent_guid", req.ParentGUID, "err", err)
+		writeServiceError(w, err, "reorder_failed")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Info("=== ReorderCategories Request Completed ===", "parent_guid", req.ParentGUID, "count", len(req.GUIDs))
+}
+
+// ListCategoriesHandler 处理 GET /api/v1/categories
+// 只返回启用的分类；tree=true 时按层级嵌套返回
+func (rs *Routes) ListCategoriesHandler(w http.ResponseWriter, r *http.Request) {
+	rs.listCategories(w, r, false)
+}
+
+// ListAdminCategoriesHandler 处理 GET /api/v1/admin/categories
+// 与 ListCategoriesHandler 相同，但包含停用的分类
+func (rs *Routes) ListAdminCategoriesHandler(w http.ResponseWriter, r *http.Request) {
+	rs.listCategories(w, r, true)
+}
+
+// listCategories 解析分类列表的查询参数并返回分类列表或分类树
+func (rs *Routes) listCategories(w http.ResponseWriter, r *http.Request, includeInactive bool) {
+	log.Info("=== ListCategories Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"query", r.URL.RawQuery,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	tree, err := parseOptionalBool(r.URL.Query().Get("tree"), "tree")
+	if err != nil {
+		log.Error("invalid query parameter", "err", err)
+		jsonResponse(w, models.ErrorResponse{Error: "invalid_request", Message: err.Error()}, http.StatusBadRequest)
+		return
+	}
+
+	req := models.ListCategoriesRequest{
+		LanguageGUID:    LanguageGUIDFromContext(r.Context()),
+		Tree:            tree != nil && *tree,
+		IncludeInactive: includeInactive,
+	}
+
+	response, err := rs.svc.ListCategories(&req)
+	if err != nil {
+		log.Error("failed to list categories", "err", err)
+		writeServiceError(w, err, "query_failed")
+		return
+	}
+
+	log.Info("ListCategories succeeded",
+		"categories_count", len(response.Categories),
+		"tree", req.Tree,
+		"include_inactive", req.IncludeInactive,
+	)
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== ListCategories Request Completed ===")
+}
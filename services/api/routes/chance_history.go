@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// GetChanceHistoryHandler 处理 GET /api/v1/sub-events/{guid}/chance-history
+// 接口 I：子事件各方向的概率走势，window=1h|6h|1d|1w|all，默认 1d
+func (rs *Routes) GetChanceHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== GetChanceHistory Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"query", r.URL.RawQuery,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	req := models.ChanceHistoryRequest{
+		SubEventGUID: chi.URLParam(r, "guid"),
+		Window:       r.URL.Query().Get("window"),
+	}
+
+	response, err := rs.svc.GetChanceHistory(&req)
+	if err != nil {
+		log.Error("failed to get chance history", "sub_event_guid", req.SubEventGUID, "window", req.Window, "err", err)
+		writeServiceError(w, err, "get_failed")
+		return
+	}
+
+	log.Info("GetChanceHistory succeeded",
+		"sub_event_guid", response.SubEventGUID,
+		"window", response.Window,
+		"directions_count", len(response.Directions),
+	)
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== GetChanceHistory Request Completed ===")
+}
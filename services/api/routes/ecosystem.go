@@ -0,0 +1,183 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// CreateEcosystemHandler 处理 POST /api/v1/admin/ecosystems
+// 接口 O：创建生态及其各语言的名称，生态需属于已存在的分类
+func (rs *Routes) CreateEcosystemHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== CreateEcosystem Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	var req models.CreateEcosystemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error("failed to decode request body", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to parse request body: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	response, err := rs.svc.CreateEcosystem(&req)
+	if err != nil {
+		log.Error("failed to create ecosystem", "category_guid", req.CategoryGUID, "err", err)
+		writeServiceError(w, err, "creation_failed")
+		return
+	}
+
+	log.Info("CreateEcosystem succeeded",
+		"guid", response.GUID,
+		"category_guid", response.CategoryGUID,
+	)
+
+	jsonResponse(w, response, http.StatusCreated)
+	log.Info("=== CreateEcosystem Request Completed ===")
+}
+
+// GetEcosystemHandler 处理 GET /api/v1/admin/ecosystems/{guid}
+// 返回生态在全部语言下的翻译及当前事件数
+func (rs *Routes) GetEcosystemHandler(w http.ResponseWriter, r *http.Request) {
+	guid := chi.URLParam(r, "guid")
+
+	response, err := rs.svc.GetEcosystem(guid)
+	if err != nil {
+		log.Error("failed to get ecosystem", "guid", guid, "err", err)
+		writeServiceError(w, err, "query_failed")
+		return
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// UpdateEcosystemHandler 处理 PATCH /api/v1/admin/ecosystems/{guid}
+// 只更新提供的字段；已被事件引用的生态修改 category_guid 返回 409
+func (rs *Routes) UpdateEcosystemHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== UpdateEcosystem Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	var req models.UpdateEcosystemRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error("failed to decode request body", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to parse request body: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	req.GUID = chi.URLParam(r, "guid")
+
+	response, err := rs.svc.UpdateEcosystem(&req)
+	if err != nil {
+		log.Error("failed to update ecosystem", "guid", req.GUID, "err", err)
+		writeServiceError(w, err, "update_failed")
+		return
+	}
+
+	log.Info("UpdateEcosystem succeeded",
+		"guid", response.GUID,
+		"category_guid", response.CategoryGUID,
+		"is_active", response.IsActive,
+	)
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== UpdateEcosystem Request Completed ===")
+}
+
+// DeleteEcosystemHandler 处理 DELETE /api/v1/admin/ecosystems/{guid}
+// 仍被事件引用的生态返回 409
+func (rs *Routes) DeleteEcosystemHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== DeleteEcosystem Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	guid := chi.URLParam(r, "guid")
+	if err := rs.svc.DeleteEcosystem(guid); err != nil {
+		log.Error("failed to delete ecosystem", "guid", guid, "err", err)
+		writeServiceError(w, err, "delete_failed")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Info("=== DeleteEcosystem Request Completed ===", "guid", guid)
+}
+
+// RestoreEcosystemHandler 处理 POST /api/v1/admin/ecosystems/{guid}/restore
+// 未删除的生态返回 404；所属分类已删除或业务编码已被使用时返回 400
+func (rs *Routes) RestoreEcosystemHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== RestoreEcosystem Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	guid := chi.URLParam(r, "guid")
+	response, err := rs.svc.RestoreEcosystem(guid)
+	if err != nil {
+		log.Error("failed to restore ecosystem", "guid", guid, "err", err)
+		writeServiceError(w, err, "restore_failed")
+		return
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== RestoreEcosystem Request Completed ===", "guid", guid)
+}
+
+// ListEcosystemsHandler 处理 GET /api/v1/ecosystems
+// 只返回启用的生态，可按 category_guid 过滤
+func (rs *Routes) ListEcosystemsHandler(w http.ResponseWriter, r *http.Request) {
+	rs.listEcosystems(w, r, false)
+}
+
+// ListAdminEcosystemsHandler 处理 GET /api/v1/admin/ecosystems
+// 与 ListEcosystemsHandler 相同，但包含停用的生态
+func (rs *Routes) ListAdminEcosystemsHandler(w http.ResponseWriter, r *http.Request) {
+	rs.listEcosystems(w, r, true)
+}
+
+// listEcosystems 解析生态列表的查询参数并返回生态列表
+func (rs *Routes) listEcosystems(w http.ResponseWriter, r *http.Request, includeInactive bool) {
+	log.Info("=== ListEcosystems Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"query", r.URL.RawQuery,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	req := models.ListEcosystemsRequest{
+		LanguageGUID:    LanguageGUIDFromContext(r.Context()),
+		CategoryGUID:    r.URL.Query().Get("category_guid"),
+		IncludeInactive: includeInactive,
+	}
+
+	response, err := rs.svc.ListEcosystems(&req)
+	if err != nil {
+		log.Error("failed to list ecosystems", "err", err)
+		writeServiceError(w, err, "query_failed")
+		return
+	}
+
+	log.Info("ListEcosystems succeeded",
+		"ecosystems_count", len(response.Ecosystems),
+		"category_guid", req.CategoryGUID,
+		"include_inactive", req.IncludeInactive,
+	)
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== ListEcosystems Request Completed ===")
+}
@@ -2,13 +2,16 @@ package routes
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/go-chi/chi/v5"
 
 	"github.com/multimarket-labs/event-pod-services/services/api/models"
+	"github.com/multimarket-labs/event-pod-services/services/api/service"
 )
 
 // CreateEventHandler 处理 POST /api/v1/events
@@ -32,6 +35,7 @@ func (rs *Routes) CreateEventHandler(w http.ResponseWriter, r *http.Request) {
 		}, http.StatusBadRequest)
 		return
 	}
+	req.IdempotencyKey = r.Header.Get("Idempotency-Key")
 
 	// 将请求体转换为 JSON 字符串用于日志
 	reqJSON, err := json.MarshalIndent(req, "", "  ")
@@ -63,6 +67,7 @@ func (rs *Routes) CreateEventHandler(w http.ResponseWriter, r *http.Request) {
 		"title", req.Title,
 		"is_sports", req.IsSports,
 		"sub_events_count", len(req.SubEvents),
+		"idempotency_key", req.IdempotencyKey,
 	)
 
 	// 调用 service 层
@@ -70,10 +75,7 @@ func (rs *Routes) CreateEventHandler(w http.ResponseWriter, r *http.Request) {
 	response, err := rs.svc.CreateEvent(&req)
 	if err != nil {
 		log.Error("failed to create event", "err", err)
-		jsonResponse(w, models.ErrorResponse{
-			Error:   "create_failed",
-			Message: err.Error(),
-		}, http.StatusInternalServerError)
+		writeServiceError(w, err, "create_failed")
 		return
 	}
 
@@ -82,8 +84,14 @@ func (rs *Routes) CreateEventHandler(w http.ResponseWriter, r *http.Request) {
 		"event_guid", response.GUID,
 		"title", response.Title,
 		"sub_events_count", len(response.SubEvents),
+		"replayed", response.Replayed,
 	)
 
+	// 幂等重放返回首次的响应，并通过响应头告知客户端
+	if response.Replayed {
+		w.Header().Set("Idempotent-Replayed", "true")
+	}
+
 	// 返回成功响应
 	jsonResponse(w, response, http.StatusCreated)
 	log.Info("=== CreateEvent Request Completed ===")
@@ -120,23 +128,14 @@ func (rs *Routes) ListEventsHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// 解析 language_guid（必需）
-	req.LanguageGUID = r.URL.Query().Get("language_guid")
-	if req.LanguageGUID == "" {
-		// 尝试从 Header 中获取 Accept-Language
-		acceptLang := r.Header.Get("Accept-Language")
-		if acceptLang != "" {
-			// 这里可以根据 Accept-Language 映射到 language_guid
-			// 简化处理：直接使用 Accept-Language 作为 language_guid
-			req.LanguageGUID = acceptLang
-		}
-	}
+	// 语言由 LanguageMiddleware 解析（必需）
+	req.LanguageGUID = LanguageGUIDFromContext(r.Context())
 
 	if req.LanguageGUID == "" {
 		log.Error("language_guid is required")
 		jsonResponse(w, models.ErrorResponse{
 			Error:   "invalid_request",
-			Message: "language_guid is required (via query parameter or Accept-Language header)",
+			Message: "language is required (via language_guid, lang query parameter or Accept-Language header)",
 		}, http.StatusBadRequest)
 		return
 	}
@@ -152,6 +151,22 @@ func (rs *Routes) ListEventsHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// 解析其他过滤和排序参数（可选）
+	if err := parseListEventsFilters(r, &req); err != nil {
+		log.Error("invalid list events parameter", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	// 解析 cursor（可选）：出现该参数即使用游标分页，值为空表示第一页
+	if r.URL.Query().Has("cursor") {
+		cursor := r.URL.Query().Get("cursor")
+		req.Cursor = &cursor
+	}
+
 	// 打印请求参数
 	log.Info("ListEvents request parameters",
 		"page", req.Page,
@@ -159,6 +174,15 @@ func (rs *Routes) ListEventsHandler(w http.ResponseWriter, r *http.Request) {
 		"language_guid", req.LanguageGUID,
 		"category_guid", req.CategoryGUID,
 		"is_live", req.IsLive,
+		"ecosystem_guid", req.EcosystemGUID,
+		"event_period_guid", req.EventPeriodGUID,
+		"team_group_guid", req.TeamGroupGUID,
+		"is_sports", req.IsSports,
+		"is_online", req.IsOnline,
+		"order_type", req.OrderType,
+		"sort_by", req.SortBy,
+		"order", req.Order,
+		"cursor", req.Cursor,
 	)
 
 	// 构建完整的 curl 命令（可以直接复制使用）
@@ -183,6 +207,14 @@ func (rs *Routes) ListEventsHandler(w http.ResponseWriter, r *http.Request) {
 	log.Info("Calling service layer to list events")
 	response, err := rs.svc.ListEvents(&req)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidCursor) || errors.Is(err, service.ErrInvalidFilter) {
+			log.Warn("invalid list events request", "err", err)
+			jsonResponse(w, models.ErrorResponse{
+				Error:   "invalid_request",
+				Message: err.Error(),
+			}, http.StatusBadRequest)
+			return
+		}
 		log.Error("failed to list events", "err", err)
 		jsonResponse(w, models.ErrorResponse{
 			Error:   "list_failed",
@@ -192,14 +224,312 @@ func (rs *Routes) ListEventsHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// 记录成功响应
-	log.Info("ListEvents succeeded",
-		"events_count", len(response.Events),
-		"total", response.Pagination.Total,
-		"page", response.Pagination.Page,
-		"total_pages", response.Pagination.TotalPages,
-	)
+	if response.Pagination != nil {
+		log.Info("ListEvents succeeded",
+			"events_count", len(response.Events),
+			"total", response.Pagination.Total,
+			"page", response.Pagination.Page,
+			"total_pages", response.Pagination.TotalPages,
+		)
+	} else {
+		log.Info("ListEvents succeeded",
+			"events_count", len(response.Events),
+			"next_cursor", response.NextCursor,
+		)
+	}
 
 	// 返回成功响应
 	jsonResponse(w, response, http.StatusOK)
 	log.Info("=== ListEvents Request Completed ===")
 }
+
+// GetEventDetailHandler 处理 GET /api/v1/events/{guid}
+// 接口 C：查询事件详情
+func (rs *Routes) GetEventDetailHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== GetEventDetail Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"query", r.URL.RawQuery,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	req := models.GetEventDetailRequest{
+		GUID:         chi.URLParam(r, "guid"),
+		LanguageGUID: LanguageGUIDFromContext(r.Context()),
+	}
+
+	if req.LanguageGUID == "" {
+		log.Error("language_guid is required")
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "language is required (via language_guid, lang query parameter or Accept-Language header)",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	response, err := rs.svc.GetEventDetail(&req)
+	if err != nil {
+		if errors.Is(err, service.ErrEventNotFound) {
+			log.Warn("event not found", "guid", req.GUID, "language_guid", req.LanguageGUID, "err", err)
+			jsonResponse(w, models.ErrorResponse{
+				Error:   "not_found",
+				Message: err.Error(),
+			}, http.StatusNotFound)
+			return
+		}
+		log.Error("failed to get event detail", "guid", req.GUID, "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "get_failed",
+			Message: err.Error(),
+		}, http.StatusInternalServerError)
+		return
+	}
+
+	log.Info("GetEventDetail succeeded",
+		"event_guid", response.GUID,
+		"sub_events_count", len(response.SubEvents),
+	)
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== GetEventDetail Request Completed ===")
+}
+
+// UpdateEventHandler 处理 PUT/PATCH /api/v1/events/{guid}
+// 接口 D：更新事件（PUT 全量更新事件字段，PATCH 只更新提供的字段）
+func (rs *Routes) UpdateEventHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== UpdateEvent Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	var req models.UpdateEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error("failed to decode request body", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to parse request body: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	req.GUID = chi.URLParam(r, "guid")
+	req.Replace = r.Method == http.MethodPut
+
+	log.Info("UpdateEvent request summary",
+		"guid", req.GUID,
+		"replace", req.Replace,
+		"updated_at", req.UpdatedAt,
+		"languages_count", len(req.Languages),
+		"add_sub_events_count", len(req.AddSubEvents),
+		"remove_sub_events_count", len(req.RemoveSubEventGUIDs),
+		"add_directions_count", len(req.AddDirections),
+		"remove_directions_count", len(req.RemoveDirectionGUIDs),
+	)
+
+	response, err := rs.svc.UpdateEvent(&req)
+	if err != nil {
+		log.Error("failed to update event", "guid", req.GUID, "err", err)
+		writeServiceError(w, err, "update_failed")
+		return
+	}
+
+	log.Info("UpdateEvent succeeded",
+		"event_guid", response.GUID,
+		"updated_at", response.UpdatedAt,
+		"sub_events_count", len(response.SubEvents),
+	)
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== UpdateEvent Request Completed ===")
+}
+
+// TransitionEventHandler 处理 POST /api/v1/events/{guid}/transitions
+// 接口 E：流转事件生命周期状态
+func (rs *Routes) TransitionEventHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== TransitionEvent Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	var req models.TransitionEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error("failed to decode request body", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to parse request body: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	req.GUID = chi.URLParam(r, "guid")
+	req.Actor = OperatorFromContext(r.Context())
+
+	response, err := rs.svc.TransitionEvent(&req)
+	if err != nil {
+		log.Error("failed to transition event", "guid", req.GUID, "to_status", req.ToStatus, "err", err)
+		writeServiceError(w, err, "transition_failed")
+		return
+	}
+
+	log.Info("TransitionEvent succeeded",
+		"event_guid", response.EventGUID,
+		"from_status", response.FromStatus,
+		"to_status", response.ToStatus,
+		"actor", response.Actor,
+	)
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== TransitionEvent Request Completed ===")
+}
+
+// ListEventTransitionsHandler 处理 GET /api/v1/events/{guid}/transitions
+// 查询事件状态流转记录
+func (rs *Routes) ListEventTransitionsHandler(w http.ResponseWriter, r *http.Request) {
+	guid := chi.URLParam(r, "guid")
+
+	response, err := rs.svc.ListEventTransitions(guid)
+	if err != nil {
+		log.Error("failed to list event transitions", "guid", guid, "err", err)
+		writeServiceError(w, err, "list_failed")
+		return
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// ResolveEventHandler 处理 POST /api/v1/events/{guid}/resolution
+// 接口 F：结算事件，标记各子事件的胜出方向
+func (rs *Routes) ResolveEventHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== ResolveEvent Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	var req models.ResolveEventRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error("failed to decode request body", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to parse request body: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	req.GUID = chi.URLParam(r, "guid")
+	req.Actor = OperatorFromContext(r.Context())
+
+	response, err := rs.svc.ResolveEvent(&req)
+	if err != nil {
+		log.Error("failed to resolve event", "guid", req.GUID, "re_resolve", req.ReResolve, "err", err)
+		writeServiceError(w, err, "resolve_failed")
+		return
+	}
+
+	log.Info("ResolveEvent succeeded",
+		"event_guid", response.EventGUID,
+		"is_re_resolve", response.IsReResolve,
+		"sub_events", len(response.SubEvents),
+	)
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== ResolveEvent Request Completed ===")
+}
+
+// writeServiceError 根据 service 层返回的错误类型输出对应的 HTTP 状态码
+func writeServiceError(w http.ResponseWriter, err error, fallbackCode string) {
+	var validationErrs service.ValidationErrors
+	switch {
+	case errors.As(err, &validationErrs):
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: err.Error(),
+			Fields:  validationErrs,
+		}, http.StatusBadRequest)
+	case errors.Is(err, service.ErrInvalidRequest), errors.Is(err, service.ErrInvalidFilter), errors.Is(err, service.ErrInvalidCursor):
+		jsonResponse(w, models.ErrorResponse{Error: "invalid_request", Message: err.Error()}, http.StatusBadRequest)
+	case errors.Is(err, service.ErrUnauthorized):
+		jsonResponse(w, models.ErrorResponse{Error: "unauthorized", Message: err.Error()}, http.StatusUnauthorized)
+	case errors.Is(err, service.ErrEventNotFound), errors.Is(err, service.ErrSubEventNotFound),
+		errors.Is(err, service.ErrTeamGroupNotFound), errors.Is(err, service.ErrCategoryNotFound),
+		errors.Is(err, service.ErrEcosystemNotFound), errors.Is(err, service.ErrEventPeriodNotFound),
+		errors.Is(err, service.ErrLanguageNotFound):
+		jsonResponse(w, models.ErrorResponse{Error: "not_found", Message: err.Error()}, http.StatusNotFound)
+	case errors.Is(err, service.ErrIdempotencyKeyReused):
+		jsonResponse(w, models.ErrorResponse{Error: "idempotency_key_reused", Message: err.Error()}, http.StatusUnprocessableEntity)
+	case errors.Is(err, service.ErrEventConflict), errors.Is(err, service.ErrIllegalTransition),
+		errors.Is(err, service.ErrAlreadyResolved), errors.Is(err, service.ErrMarketClosed),
+		errors.Is(err, service.ErrScoreUpdateRejected), errors.Is(err, service.ErrTeamGroupInUse),
+		errors.Is(err, service.ErrCategoryInUse), errors.Is(err, service.ErrEcosystemInUse),
+		errors.Is(err, service.ErrEventPeriodInUse), errors.Is(err, service.ErrDefaultLanguage),
+		errors.Is(err, service.ErrEventNotDeletable):
+		jsonResponse(w, models.ErrorResponse{Error: "conflict", Message: err.Error()}, http.StatusConflict)
+	case errors.Is(err, service.ErrInsufficientBalance), errors.Is(err, service.ErrInsufficientShares),
+		errors.Is(err, service.ErrPriceLimitExceeded):
+		jsonResponse(w, models.ErrorResponse{Error: "order_rejected", Message: err.Error()}, http.StatusUnprocessableEntity)
+	case errors.Is(err, service.ErrStorageUnavailable):
+		jsonResponse(w, models.ErrorResponse{Error: "storage_unavailable", Message: err.Error()}, http.StatusServiceUnavailable)
+	default:
+		jsonResponse(w, models.ErrorResponse{Error: fallbackCode, Message: err.Error()}, http.StatusInternalServerError)
+	}
+}
+
+// parseListEventsFilters 解析事件列表的过滤和排序查询参数，格式错误时返回错误
+func parseListEventsFilters(r *http.Request, req *models.ListEventsRequest) error {
+	query := r.URL.Query()
+	req.EcosystemGUID = query.Get("ecosystem_guid")
+	req.EventPeriodGUID = query.Get("event_period_guid")
+	req.TeamGroupGUID = query.Get("team_group_guid")
+	req.SortBy = query.Get("sort_by")
+	req.Order = query.Get("order")
+
+	var err error
+	if req.IsSports, err = parseOptionalBool(query.Get("is_sports"), "is_sports"); err != nil {
+		return err
+	}
+	if req.IsOnline, err = parseOptionalBool(query.Get("is_online"), "is_online"); err != nil {
+		return err
+	}
+	if orderTypeStr := query.Get("order_type"); orderTypeStr != "" {
+		orderType, err := strconv.ParseInt(orderTypeStr, 10, 16)
+		if err != nil {
+			return fmt.Errorf("order_type must be an integer: %w", err)
+		}
+		orderTypeInt16 := int16(orderType)
+		req.OrderType = &orderTypeInt16
+	}
+	for name, target := range map[string]**int64{
+		"created_from":   &req.CreatedFrom,
+		"created_to":     &req.CreatedTo,
+		"open_time_from": &req.OpenTimeFrom,
+		"open_time_to":   &req.OpenTimeTo,
+	} {
+		if *target, err = parseOptionalUnixTime(query.Get(name), name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseOptionalBool 解析可选的布尔查询参数
+func parseOptionalBool(value, name string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a boolean: %w", name, err)
+	}
+	return &b, nil
+}
+
+// parseOptionalUnixTime 解析可选的 Unix 时间戳查询参数
+func parseOptionalUnixTime(value, name string) (*int64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be a unix timestamp: %w", name, err)
+	}
+	return &i, nil
+}
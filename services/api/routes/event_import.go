@@ -0,0 +1,89 @@
+package routes
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+	"github.com/multimarket-labs/event-pod-services/services/api/service"
+)
+
+const (
+	// maxImportBodyBytes 批量导入请求体的最大长度
+	maxImportBodyBytes = 10 << 20
+	// maxImportLines 单次批量导入的最大行数
+	maxImportLines = 1000
+)
+
+// ImportEventsHandler 处理 POST /api/v1/admin/events:bulk
+// 请求体为 JSONL 或 CSV（format 查询参数或 Content-Type: text/csv 指定），
+// mode=all_or_nothing|best_effort，dry_run=true 时只校验不写入
+func (rs *Routes) ImportEventsHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== ImportEvents Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"query", r.URL.RawQuery,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	query := r.URL.Query()
+	req := models.ImportEventsRequest{Mode: query.Get("mode")}
+	if dryRun := query.Get("dry_run"); dryRun != "" {
+		parsed, err := strconv.ParseBool(dryRun)
+		if err != nil {
+			jsonResponse(w, models.ErrorResponse{
+				Error:   "invalid_request",
+				Message: "dry_run must be a boolean",
+			}, http.StatusBadRequest)
+			return
+		}
+		req.DryRun = parsed
+	}
+
+	lines, err := service.ParseEventImport(http.MaxBytesReader(w, r.Body, maxImportBodyBytes), importFormat(r), maxImportLines)
+	if err != nil {
+		log.Error("failed to parse import body", "err", err)
+		writeServiceError(w, err, "import_failed")
+		return
+	}
+	req.Lines = lines
+
+	response, err := rs.svc.ImportEvents(&req)
+	if err != nil {
+		log.Error("failed to import events", "err", err)
+		writeServiceError(w, err, "import_failed")
+		return
+	}
+
+	log.Info("ImportEvents finished",
+		"mode", response.Mode,
+		"dry_run", response.DryRun,
+		"committed", response.Committed,
+		"total", response.Total,
+		"succeeded", response.Succeeded,
+		"failed", response.Failed,
+	)
+
+	// all_or_nothing 模式下有失败行时整体未导入
+	statusCode := http.StatusOK
+	if !response.DryRun && !response.Committed {
+		statusCode = http.StatusUnprocessableEntity
+	}
+	jsonResponse(w, response, statusCode)
+	log.Info("=== ImportEvents Request Completed ===")
+}
+
+// importFormat 优先使用 format 查询参数，其次根据 Content-Type 判断，默认 JSONL
+func importFormat(r *http.Request) string {
+	if format := r.URL.Query().Get("format"); format != "" {
+		return format
+	}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "text/csv" {
+		return models.ImportFormatCSV
+	}
+	return models.ImportFormatJSONL
+}
@@ -0,0 +1,183 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// CreateEventPeriodHandler 处理 POST /api/v1/admin/event-periods
+// 接口 P：创建时间标签及其各语言的名称，时间标签需属于已存在的分类
+func (rs *Routes) CreateEventPeriodHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== CreateEventPeriod Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	var req models.CreateEventPeriodRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error("failed to decode request body", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to parse request body: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	response, err := rs.svc.CreateEventPeriod(&req)
+	if err != nil {
+		log.Error("failed to create event period", "category_guid", req.CategoryGUID, "err", err)
+		writeServiceError(w, err, "creation_failed")
+		return
+	}
+
+	log.Info("CreateEventPeriod succeeded",
+		"guid", response.GUID,
+		"category_guid", response.CategoryGUID,
+	)
+
+	jsonResponse(w, response, http.StatusCreated)
+	log.Info("=== CreateEventPeriod Request Completed ===")
+}
+
+// GetEventPeriodHandler 处理 GET /api/v1/admin/event-periods/{guid}
+// 返回时间标签在全部语言下的翻译
+func (rs *Routes) GetEventPeriodHandler(w http.ResponseWriter, r *http.Request) {
+	guid := chi.URLParam(r, "guid")
+
+	response, err := rs.svc.GetEventPeriod(guid)
+	if err != nil {
+		log.Error("failed to get event period", "guid", guid, "err", err)
+		writeServiceError(w, err, "query_failed")
+		return
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// UpdateEventPeriodHandler 处理 PATCH /api/v1/admin/event-periods/{guid}
+// 只更新提供的字段；已被事件引用的时间标签修改 category_guid 返回 409
+func (rs *Routes) UpdateEventPeriodHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== UpdateEventPeriod Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	var req models.UpdateEventPeriodRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error("failed to decode request body", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to parse request body: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	req.GUID = chi.URLParam(r, "guid")
+
+	response, err := rs.svc.UpdateEventPeriod(&req)
+	if err != nil {
+		log.Error("failed to update event period", "guid", req.GUID, "err", err)
+		writeServiceError(w, err, "update_failed")
+		return
+	}
+
+	log.Info("UpdateEventPeriod succeeded",
+		"guid", response.GUID,
+		"category_guid", response.CategoryGUID,
+		"is_active", response.IsActive,
+	)
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== UpdateEventPeriod Request Completed ===")
+}
+
+// DeleteEventPeriodHandler 处理 DELETE /api/v1/admin/event-periods/{guid}
+// 仍被事件引用的时间标签返回 409
+func (rs *Routes) DeleteEventPeriodHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== DeleteEventPeriod Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	guid := chi.URLParam(r, "guid")
+	if err := rs.svc.DeleteEventPeriod(guid); err != nil {
+		log.Error("failed to delete event period", "guid", guid, "err", err)
+		writeServiceError(w, err, "delete_failed")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Info("=== DeleteEventPeriod Request Completed ===", "guid", guid)
+}
+
+// RestoreEventPeriodHandler 处理 POST /api/v1/admin/event-periods/{guid}/restore
+// 未删除的时间标签返回 404；所属分类已删除或业务编码已被使用时返回 400
+func (rs *Routes) RestoreEventPeriodHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== RestoreEventPeriod Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	guid := chi.URLParam(r, "guid")
+	response, err := rs.svc.RestoreEventPeriod(guid)
+	if err != nil {
+		log.Error("failed to restore event period", "guid", guid, "err", err)
+		writeServiceError(w, err, "restore_failed")
+		return
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== RestoreEventPeriod Request Completed ===", "guid", guid)
+}
+
+// ListEventPeriodsHandler 处理 GET /api/v1/event-periods
+// 只返回启用的时间标签，可按 category_guid 过滤
+func (rs *Routes) ListEventPeriodsHandler(w http.ResponseWriter, r *http.Request) {
+	rs.listEventPeriods(w, r, false)
+}
+
+// ListAdminEventPeriodsHandler 处理 GET /api/v1/admin/event-periods
+// 与 ListEventPeriodsHandler 相同，但包含停用的时间标签
+func (rs *Routes) ListAdminEventPeriodsHandler(w http.ResponseWriter, r *http.Request) {
+	rs.listEventPeriods(w, r, true)
+}
+
+// listEventPeriods 解析时间标签列表的查询参数并返回时间标签列表
+func (rs *Routes) listEventPeriods(w http.ResponseWriter, r *http.Request, includeInactive bool) {
+	log.Info("=== ListEventPeriods Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"query", r.URL.RawQuery,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	req := models.ListEventPeriodsRequest{
+		LanguageGUID:    LanguageGUIDFromContext(r.Context()),
+		CategoryGUID:    r.URL.Query().Get("category_guid"),
+		IncludeInactive: includeInactive,
+	}
+
+	response, err := rs.svc.ListEventPeriods(&req)
+	if err != nil {
+		log.Error("failed to list event periods", "err", err)
+		writeServiceError(w, err, "query_failed")
+		return
+	}
+
+	log.Info("ListEventPeriods succeeded",
+		"event_periods_count", len(response.EventPeriods),
+		"category_guid", req.CategoryGUID,
+		"include_inactive", req.IncludeInactive,
+	)
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== ListEventPeriods Request Completed ===")
+}
@@ -0,0 +1,117 @@
+package routes
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+type languageContextKey struct{}
+
+// LanguageGUIDFromContext 获取 LanguageMiddleware 解析出的语言 GUID
+func LanguageGUIDFromContext(ctx context.Context) string {
+	languageGUID, _ := ctx.Value(languageContextKey{}).(string)
+	return languageGUID
+}
+
+// LanguageMiddleware 解析请求语言并放入 context，供多语言接口使用
+// 优先级：language_guid 查询参数 > lang 短代码（如 lang=en）> Accept-Language 头 > 默认语言
+func (rs *Routes) LanguageMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		languageGUID := query.Get("language_guid")
+		if languageGUID == "" {
+			var tags []string
+			lang := query.Get("lang")
+			if lang != "" {
+				tags = []string{lang}
+			} else {
+				tags = parseAcceptLanguage(r.Header.Get("Accept-Language"))
+			}
+
+			guid, matched, err := rs.svc.ResolveLanguage(tags)
+			if err != nil {
+				log.Error("failed to resolve language", "lang", lang, "err", err)
+				jsonResponse(w, models.ErrorResponse{
+					Error:   "language_failed",
+					Message: err.Error(),
+				}, http.StatusInternalServerError)
+				return
+			}
+			if lang != "" && !matched {
+				jsonResponse(w, models.ErrorResponse{
+					Error:   "invalid_request",
+					Message: "unsupported language: " + lang,
+				}, http.StatusBadRequest)
+				return
+			}
+			languageGUID = guid
+		}
+
+		ctx := context.WithValue(r.Context(), languageContextKey{}, languageGUID)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// parseAcceptLanguage 解析 Accept-Language 头，按 q 值从高到低返回语言标签
+// 带地区的标签（en-US）之后紧跟其主语言（en），q=0 和 * 会被忽略
+func parseAcceptLanguage(header string) []string {
+	type weightedTag struct {
+		tag string
+		q   float64
+	}
+
+	var weighted []weightedTag
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(strings.TrimSpace(part), ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				parsed = 0
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		weighted = append(weighted, weightedTag{tag: tag, q: q})
+	}
+
+	// q 值相同时保持头中的顺序
+	sort.SliceStable(weighted, func(i, j int) bool {
+		return weighted[i].q > weighted[j].q
+	})
+
+	tags := make([]string, 0, len(weighted)*2)
+	seen := make(map[string]bool, len(weighted)*2)
+	for _, w := range weighted {
+		candidates := []string{w.tag}
+		if primary, _, ok := strings.Cut(w.tag, "-"); ok {
+			candidates = append(candidates, primary)
+		}
+		for _, candidate := range candidates {
+			if !seen[candidate] {
+				seen[candidate] = true
+				tags = append(tags, candidate)
+			}
+		}
+	}
+	return tags
+}
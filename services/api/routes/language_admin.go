@@ -0,0 +1,204 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// ListLanguagesHandler 处理 GET /api/v1/admin/languages
+// 接口 Q：返回全部语言（包含停用的语言），默认语言在前
+func (rs *Routes) ListLanguagesHandler(w http.ResponseWriter, r *http.Request) {
+	response, err := rs.svc.ListLanguages()
+	if err != nil {
+		log.Error("failed to list languages", "err", err)
+		writeServiceError(w, err, "query_failed")
+		return
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// CreateLanguageHandler 处理 POST /api/v1/admin/languages
+// is_default 为 true 时原默认语言在同一事务中取消默认
+func (rs *Routes) CreateLanguageHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== CreateLanguage Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	var req models.CreateLanguageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error("failed to decode request body", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to parse request body: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	response, err := rs.svc.CreateLanguage(&req)
+	if err != nil {
+		log.Error("failed to create language", "language_name", req.LanguageName, "err", err)
+		writeServiceError(w, err, "creation_failed")
+		return
+	}
+
+	log.Info("CreateLanguage succeeded",
+		"guid", response.GUID,
+		"language_name", response.LanguageName,
+		"is_default", response.IsDefault,
+		"is_active", response.IsActive,
+	)
+
+	jsonResponse(w, response, http.StatusCreated)
+	log.Info("=== CreateLanguage Request Completed ===")
+}
+
+// UpdateLanguageHandler 处理 PATCH /api/v1/admin/languages/{guid}
+// 只更新提供的字段；is_default 只能设为 true，停用的语言不能设为默认语言
+func (rs *Routes) UpdateLanguageHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== UpdateLanguage Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	var req models.UpdateLanguageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error("failed to decode request body", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to parse request body: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	req.GUID = chi.URLParam(r, "guid")
+
+	response, err := rs.svc.UpdateLanguage(&req)
+	if err != nil {
+		log.Error("failed to update language", "guid", req.GUID, "err", err)
+		writeServiceError(w, err, "update_failed")
+		return
+	}
+
+	log.Info("UpdateLanguage succeeded",
+		"guid", response.GUID,
+		"language_name", response.LanguageName,
+		"is_default", response.IsDefault,
+	)
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== UpdateLanguage Request Completed ===")
+}
+
+// ActivateLanguageHandler 处理 POST /api/v1/admin/languages/{guid}/activate
+// 启用语言，响应中列出仍缺少该语言翻译的事件、分类、运动队
+func (rs *Routes) ActivateLanguageHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== ActivateLanguage Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	guid := chi.URLParam(r, "guid")
+	response, err := rs.svc.ActivateLanguage(guid)
+	if err != nil {
+		log.Error("failed to activate language", "guid", guid, "err", err)
+		writeServiceError(w, err, "activation_failed")
+		return
+	}
+
+	log.Info("ActivateLanguage succeeded",
+		"guid", response.Language.GUID,
+		"language_name", response.Language.LanguageName,
+		"missing_events", response.MissingTranslations.Events.Total,
+		"missing_categories", response.MissingTranslations.Categories.Total,
+		"missing_team_groups", response.MissingTranslations.TeamGroups.Total,
+	)
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== ActivateLanguage Request Completed ===")
+}
+
+// DeactivateLanguageHandler 处理 POST /api/v1/admin/languages/{guid}/deactivate
+// 默认语言不能停用，返回 409
+func (rs *Routes) DeactivateLanguageHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== DeactivateLanguage Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	guid := chi.URLParam(r, "guid")
+	response, err := rs.svc.DeactivateLanguage(guid)
+	if err != nil {
+		log.Error("failed to deactivate language", "guid", guid, "err", err)
+		writeServiceError(w, err, "deactivation_failed")
+		return
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== DeactivateLanguage Request Completed ===", "guid", guid)
+}
+
+// DeleteLanguageHandler 处理 DELETE /api/v1/admin/languages/{guid}
+// 默认语言返回 409
+func (rs *Routes) DeleteLanguageHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== DeleteLanguage Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	guid := chi.URLParam(r, "guid")
+	if err := rs.svc.DeleteLanguage(guid); err != nil {
+		log.Error("failed to delete language", "guid", guid, "err", err)
+		writeServiceError(w, err, "delete_failed")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Info("=== DeleteLanguage Request Completed ===", "guid", guid)
+}
+
+// RestoreLanguageHandler 处理 POST /api/v1/admin/languages/{guid}/restore
+// 未删除的语言返回 404；恢复后保持删除前的启用状态
+func (rs *Routes) RestoreLanguageHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== RestoreLanguage Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	guid := chi.URLParam(r, "guid")
+	response, err := rs.svc.RestoreLanguage(guid)
+	if err != nil {
+		log.Error("failed to restore language", "guid", guid, "err", err)
+		writeServiceError(w, err, "restore_failed")
+		return
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== RestoreLanguage Request Completed ===", "guid", guid)
+}
+
+// GetMissingTranslationsHandler 处理 GET /api/v1/admin/languages/{guid}/missing-translations
+// 每类最多列出 100 个 GUID，total 为缺少翻译的记录总数
+func (rs *Routes) GetMissingTranslationsHandler(w http.ResponseWriter, r *http.Request) {
+	guid := chi.URLParam(r, "guid")
+
+	response, err := rs.svc.GetMissingTranslations(guid)
+	if err != nil {
+		log.Error("failed to get missing translations", "guid", guid, "err", err)
+		writeServiceError(w, err, "query_failed")
+		return
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
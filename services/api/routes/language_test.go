@@ -0,0 +1,35 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	cases := map[string]struct {
+		header string
+		want   []string
+	}{
+		"empty header":             {header: "", want: []string{}},
+		"single tag":               {header: "zh", want: []string{"zh"}},
+		"lower cased":              {header: "EN", want: []string{"en"}},
+		"ordered by q":             {header: "en;q=0.5, zh;q=0.9, ja", want: []string{"ja", "zh", "en"}},
+		"equal q keeps order":      {header: "fr;q=0.8, de;q=0.8", want: []string{"fr", "de"}},
+		"wildcard ignored":         {header: "*, en;q=0.5", want: []string{"en"}},
+		"zero q ignored":           {header: "en;q=0, zh", want: []string{"zh"}},
+		"malformed q ignored":      {header: "en;q=abc, zh;q=0.1", want: []string{"zh"}},
+		"other params ignored":     {header: "en;level=1;q=0.3, zh;q=0.4", want: []string{"zh", "en"}},
+		"param without value":      {header: "en;q, zh;q=0.5", want: []string{"en", "zh"}},
+		"region adds primary":      {header: "en-US", want: []string{"en-us", "en"}},
+		"primary not duplicated":   {header: "en-US, en-GB;q=0.9, en;q=0.8", want: []string{"en-us", "en", "en-gb"}},
+		"primary follows its q":    {header: "zh-CN;q=0.5, ja;q=0.7", want: []string{"ja", "zh-cn", "zh"}},
+		"empty parts skipped":      {header: " , en ,, ", want: []string{"en"}},
+		"explicit primary demoted": {header: "en;q=0.2, en-US", want: []string{"en-us", "en"}},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, parseAcceptLanguage(tc.header))
+		})
+	}
+}
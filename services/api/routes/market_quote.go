@@ -0,0 +1,56 @@
+package routes
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// QuoteSubEventTradeHandler 处理 GET /api/v1/sub-events/{guid}/quote
+// 接口 J：按 LMSR 做市商试算交易，direction_guid 为交易方向，amount 为份额（正数买入、负数卖出）
+func (rs *Routes) QuoteSubEventTradeHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== QuoteSubEventTrade Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"query", r.URL.RawQuery,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	query := r.URL.Query()
+	amount, err := strconv.ParseFloat(query.Get("amount"), 64)
+	if err != nil {
+		log.Error("invalid quote amount", "amount", query.Get("amount"), "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "amount must be a number",
+		}, http.StatusBadRequest)
+		return
+	}
+
+	req := models.MarketQuoteRequest{
+		SubEventGUID:  chi.URLParam(r, "guid"),
+		DirectionGUID: query.Get("direction_guid"),
+		Amount:        amount,
+	}
+
+	response, err := rs.svc.QuoteSubEventTrade(&req)
+	if err != nil {
+		log.Error("failed to quote sub event trade", "sub_event_guid", req.SubEventGUID, "direction_guid", req.DirectionGUID, "err", err)
+		writeServiceError(w, err, "quote_failed")
+		return
+	}
+
+	log.Info("QuoteSubEventTrade succeeded",
+		"sub_event_guid", response.SubEventGUID,
+		"direction_guid", response.DirectionGUID,
+		"amount", response.Amount,
+		"cost", response.Cost,
+	)
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== QuoteSubEventTrade Request Completed ===")
+}
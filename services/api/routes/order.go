@@ -0,0 +1,176 @@
+package routes
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// PlaceOrderHandler 处理 POST /api/v1/orders
+// 接口 K：按做市商当前价格买入或卖出方向份额，支持 Idempotency-Key 请求头；需要 WalletAuth，用户地址取自钱包令牌
+func (rs *Routes) PlaceOrderHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== PlaceOrder Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	var req models.PlaceOrderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error("failed to decode request body", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to parse request body: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	req.UserAddress = WalletAddressFromContext(r.Context())
+	req.IdempotencyKey = r.Header.Get("Idempotency-Key")
+
+	log.Info("PlaceOrder request summary",
+		"user_address", req.UserAddress,
+		"sub_event_guid", req.SubEventGUID,
+		"direction_guid", req.DirectionGUID,
+		"side", req.Side,
+		"shares", req.Shares,
+		"idempotency_key", req.IdempotencyKey,
+	)
+
+	response, err := rs.svc.PlaceOrder(&req)
+	if err != nil {
+		log.Error("failed to place order", "user_address", req.UserAddress, "direction_guid", req.DirectionGUID, "err", err)
+		writeServiceError(w, err, "order_failed")
+		return
+	}
+
+	log.Info("PlaceOrder succeeded",
+		"order_guid", response.Order.GUID,
+		"amount", response.Order.Amount,
+		"average_price", response.Order.AveragePrice,
+		"replayed", response.Replayed,
+	)
+
+	if response.Replayed {
+		w.Header().Set("Idempotent-Replayed", "true")
+	}
+
+	jsonResponse(w, response, http.StatusCreated)
+	log.Info("=== PlaceOrder Request Completed ===")
+}
+
+// ListOrdersHandler 处理 GET /api/v1/users/{address}/orders
+func (rs *Routes) ListOrdersHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== ListOrders Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"query", r.URL.RawQuery,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	req := models.ListOrdersRequest{
+		UserAddress: chi.URLParam(r, "address"),
+		Page:        1,
+		Limit:       20,
+	}
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil {
+			req.Page = page
+		}
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil {
+			req.Limit = limit
+		}
+	}
+
+	response, err := rs.svc.ListOrders(&req)
+	if err != nil {
+		log.Error("failed to list orders", "user_address", req.UserAddress, "err", err)
+		writeServiceError(w, err, "query_failed")
+		return
+	}
+
+	log.Info("ListOrders succeeded",
+		"user_address", req.UserAddress,
+		"orders_count", len(response.Orders),
+		"total", response.Pagination.Total,
+	)
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== ListOrders Request Completed ===")
+}
+
+// ListPositionsHandler 处理 GET /api/v1/users/{address}/positions
+// 可选查询参数 status：open 或 settled
+func (rs *Routes) ListPositionsHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== ListPositions Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"query", r.URL.RawQuery,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	req := models.ListPositionsRequest{
+		UserAddress: chi.URLParam(r, "address"),
+		Status:      r.URL.Query().Get("status"),
+	}
+
+	response, err := rs.svc.ListPositions(&req)
+	if err != nil {
+		log.Error("failed to list positions", "user_address", req.UserAddress, "err", err)
+		writeServiceError(w, err, "query_failed")
+		return
+	}
+
+	log.Info("ListPositions succeeded",
+		"user_address", response.UserAddress,
+		"positions_count", len(response.Positions),
+	)
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== ListPositions Request Completed ===")
+}
+
+// CreditBalanceHandler 处理 POST /api/v1/admin/balances:credit
+func (rs *Routes) CreditBalanceHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== CreditBalance Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	var req models.CreditBalanceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error("failed to decode request body", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to parse request body: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	req.Operator = OperatorFromContext(r.Context())
+
+	response, err := rs.svc.CreditBalance(&req)
+	if err != nil {
+		log.Error("failed to credit balance", "user_address", req.UserAddress, "err", err)
+		writeServiceError(w, err, "credit_failed")
+		return
+	}
+
+	log.Info("CreditBalance succeeded",
+		"credit_guid", response.CreditGUID,
+		"user_address", response.UserAddress,
+		"amount", response.Amount,
+		"operator", response.Operator,
+		"available", response.Available,
+	)
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== CreditBalance Request Completed ===")
+}
@@ -1,84 +1,174 @@
 package routes
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/go-chi/chi/v5"
 	"github.com/golang-jwt/jwt/v4"
 
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
 	"github.com/multimarket-labs/event-pod-services/services/api/service"
 )
 
 var (
-	jwtSecret  = []byte("my_token_secret_123456") // todo: replace more security token
 	CapitalKey = "%s:capital"
 )
 
+// Claims 后台操作人令牌的声明，BusinessId 为操作人标识
 type Claims struct {
 	BusinessId string `json:"business_id"`
 	jwt.RegisteredClaims
 }
 
+type operatorContextKey struct{}
+
+// OperatorFromContext 获取 JWTAuth 校验通过的后台操作人
+func OperatorFromContext(ctx context.Context) string {
+	operator, _ := ctx.Value(operatorContextKey{}).(string)
+	return operator
+}
+
 type Routes struct {
-	router *chi.Mux
-	svc    service.Service
+	router         *chi.Mux
+	svc            service.Service
+	jwtSecret      []byte
+	scoreFeedToken string
 }
 
 // NewRoutes ... Construct a new route handler instance
-func NewRoutes(r *chi.Mux, svc service.Service) Routes {
+// jwtSecret signs operator tokens for admin and mutating routes; an empty secret rejects them all
+// scoreFeedToken is the bearer token score feeds must present; an empty token disables the feed
+func NewRoutes(r *chi.Mux, svc service.Service, jwtSecret, scoreFeedToken string) Routes {
 	rs := Routes{
-		router: r,
-		svc:    svc,
+		router:         r,
+		svc:            svc,
+		jwtSecret:      []byte(jwtSecret),
+		scoreFeedToken: scoreFeedToken,
 	}
 
-	// Register predict event route (Dify integration)
-	r.Post("/api/v1/admin/predict-event", rs.PredictEventHandler)
+	// Admin and mutating routes: authenticated with an operator token
+	r.Group(func(r chi.Router) {
+		r.Use(rs.JWTAuth)
+		r.Post("/api/v1/admin/predict-event", rs.PredictEventHandler)
+		r.Post("/api/v1/admin/events:bulk", rs.ImportEventsHandler)
+		r.Post("/api/v1/admin/events", rs.CreateAdminEventHandler)
+		r.Delete("/api/v1/admin/events/{guid}", rs.DeleteEventHandler)
+		r.Post("/api/v1/admin/events/{guid}/restore", rs.RestoreEventHandler)
+		r.Post("/api/v1/admin/balances:credit", rs.CreditBalanceHandler)
+		r.Post("/api/v1/admin/team-groups", rs.CreateTeamGroupHandler)
+		r.Get("/api/v1/admin/team-groups/{guid}", rs.GetTeamGroupHandler)
+		r.Patch("/api/v1/admin/team-groups/{guid}", rs.UpdateTeamGroupHandler)
+		r.Delete("/api/v1/admin/team-groups/{guid}", rs.DeleteTeamGroupHandler)
+		r.Post("/api/v1/admin/team-groups/{guid}/restore", rs.RestoreTeamGroupHandler)
+		r.Post("/api/v1/admin/team-groups/{guid}/logo", rs.UploadTeamGroupLogoHandler)
+		r.Post("/api/v1/admin/categories", rs.CreateCategoryHandler)
+		r.Post("/api/v1/admin/categories:reorder", rs.ReorderCategoriesHandler)
+		r.Get("/api/v1/admin/categories/{guid}", rs.GetCategoryHandler)
+		r.Patch("/api/v1/admin/categories/{guid}", rs.UpdateCategoryHandler)
+		r.Delete("/api/v1/admin/categories/{guid}", rs.DeleteCategoryHandler)
+		r.Post("/api/v1/admin/categories/{guid}/restore", rs.RestoreCategoryHandler)
+		r.Post("/api/v1/admin/ecosystems", rs.CreateEcosystemHandler)
+		r.Get("/api/v1/admin/ecosystems/{guid}", rs.GetEcosystemHandler)
+		r.Patch("/api/v1/admin/ecosystems/{guid}", rs.UpdateEcosystemHandler)
+		r.Delete("/api/v1/admin/ecosystems/{guid}", rs.DeleteEcosystemHandler)
+		r.Post("/api/v1/admin/ecosystems/{guid}/restore", rs.RestoreEcosystemHandler)
+		r.Post("/api/v1/admin/event-periods", rs.CreateEventPeriodHandler)
+		r.Get("/api/v1/admin/event-periods/{guid}", rs.GetEventPeriodHandler)
+		r.Patch("/api/v1/admin/event-periods/{guid}", rs.UpdateEventPeriodHandler)
+		r.Delete("/api/v1/admin/event-periods/{guid}", rs.DeleteEventPeriodHandler)
+		r.Post("/api/v1/admin/event-periods/{guid}/restore", rs.RestoreEventPeriodHandler)
+		r.Get("/api/v1/admin/languages", rs.ListLanguagesHandler)
+		r.Post("/api/v1/admin/languages", rs.CreateLanguageHandler)
+		r.Patch("/api/v1/admin/languages/{guid}", rs.UpdateLanguageHandler)
+		r.Delete("/api/v1/admin/languages/{guid}", rs.DeleteLanguageHandler)
+		r.Post("/api/v1/admin/languages/{guid}/restore", rs.RestoreLanguageHandler)
+		r.Post("/api/v1/admin/languages/{guid}/activate", rs.ActivateLanguageHandler)
+		r.Post("/api/v1/admin/languages/{guid}/deactivate", rs.DeactivateLanguageHandler)
+		r.Get("/api/v1/admin/languages/{guid}/missing-translations", rs.GetMissingTranslationsHandler)
+
+		// Event write routes
+		r.Post("/api/v1/events", rs.CreateEventHandler)
+		r.Put("/api/v1/events/{guid}", rs.UpdateEventHandler)
+		r.Patch("/api/v1/events/{guid}", rs.UpdateEventHandler)
+		r.Post("/api/v1/events/{guid}/transitions", rs.TransitionEventHandler)
+		r.Post("/api/v1/events/{guid}/resolution", rs.ResolveEventHandler)
+
+		// Localized admin routes: language resolved by LanguageMiddleware
+		r.Group(func(r chi.Router) {
+			r.Use(rs.LanguageMiddleware)
+			r.Get("/api/v1/admin/categories", rs.ListAdminCategoriesHandler)
+			r.Get("/api/v1/admin/ecosystems", rs.ListAdminEcosystemsHandler)
+			r.Get("/api/v1/admin/event-periods", rs.ListAdminEventPeriodsHandler)
+		})
+	})
 
-	// Register event routes
-	r.Post("/api/v1/events", rs.CreateEventHandler)
-	r.Get("/api/v1/events", rs.ListEventsHandler)
+	// Register public event routes
+	r.Get("/api/v1/events/{guid}/transitions", rs.ListEventTransitionsHandler)
+	r.Get("/api/v1/sub-events/{guid}/chance-history", rs.GetChanceHistoryHandler)
+	r.Get("/api/v1/sub-events/{guid}/quote", rs.QuoteSubEventTradeHandler)
+	r.Get("/api/v1/events/{guid}/scores", rs.ListEventScoreHistoryHandler)
+
+	// Score feed routes: authenticated with the score feed token
+	r.With(rs.ScoreFeedAuth).Post("/api/v1/feeds/events/{guid}/score", rs.UpdateEventScoreHandler)
+
+	// Wallet login routes
+	r.Post("/api/v1/auth/nonce", rs.WalletNonceHandler)
+	r.Post("/api/v1/auth/login", rs.WalletLoginHandler)
+
+	// Register order routes: orders are placed for the wallet in the wallet token
+	r.With(rs.WalletAuth).Post("/api/v1/orders", rs.PlaceOrderHandler)
+	r.Get("/api/v1/users/{address}/orders", rs.ListOrdersHandler)
+	r.Get("/api/v1/users/{address}/positions", rs.ListPositionsHandler)
+
+	// Localized routes: language resolved by LanguageMiddleware
+	r.Group(func(r chi.Router) {
+		r.Use(rs.LanguageMiddleware)
+		r.Get("/api/v1/events", rs.ListEventsHandler)
+		r.Get("/api/v1/events/{guid}", rs.GetEventDetailHandler)
+		r.Get("/api/v1/team-groups", rs.ListTeamGroupsHandler)
+		r.Get("/api/v1/categories", rs.ListCategoriesHandler)
+		r.Get("/api/v1/ecosystems", rs.ListEcosystemsHandler)
+		r.Get("/api/v1/event-periods", rs.ListEventPeriodsHandler)
+	})
 
 	return rs
 }
 
-func (rs *Routes) validateTokenStr(uid string, tokenRaw string) bool {
-	return false
-}
-
+// JWTAuth 校验后台操作人的 Authorization: Bearer <token>
+// 令牌以 jwt_secret 做 HMAC 签名，必须带有过期时间和 business_id；business_id 作为操作人放入 context
+// 未配置 jwt_secret 时拒绝所有请求
 func (rs *Routes) JWTAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		authHeader := r.Header.Get("Authorization")
-		if authHeader == "" {
-			http.Error(w, "missing Authorization header", http.StatusUnauthorized)
+		if len(rs.jwtSecret) == 0 {
+			log.Warn("jwt secret is not configured, rejecting request", "path", r.URL.Path)
+			jsonResponse(w, models.ErrorResponse{Error: "unauthorized", Message: "admin authentication is not enabled"}, http.StatusUnauthorized)
 			return
 		}
 
-		parts := strings.SplitN(authHeader, " ", 2)
+		parts := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
 		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" {
-			http.Error(w, "invalid Authorization header", http.StatusUnauthorized)
+			jsonResponse(w, models.ErrorResponse{Error: "unauthorized", Message: "missing or invalid Authorization header"}, http.StatusUnauthorized)
 			return
 		}
-		tokenStr := parts[1]
 
 		claims := &Claims{}
-		token, err := jwt.ParseWithClaims(tokenStr, claims, func(token *jwt.Token) (interface{}, error) {
+		token, err := jwt.ParseWithClaims(parts[1], claims, func(token *jwt.Token) (interface{}, error) {
 			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 				return nil, fmt.Errorf("unexpected signing method")
 			}
-			return jwtSecret, nil
+			return rs.jwtSecret, nil
 		})
-
-		if err != nil || !token.Valid {
-			http.Error(w, "invalid or expired token", http.StatusUnauthorized)
+		if err != nil || !token.Valid || claims.ExpiresAt == nil || claims.BusinessId == "" {
+			log.Warn("invalid operator token", "path", r.URL.Path, "remote_addr", r.RemoteAddr, "err", err)
+			jsonResponse(w, models.ErrorResponse{Error: "unauthorized", Message: "invalid or expired token"}, http.StatusUnauthorized)
 			return
 		}
 
-		if !rs.validateTokenStr(claims.BusinessId, tokenStr) {
-			http.Error(w, "invalid or expired token,not same", http.StatusUnauthorized)
-			return
-		}
-		return
+		ctx := context.WithValue(r.Context(), operatorContextKey{}, claims.BusinessId)
+		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -0,0 +1,89 @@
+package routes
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// ScoreFeedAuth 校验比分源的 Authorization: Bearer <score_feed_token>
+// 未配置 score_feed_token 时拒绝所有推送
+func (rs *Routes) ScoreFeedAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rs.scoreFeedToken == "" {
+			log.Warn("score feed token is not configured, rejecting request", "path", r.URL.Path)
+			jsonResponse(w, models.ErrorResponse{Error: "unauthorized", Message: "score feed is not enabled"}, http.StatusUnauthorized)
+			return
+		}
+
+		parts := strings.SplitN(r.Header.Get("Authorization"), " ", 2)
+		if len(parts) != 2 || strings.ToLower(parts[0]) != "bearer" ||
+			subtle.ConstantTimeCompare([]byte(parts[1]), []byte(rs.scoreFeedToken)) != 1 {
+			log.Warn("invalid score feed token", "path", r.URL.Path, "remote_addr", r.RemoteAddr)
+			jsonResponse(w, models.ErrorResponse{Error: "unauthorized", Message: "invalid or missing score feed token"}, http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// UpdateEventScoreHandler 处理 POST /api/v1/feeds/events/{guid}/score
+// 接口 L：比分源推送运动类事件的比分和比赛阶段，需要 ScoreFeedAuth
+func (rs *Routes) UpdateEventScoreHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== UpdateEventScore Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	var req models.UpdateEventScoreRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error("failed to decode request body", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to parse request body: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	req.GUID = chi.URLParam(r, "guid")
+
+	response, err := rs.svc.UpdateEventScore(&req)
+	if err != nil {
+		log.Error("failed to update event score", "guid", req.GUID, "source", req.Source, "err", err)
+		writeServiceError(w, err, "update_failed")
+		return
+	}
+
+	log.Info("UpdateEventScore succeeded",
+		"guid", response.EventGUID,
+		"main_score", response.MainScore,
+		"cluster_score", response.ClusterScore,
+		"stage", response.Stage,
+		"changed", response.Change != nil,
+	)
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== UpdateEventScore Request Completed ===")
+}
+
+// ListEventScoreHistoryHandler 处理 GET /api/v1/events/{guid}/scores
+// 查询事件的比分变更记录
+func (rs *Routes) ListEventScoreHistoryHandler(w http.ResponseWriter, r *http.Request) {
+	guid := chi.URLParam(r, "guid")
+
+	response, err := rs.svc.ListEventScoreHistory(guid)
+	if err != nil {
+		log.Error("failed to list event score history", "guid", guid, "err", err)
+		writeServiceError(w, err, "list_failed")
+		return
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
@@ -0,0 +1,231 @@
+package routes
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/go-chi/chi/v5"
+
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+const (
+	// maxLogoUploadBytes 上传 Logo 请求体的最大长度（包含 multipart 开销），文件本身的限制由 service 校验
+	maxLogoUploadBytes = 3 << 20
+)
+
+// CreateTeamGroupHandler 处理 POST /api/v1/admin/team-groups
+// 接口 M：创建运动队及其各语言的名称
+func (rs *Routes) CreateTeamGroupHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== CreateTeamGroup Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	var req models.CreateTeamGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error("failed to decode request body", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to parse request body: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	response, err := rs.svc.CreateTeamGroup(&req)
+	if err != nil {
+		log.Error("failed to create team group", "err", err)
+		writeServiceError(w, err, "creation_failed")
+		return
+	}
+
+	log.Info("CreateTeamGroup succeeded", "guid", response.GUID, "names_count", len(response.Names))
+
+	jsonResponse(w, response, http.StatusCreated)
+	log.Info("=== CreateTeamGroup Request Completed ===")
+}
+
+// GetTeamGroupHandler 处理 GET /api/v1/admin/team-groups/{guid}
+// 返回运动队在全部语言下的名称
+func (rs *Routes) GetTeamGroupHandler(w http.ResponseWriter, r *http.Request) {
+	guid := chi.URLParam(r, "guid")
+
+	response, err := rs.svc.GetTeamGroup(guid)
+	if err != nil {
+		log.Error("failed to get team group", "guid", guid, "err", err)
+		writeServiceError(w, err, "query_failed")
+		return
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+}
+
+// UpdateTeamGroupHandler 处理 PATCH /api/v1/admin/team-groups/{guid}
+// 只更新提供的字段，names 中值为空字符串的语言会被删除
+func (rs *Routes) UpdateTeamGroupHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== UpdateTeamGroup Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	var req models.UpdateTeamGroupRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		log.Error("failed to decode request body", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to parse request body: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	req.GUID = chi.URLParam(r, "guid")
+
+	response, err := rs.svc.UpdateTeamGroup(&req)
+	if err != nil {
+		log.Error("failed to update team group", "guid", req.GUID, "err", err)
+		writeServiceError(w, err, "update_failed")
+		return
+	}
+
+	log.Info("UpdateTeamGroup succeeded", "guid", response.GUID, "names_count", len(response.Names))
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== UpdateTeamGroup Request Completed ===")
+}
+
+// DeleteTeamGroupHandler 处理 DELETE /api/v1/admin/team-groups/{guid}
+// 仍被事件引用的运动队返回 409
+func (rs *Routes) DeleteTeamGroupHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== DeleteTeamGroup Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	guid := chi.URLParam(r, "guid")
+	if err := rs.svc.DeleteTeamGroup(guid); err != nil {
+		log.Error("failed to delete team group", "guid", guid, "err", err)
+		writeServiceError(w, err, "delete_failed")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	log.Info("=== DeleteTeamGroup Request Completed ===", "guid", guid)
+}
+
+// RestoreTeamGroupHandler 处理 POST /api/v1/admin/team-groups/{guid}/restore
+// 未删除的运动队返回 404
+func (rs *Routes) RestoreTeamGroupHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== RestoreTeamGroup Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	guid := chi.URLParam(r, "guid")
+	response, err := rs.svc.RestoreTeamGroup(guid)
+	if err != nil {
+		log.Error("failed to restore team group", "guid", guid, "err", err)
+		writeServiceError(w, err, "restore_failed")
+		return
+	}
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== RestoreTeamGroup Request Completed ===", "guid", guid)
+}
+
+// UploadTeamGroupLogoHandler 处理 POST /api/v1/admin/team-groups/{guid}/logo
+// multipart/form-data，文件放在 file 字段，上传成功后更新运动队的 logo
+func (rs *Routes) UploadTeamGroupLogoHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== UploadTeamGroupLogo Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxLogoUploadBytes)
+	file, header, err := r.FormFile("file")
+	if err != nil {
+		log.Error("failed to read uploaded file", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to read file field: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		log.Error("failed to read uploaded file", "err", err)
+		jsonResponse(w, models.ErrorResponse{
+			Error:   "invalid_request",
+			Message: "Failed to read file field: " + err.Error(),
+		}, http.StatusBadRequest)
+		return
+	}
+
+	req := models.UploadTeamGroupLogoRequest{
+		GUID: chi.URLParam(r, "guid"),
+		Data: data,
+	}
+
+	response, err := rs.svc.UploadTeamGroupLogo(r.Context(), &req)
+	if err != nil {
+		log.Error("failed to upload team group logo", "guid", req.GUID, "filename", header.Filename, "err", err)
+		writeServiceError(w, err, "upload_failed")
+		return
+	}
+
+	log.Info("UploadTeamGroupLogo succeeded", "guid", response.GUID, "logo", response.Logo, "size", len(data))
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== UploadTeamGroupLogo Request Completed ===")
+}
+
+// ListTeamGroupsHandler 处理 GET /api/v1/team-groups
+// 查询参数：q（按名称搜索）、page、limit，名称按 LanguageMiddleware 解析的语言返回
+func (rs *Routes) ListTeamGroupsHandler(w http.ResponseWriter, r *http.Request) {
+	log.Info("=== ListTeamGroups Request Started ===",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"query", r.URL.RawQuery,
+		"remote_addr", r.RemoteAddr,
+	)
+
+	req := models.ListTeamGroupsRequest{
+		LanguageGUID: LanguageGUIDFromContext(r.Context()),
+		Query:        r.URL.Query().Get("q"),
+		Page:         1,
+		Limit:        20,
+	}
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil {
+			req.Page = page
+		}
+	}
+	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
+		if limit, err := strconv.Atoi(limitStr); err == nil {
+			req.Limit = limit
+		}
+	}
+
+	response, err := rs.svc.ListTeamGroups(&req)
+	if err != nil {
+		log.Error("failed to list team groups", "query", req.Query, "err", err)
+		writeServiceError(w, err, "query_failed")
+		return
+	}
+
+	log.Info("ListTeamGroups succeeded",
+		"team_groups_count", len(response.TeamGroups),
+		"total", response.Pagination.Total,
+	)
+
+	jsonResponse(w, response, http.StatusOK)
+	log.Info("=== ListTeamGroups Request Completed ===")
+}
@@ -0,0 +1,316 @@
+package service
+
+import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/database"
+	"github.com/multimarket-labs/event-pod-services/pricing"
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// hexColorPattern 结果颜色格式，例如 #0000FF 或 #00F
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// CreateAdminEvent 按 docs/API_CREATE_EVENT.md 的格式创建事件
+// 映射关系：
+// 1. title/description 以默认语言写入 event_language 的 title/rules，image_url 写入 event.logo
+// 2. start_date 写入 event.open_at/start_at（及 open_time），end_date 写入 event.close_at
+// 3. tags 写入 tag 表（同名标签复用）并通过 event_tag 关联事件
+// 4. question 写入 sub_event.title 及默认语言的 sub_event_language
+// 5. outcomes 按 idx 升序写入 sub_event_direction，color/idx 保存在方向的 info 中，概率平均分配
+// 提供分类、生态和时间标签时与 CreateEvent 一样校验引用并累加生态的事件数；所有写入在同一个事务中完成
+func (h *HandlerSvc) CreateAdminEvent(req *models.AdminCreateEventRequest) (*models.AdminEventResponse, error) {
+	if err := validateAdminCreateEventRequest(req); err != nil {
+		return nil, err
+	}
+
+	var response *models.AdminEventResponse
+	repo := database.NewEventRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		defaultLanguage, err := database.NewLanguageRepository().GetDefaultLanguage(db)
+		if err != nil {
+			return err
+		}
+		if defaultLanguage == nil {
+			return fmt.Errorf("no default language is configured")
+		}
+
+		created, err := createAdminEvent(db, repo, defaultLanguage.GUID, req)
+		if err != nil {
+			return err
+		}
+		response = created
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// createAdminEvent 在事务中插入 event、event_language、标签、sub_event 及方向
+func createAdminEvent(db *gorm.DB, repo database.EventRepository, languageGUID string, req *models.AdminCreateEventRequest) (*models.AdminEventResponse, error) {
+	if req.CategoryGUID != "" {
+		// 与创建事件一致，先对生态加排他锁再校验引用，校验通过后才累加事件数
+		ecosystemRepo := database.NewEcosystemRepository()
+		if _, err := ecosystemRepo.LockEcosystem(db, req.EcosystemGUID); err != nil {
+			return nil, err
+		}
+		var errs ValidationErrors
+		if err := validateEventTaxonomyReferences(db, &errs, req.CategoryGUID, req.EcosystemGUID, req.EventPeriodGUID); err != nil {
+			return nil, err
+		}
+		if err := errs.err(); err != nil {
+			return nil, err
+		}
+		if err := ecosystemRepo.AdjustEcosystemEventNum(db, req.EcosystemGUID, 1); err != nil {
+			return nil, err
+		}
+	}
+
+	startAt := unixToTime(&req.StartDate)
+	event := &database.Event{
+		CategoryGUID:     req.CategoryGUID,
+		EcosystemGUID:    req.EcosystemGUID,
+		EventPeriodGUID:  req.EventPeriodGUID,
+		MainScore:        "0",
+		ClusterScore:     "0",
+		Logo:             req.ImageURL,
+		OrderNum:         "0",
+		OpenTime:         openTimeString(startAt),
+		OpenAt:           startAt,
+		StartAt:          startAt,
+		CloseAt:          unixToTime(&req.EndDate),
+		ExperimentResult: "",
+		Info:             database.JSONB{},
+		IsLive:           1, // 默认为未来事件
+		IsSports:         false,
+		Stage:            "Q1",
+		Status:           database.EventStatusDraft,
+	}
+	if err := repo.CreateEvent(db, event); err != nil {
+		return nil, fmt.Errorf("failed to create event: %w", err)
+	}
+
+	eventLang := &database.EventLanguage{
+		EventGUID:    event.GUID,
+		LanguageGUID: languageGUID,
+		Title:        req.Title,
+		Rules:        req.Description,
+	}
+	if err := repo.CreateEventLanguage(db, eventLang); err != nil {
+		return nil, fmt.Errorf("failed to create event language: %w", err)
+	}
+
+	response := &models.AdminEventResponse{
+		GUID:        event.GUID,
+		Title:       req.Title,
+		Description: req.Description,
+		ImageURL:    req.ImageURL,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		Tags:        make([]models.AdminTagResponse, 0, len(req.Tags)),
+		SubEvents:   make([]models.AdminSubEventResponse, 0, len(req.SubEvents)),
+		Created:     event.CreatedAt.Unix(),
+		Updated:     event.UpdatedAt.Unix(),
+	}
+
+	tagRepo := database.NewTagRepository()
+	tags, err := tagRepo.EnsureTags(db, req.Tags)
+	if err != nil {
+		return nil, err
+	}
+	tagGUIDs := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tagGUIDs = append(tagGUIDs, tag.GUID)
+		response.Tags = append(response.Tags, models.AdminTagResponse{
+			GUID:    tag.GUID,
+			Name:    tag.Name,
+			Created: tag.CreatedAt.Unix(),
+			Updated: tag.UpdatedAt.Unix(),
+		})
+	}
+	if err := tagRepo.CreateEventTags(db, event.GUID, tagGUIDs); err != nil {
+		return nil, err
+	}
+
+	for _, subReq := range req.SubEvents {
+		subEvent := &database.SubEvent{
+			ParentEventGUID: event.GUID,
+			Title:           subReq.Question,
+			Logo:            req.ImageURL,
+			Liquidity:       pricing.DefaultLiquidity,
+		}
+		if err := repo.CreateSubEvent(db, subEvent); err != nil {
+			return nil, fmt.Errorf("failed to create sub event: %w", err)
+		}
+
+		subEventLang := &database.SubEventLanguage{
+			SubEventGUID: subEvent.GUID,
+			LanguageGUID: languageGUID,
+			Title:        subReq.Question,
+		}
+		if err := repo.CreateSubEventLanguage(db, subEventLang); err != nil {
+			return nil, fmt.Errorf("failed to create sub event language: %w", err)
+		}
+
+		subResponse := models.AdminSubEventResponse{
+			GUID:      subEvent.GUID,
+			EventGUID: event.GUID,
+			Question:  subEvent.Title,
+			Outcomes:  make([]models.AdminOutcomeResponse, 0, len(subReq.Outcomes)),
+			Created:   subEvent.CreatedAt.Unix(),
+			Updated:   subEvent.UpdatedAt.Unix(),
+		}
+
+		// 各结果初始概率均等，由做市商定价
+		chances := splitChance(len(subReq.Outcomes))
+		directions := make([]database.SubEventDirection, 0, len(subReq.Outcomes))
+		for i, outcome := range subReq.Outcomes {
+			directions = append(directions, database.SubEventDirection{
+				Direction: outcome.Name,
+				Chance:    chances[i],
+				Info: database.JSONB{
+					"color": outcome.Color,
+					"idx":   outcome.Idx,
+				},
+			})
+		}
+		directions, err := createSubEventDirections(db, repo, subEvent, directions)
+		if err != nil {
+			return nil, err
+		}
+
+		for i, outcome := range subReq.Outcomes {
+			direction := directions[i]
+			subResponse.Outcomes = append(subResponse.Outcomes, models.AdminOutcomeResponse{
+				GUID:         direction.GUID,
+				SubEventGUID: subEvent.GUID,
+				Name:         direction.Direction,
+				Color:        outcome.Color,
+				Idx:          outcome.Idx,
+				Created:      direction.CreatedAt.Unix(),
+				Updated:      direction.UpdatedAt.Unix(),
+			})
+		}
+		response.SubEvents = append(response.SubEvents, subResponse)
+	}
+
+	return response, nil
+}
+
+// splitChance 将 100 平均分配给 n 个方向，余数依次分给前面的方向
+func splitChance(n int) []int16 {
+	chances := make([]int16, n)
+	for i := range chances {
+		chances[i] = int16(100 / n)
+		if i < 100%n {
+			chances[i]++
+		}
+	}
+	return chances
+}
+
+// validateAdminCreateEventRequest 校验后台创建事件请求，并规范化标签（去空白、去重）和结果顺序（按 idx 升序）
+func validateAdminCreateEventRequest(req *models.AdminCreateEventRequest) error {
+	var errs ValidationErrors
+
+	req.Title = strings.TrimSpace(req.Title)
+	if req.Title == "" {
+		errs.add("title", "cannot be empty")
+	} else if utf8.RuneCountInString(req.Title) > 200 {
+		errs.add("title", "must be at most 200 characters")
+	}
+	if utf8.RuneCountInString(req.ImageURL) > 300 {
+		errs.add("image_url", "must be at most 300 characters")
+	}
+	if req.StartDate <= 0 {
+		errs.add("start_date", "is required")
+	}
+	if req.EndDate <= 0 {
+		errs.add("end_date", "is required")
+	}
+	if req.StartDate > 0 && req.EndDate > 0 && req.StartDate >= req.EndDate {
+		errs.add("end_date", "must be later than start_date")
+	}
+
+	tags := make([]string, 0, len(req.Tags))
+	for i, tag := range req.Tags {
+		tag = strings.TrimSpace(tag)
+		if utf8.RuneCountInString(tag) > 100 {
+			errs.add(fmt.Sprintf("tags[%d]", i), "must be at most 100 characters")
+		}
+		if tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	}
+	req.Tags = tags
+
+	// 分类、生态和时间标签要么都提供，要么都不提供（创建不关联分类的草稿）
+	references := map[string]string{
+		"category_guid":     req.CategoryGUID,
+		"ecosystem_guid":    req.EcosystemGUID,
+		"event_period_guid": req.EventPeriodGUID,
+	}
+	if req.CategoryGUID != "" || req.EcosystemGUID != "" || req.EventPeriodGUID != "" {
+		for _, field := range slices.Sorted(maps.Keys(references)) {
+			if references[field] == "" {
+				errs.add(field, "is required when category_guid, ecosystem_guid or event_period_guid is provided")
+			}
+		}
+	}
+
+	if len(req.SubEvents) == 0 {
+		errs.add("sub_events", "at least one sub_event is required")
+	}
+	for i := range req.SubEvents {
+		subReq := &req.SubEvents[i]
+		field := fmt.Sprintf("sub_events[%d]", i)
+
+		subReq.Question = strings.TrimSpace(subReq.Question)
+		if subReq.Question == "" {
+			errs.add(field+".question", "cannot be empty")
+		} else if utf8.RuneCountInString(subReq.Question) > 200 {
+			errs.add(field+".question", "must be at most 200 characters")
+		}
+		if len(subReq.Outcomes) < 2 {
+			errs.add(field+".outcomes", "must have at least 2 outcomes")
+		}
+
+		seen := make(map[int]bool, len(subReq.Outcomes))
+		for j, outcome := range subReq.Outcomes {
+			outcomeField := fmt.Sprintf("%s.outcomes[%d]", field, j)
+			if strings.TrimSpace(outcome.Name) == "" {
+				errs.add(outcomeField+".name", "cannot be empty")
+			} else if utf8.RuneCountInString(outcome.Name) > 200 {
+				errs.add(outcomeField+".name", "must be at most 200 characters")
+			}
+			if !hexColorPattern.MatchString(outcome.Color) {
+				errs.add(outcomeField+".color", "must be a hex color such as #0000FF")
+			}
+			if outcome.Idx < 0 {
+				errs.add(outcomeField+".idx", "cannot be negative")
+			} else if seen[outcome.Idx] {
+				errs.add(outcomeField+".idx", "duplicate idx %d", outcome.Idx)
+			}
+			seen[outcome.Idx] = true
+		}
+		slices.SortStableFunc(subReq.Outcomes, func(a, b models.AdminOutcomeRequest) int {
+			return a.Idx - b.Idx
+		})
+	}
+
+	return errs.err()
+}
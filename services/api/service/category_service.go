@@ -0,0 +1,647 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/database"
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+var (
+	// ErrCategoryNotFound 分类不存在
+	ErrCategoryNotFound = errors.New("category not found")
+	// ErrCategoryInUse 分类仍有子分类或被事件、生态、时间标签引用，不能删除
+	ErrCategoryInUse = errors.New("category is in use")
+)
+
+// maxCategoryLevel 分类的最大层级（0 为一级分类），与 category_language.level 的约定一致：一级、二级分类
+const maxCategoryLevel = 1
+
+// CreateCategory 创建分类及其各语言的名称，层级由父分类决定
+func (h *HandlerSvc) CreateCategory(req *models.CreateCategoryRequest) (*models.CategoryDetailResponse, error) {
+	var errs ValidationErrors
+	validateTaxonomyCode(&errs, req.Code)
+	validateTaxonomyRemark(&errs, req.Remark)
+	if req.SortOrder != nil && *req.SortOrder < 0 {
+		errs.add("sort_order", "must not be negative")
+	}
+	if len(req.Translations) == 0 {
+		errs.add("translations", "at least one language is required")
+	}
+	for _, languageGUID := range slices.Sorted(maps.Keys(req.Translations)) {
+		translation := req.Translations[languageGUID]
+		validateTaxonomyTranslation(&errs, languageGUID, &translation)
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	var response *models.CategoryDetailResponse
+	repo := database.NewCategoryRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		if err := repo.LockCategoryTree(db); err != nil {
+			return err
+		}
+		if err := checkTranslationLanguages(db, slices.Collect(maps.Keys(req.Translations))); err != nil {
+			return err
+		}
+		if err := checkCategoryCode(db, repo, req.Code, ""); err != nil {
+			return err
+		}
+
+		tree, err := loadCategoryTree(db, repo)
+		if err != nil {
+			return err
+		}
+		level, err := tree.childLevel(req.ParentGUID)
+		if err != nil {
+			return err
+		}
+
+		category := &database.Category{
+			Code:   optionalString(req.Code),
+			Remark: optionalString(req.Remark),
+		}
+		if req.SortOrder != nil {
+			category.SortOrder = *req.SortOrder
+		} else if category.SortOrder, err = repo.NextCategorySortOrder(db, req.ParentGUID); err != nil {
+			return err
+		}
+		if err := repo.CreateCategory(db, category); err != nil {
+			return err
+		}
+		// is_active 的数据库默认值为 true，GORM 创建时会忽略零值，停用需单独更新
+		if req.IsActive != nil && !*req.IsActive {
+			if err := repo.UpdateCategory(db, category.GUID, map[string]interface{}{"is_active": false}); err != nil {
+				return err
+			}
+		}
+
+		for languageGUID, translation := range req.Translations {
+			categoryLang := &database.CategoryLanguage{
+				LanguageGUID:       languageGUID,
+				CategoryGUID:       category.GUID,
+				ParentCategoryGUID: req.ParentGUID,
+				Level:              level,
+				Name:               translation.Name,
+				Description:        translation.Description,
+			}
+			if err := repo.UpsertCategoryLanguage(db, categoryLang); err != nil {
+				return err
+			}
+		}
+
+		detail, err := loadCategoryDetail(db, repo, category.GUID)
+		if err != nil {
+			return err
+		}
+		response = detail
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetCategory 查询分类详情（包含全部语言）
+func (h *HandlerSvc) GetCategory(categoryGUID string) (*models.CategoryDetailResponse, error) {
+	return loadCategoryDetail(h.db.GetGorm(), database.NewCategoryRepository(), categoryGUID)
+}
+
+// UpdateCategory 更新分类，可移动到新的父分类（子分类随之移动）、启用或停用、修改翻译
+func (h *HandlerSvc) UpdateCategory(req *models.UpdateCategoryRequest) (*models.CategoryDetailResponse, error) {
+	if req.GUID == "" {
+		return nil, fmt.Errorf("%w: guid is required", ErrInvalidRequest)
+	}
+
+	var errs ValidationErrors
+	if req.Code != nil {
+		validateTaxonomyCode(&errs, *req.Code)
+	}
+	if req.Remark != nil {
+		validateTaxonomyRemark(&errs, *req.Remark)
+	}
+	if req.SortOrder != nil && *req.SortOrder < 0 {
+		errs.add("sort_order", "must not be negative")
+	}
+	upserts, removed := splitTranslationUpdates(&errs, req.Translations)
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	var response *models.CategoryDetailResponse
+	repo := database.NewCategoryRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		if err := repo.LockCategoryTree(db); err != nil {
+			return err
+		}
+		category, err := repo.LockCategory(db, req.GUID)
+		if err != nil {
+			return err
+		}
+		if category == nil {
+			return fmt.Errorf("%w: %s", ErrCategoryNotFound, req.GUID)
+		}
+		if err := checkTranslationLanguages(db, slices.Collect(maps.Keys(upserts))); err != nil {
+			return err
+		}
+
+		tree, err := loadCategoryTree(db, repo)
+		if err != nil {
+			return err
+		}
+		node := tree.nodes[category.GUID]
+		parentGUID, level := node.ParentCategoryGUID, node.Level
+
+		columns := make(map[string]interface{})
+		moved := req.ParentGUID != nil && *req.ParentGUID != parentGUID
+		if moved {
+			if err := tree.checkMove(category.GUID, *req.ParentGUID); err != nil {
+				return err
+			}
+			parentGUID = *req.ParentGUID
+			if level, err = tree.childLevel(parentGUID); err != nil {
+				return err
+			}
+			if req.SortOrder == nil {
+				sortOrder, err := repo.NextCategorySortOrder(db, parentGUID)
+				if err != nil {
+					return err
+				}
+				columns["sort_order"] = sortOrder
+			}
+		}
+		if req.Code != nil {
+			if err := checkCategoryCode(db, repo, *req.Code, category.GUID); err != nil {
+				return err
+			}
+			columns["code"] = optionalString(*req.Code)
+		}
+		if req.SortOrder != nil {
+			columns["sort_order"] = *req.SortOrder
+		}
+		if req.IsActive != nil {
+			columns["is_active"] = *req.IsActive
+		}
+		if req.Remark != nil {
+			columns["remark"] = optionalString(*req.Remark)
+		}
+
+		if err := repo.DeleteCategoryLanguages(db, category.GUID, removed); err != nil {
+			return err
+		}
+		for languageGUID, translation := range upserts {
+			categoryLang := &database.CategoryLanguage{
+				LanguageGUID:       languageGUID,
+				CategoryGUID:       category.GUID,
+				ParentCategoryGUID: parentGUID,
+				Level:              level,
+				Name:               translation.Name,
+				Description:        translation.Description,
+			}
+			if err := repo.UpsertCategoryLanguage(db, categoryLang); err != nil {
+				return err
+			}
+		}
+		if moved {
+			if err := repo.MoveCategory(db, category.GUID, parentGUID, level); err != nil {
+				return err
+			}
+			// 子分类保持原有的父子关系，只调整层级
+			delta := level - node.Level
+			for _, descendantGUID := range tree.descendants(category.GUID) {
+				descendant := tree.nodes[descendantGUID]
+				if err := repo.MoveCategory(db, descendantGUID, descendant.ParentCategoryGUID, descendant.Level+delta); err != nil {
+					return err
+				}
+			}
+		}
+		if err := repo.UpdateCategory(db, category.GUID, columns); err != nil {
+			return err
+		}
+
+		detail, err := loadCategoryDetail(db, repo, category.GUID)
+		if err != nil {
+			return err
+		}
+		if len(detail.Translations) == 0 {
+			return fmt.Errorf("%w: category must keep at least one translation", ErrInvalidRequest)
+		}
+		response = detail
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// DeleteCategory 软删除分类，仍有子分类或被事件、生态、时间标签引用时拒绝删除；翻译保留以便恢复
+func (h *HandlerSvc) DeleteCategory(categoryGUID string) error {
+	repo := database.NewCategoryRepository()
+
+	return h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		if err := repo.LockCategoryTree(db); err != nil {
+			return err
+		}
+		category, err := repo.LockCategory(db, categoryGUID)
+		if err != nil {
+			return err
+		}
+		if category == nil {
+			return fmt.Errorf("%w: %s", ErrCategoryNotFound, categoryGUID)
+		}
+
+		tree, err := loadCategoryTree(db, repo)
+		if err != nil {
+			return err
+		}
+		if children := tree.children[category.GUID]; len(children) > 0 {
+			return fmt.Errorf("%w: %s has %d child categories", ErrCategoryInUse, category.GUID, len(children))
+		}
+		usage, err := repo.GetCategoryUsage(db, category.GUID)
+		if err != nil {
+			return err
+		}
+		if usage.InUse() {
+			return fmt.Errorf("%w: %s is used by %d events, %d ecosystems and %d event periods",
+				ErrCategoryInUse, category.GUID, usage.Events, usage.Ecosystems, usage.EventPeriods)
+		}
+
+		return repo.DeleteCategory(db, category.GUID)
+	})
+}
+
+// RestoreCategory 恢复已删除的分类及其翻译，放回原父分类下（按父分类当前层级重新计算层级）
+// 父分类已删除、层级超出限制或业务编码已被其他分类使用时拒绝恢复
+func (h *HandlerSvc) RestoreCategory(categoryGUID string) (*models.CategoryDetailResponse, error) {
+	var response *models.CategoryDetailResponse
+	repo := database.NewCategoryRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		if err := repo.LockCategoryTree(db); err != nil {
+			return err
+		}
+		category, err := repo.LockDeletedCategory(db, categoryGUID)
+		if err != nil {
+			return err
+		}
+		if category == nil {
+			return fmt.Errorf("%w: no deleted category %s", ErrCategoryNotFound, categoryGUID)
+		}
+		if err := checkCategoryCode(db, repo, derefString(category.Code), category.GUID); err != nil {
+			return err
+		}
+
+		categoryLangs, err := repo.GetCategoryLanguages(db, []string{category.GUID}, nil)
+		if err != nil {
+			return err
+		}
+		parentGUID := ""
+		if len(categoryLangs) > 0 {
+			parentGUID = categoryLangs[0].ParentCategoryGUID
+		}
+		tree, err := loadCategoryTree(db, repo)
+		if err != nil {
+			return err
+		}
+		// 删除时已没有子分类，之后也不能在已删除的分类下新建子分类，恢复的分类没有子树
+		level, err := tree.childLevel(parentGUID)
+		if err != nil {
+			return err
+		}
+
+		if err := repo.RestoreCategory(db, category.GUID); err != nil {
+			return err
+		}
+		if err := repo.MoveCategory(db, category.GUID, parentGUID, level); err != nil {
+			return err
+		}
+
+		response, err = loadCategoryDetail(db, repo, category.GUID)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ReorderCategories 按给出的顺序重写父分类下全部子分类的 sort_order（拖拽排序）
+func (h *HandlerSvc) ReorderCategories(req *models.ReorderCategoriesRequest) error {
+	var errs ValidationErrors
+	if len(req.GUIDs) == 0 {
+		errs.add("guids", "is required")
+	}
+	seen := make(map[string]bool, len(req.GUIDs))
+	for i, guid := range req.GUIDs {
+		switch {
+		case guid == "":
+			errs.add(fmt.Sprintf("guids[%d]", i), "must not be empty")
+		case seen[guid]:
+			errs.add(fmt.Sprintf("guids[%d]", i), "duplicate category %s", guid)
+		}
+		seen[guid] = true
+	}
+	if err := errs.err(); err != nil {
+		return err
+	}
+
+	repo := database.NewCategoryRepository()
+
+	return h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		if err := repo.LockCategoryTree(db); err != nil {
+			return err
+		}
+		tree, err := loadCategoryTree(db, repo)
+		if err != nil {
+			return err
+		}
+		if req.ParentGUID != "" {
+			if _, ok := tree.nodes[req.ParentGUID]; !ok {
+				return fmt.Errorf("%w: %s", ErrCategoryNotFound, req.ParentGUID)
+			}
+		}
+
+		children := tree.children[req.ParentGUID]
+		if len(children) != len(req.GUIDs) || !containsAll(seen, children) {
+			return fmt.Errorf("%w: guids must list exactly the %d child categories of parent %q",
+				ErrInvalidRequest, len(children), req.ParentGUID)
+		}
+		for i, guid := range req.GUIDs {
+			if err := repo.UpdateCategory(db, guid, map[string]interface{}{"sort_order": i}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ListCategories 查询分类，名称按请求语言返回（缺少时回退到默认语言）
+// 默认只返回启用的分类，停用分类的子分类一并隐藏；tree=true 时按层级嵌套
+func (h *HandlerSvc) ListCategories(req *models.ListCategoriesRequest) (*models.ListCategoriesResponse, error) {
+	if req.LanguageGUID == "" {
+		return nil, fmt.Errorf("%w: language_guid is required", ErrInvalidRequest)
+	}
+
+	db := h.db.GetGorm()
+	repo := database.NewCategoryRepository()
+
+	tree, err := loadCategoryTree(db, repo)
+	if err != nil {
+		return nil, err
+	}
+	languageGUIDs, err := languagePreference(db, req.LanguageGUID)
+	if err != nil {
+		return nil, err
+	}
+	categoryLangs, err := repo.GetCategoryLanguages(db, slices.Collect(maps.Keys(tree.categories)), languageGUIDs)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]taxonomyLanguage, 0, len(categoryLangs))
+	for _, categoryLang := range categoryLangs {
+		rows = append(rows, taxonomyLanguage{
+			OwnerGUID:    categoryLang.CategoryGUID,
+			LanguageGUID: categoryLang.LanguageGUID,
+			Name:         categoryLang.Name,
+			Description:  categoryLang.Description,
+		})
+	}
+	translations := localizedTaxonomyTranslations(rows, languageGUIDs)
+
+	var build func(parentGUID string) []*models.CategoryResponse
+	build = func(parentGUID string) []*models.CategoryResponse {
+		var responses []*models.CategoryResponse
+		for _, guid := range tree.children[parentGUID] {
+			category := tree.categories[guid]
+			if !category.IsActive && !req.IncludeInactive {
+				continue
+			}
+			node := tree.nodes[guid]
+			translation := translations[guid]
+			responses = append(responses, &models.CategoryResponse{
+				GUID:        category.GUID,
+				Code:        derefString(category.Code),
+				ParentGUID:  node.ParentCategoryGUID,
+				Level:       node.Level,
+				SortOrder:   category.SortOrder,
+				IsActive:    category.IsActive,
+				Name:        translation.Name,
+				Description: translation.Description,
+				Children:    build(guid),
+			})
+		}
+		return responses
+	}
+	roots := build("")
+
+	if req.Tree {
+		if roots == nil {
+			roots = []*models.CategoryResponse{}
+		}
+		return &models.ListCategoriesResponse{Categories: roots}, nil
+	}
+	// 按层级先序展开，子分类紧跟在父分类之后
+	flat := []*models.CategoryResponse{}
+	var flatten func(responses []*models.CategoryResponse)
+	flatten = func(responses []*models.CategoryResponse) {
+		for _, response := range responses {
+			children := response.Children
+			response.Children = nil
+			flat = append(flat, response)
+			flatten(children)
+		}
+	}
+	flatten(roots)
+	return &models.ListCategoriesResponse{Categories: flat}, nil
+}
+
+// categoryTree 分类层级，父子关系和层级来自 category_language
+// 没有多语言记录或父分类已不存在的分类视为一级分类
+type categoryTree struct {
+	categories map[string]database.Category
+	nodes      map[string]database.CategoryNode
+	children   map[string][]string // 父分类 GUID（一级分类为空字符串）-> 按 sort_order 排列的子分类
+}
+
+// loadCategoryTree 加载全部分类并建立层级
+func loadCategoryTree(db *gorm.DB, repo database.CategoryRepository) (*categoryTree, error) {
+	categories, err := repo.ListCategories(db)
+	if err != nil {
+		return nil, err
+	}
+	nodes, err := repo.GetCategoryNodes(db)
+	if err != nil {
+		return nil, err
+	}
+	return newCategoryTree(categories, nodes), nil
+}
+
+// newCategoryTree 由按 sort_order 排好序的分类和层级信息建立分类树
+func newCategoryTree(categories []database.Category, nodes []database.CategoryNode) *categoryTree {
+	tree := &categoryTree{
+		categories: make(map[string]database.Category, len(categories)),
+		nodes:      make(map[string]database.CategoryNode, len(categories)),
+		children:   make(map[string][]string),
+	}
+	for _, category := range categories {
+		tree.categories[category.GUID] = category
+	}
+	for _, node := range nodes {
+		if _, ok := tree.categories[node.CategoryGUID]; ok {
+			tree.nodes[node.CategoryGUID] = node
+		}
+	}
+	for _, category := range categories {
+		node, ok := tree.nodes[category.GUID]
+		if _, parentExists := tree.categories[node.ParentCategoryGUID]; !ok || !parentExists {
+			node = database.CategoryNode{CategoryGUID: category.GUID}
+		}
+		tree.nodes[category.GUID] = node
+		tree.children[node.ParentCategoryGUID] = append(tree.children[node.ParentCategoryGUID], category.GUID)
+	}
+	return tree
+}
+
+// childLevel 返回父分类下子分类的层级，父分类为空时为一级分类
+func (t *categoryTree) childLevel(parentGUID string) (int16, error) {
+	if parentGUID == "" {
+		return 0, nil
+	}
+	var errs ValidationErrors
+	parent, ok := t.nodes[parentGUID]
+	switch {
+	case !ok:
+		errs.add("parent_guid", "category %s does not exist", parentGUID)
+	case parent.Level >= maxCategoryLevel:
+		errs.add("parent_guid", "categories can be nested at most %d levels deep", maxCategoryLevel+1)
+	}
+	if err := errs.err(); err != nil {
+		return 0, err
+	}
+	return parent.Level + 1, nil
+}
+
+// checkMove 校验能否将分类移动到新的父分类下：不能移到自身或子孙分类下，移动后整棵子树不超过最大层级
+func (t *categoryTree) checkMove(categoryGUID, parentGUID string) error {
+	var errs ValidationErrors
+	if parentGUID == categoryGUID || slices.Contains(t.descendants(categoryGUID), parentGUID) {
+		errs.add("parent_guid", "category cannot be moved under itself or its descendants")
+		return errs.err()
+	}
+	level, err := t.childLevel(parentGUID)
+	if err != nil {
+		return err
+	}
+	if int(level)+t.height(categoryGUID) > maxCategoryLevel {
+		errs.add("parent_guid", "categories can be nested at most %d levels deep", maxCategoryLevel+1)
+	}
+	return errs.err()
+}
+
+// descendants 返回分类的全部子孙分类（先序）
+func (t *categoryTree) descendants(categoryGUID string) []string {
+	var result []string
+	for _, child := range t.children[categoryGUID] {
+		result = append(result, child)
+		result = append(result, t.descendants(child)...)
+	}
+	return result
+}
+
+// height 返回分类下子树的高度，没有子分类时为 0
+func (t *categoryTree) height(categoryGUID string) int {
+	height := 0
+	for _, child := range t.children[categoryGUID] {
+		height = max(height, t.height(child)+1)
+	}
+	return height
+}
+
+// loadCategoryDetail 加载分类及其全部语言的翻译
+func loadCategoryDetail(db *gorm.DB, repo database.CategoryRepository, categoryGUID string) (*models.CategoryDetailResponse, error) {
+	category, err := repo.GetCategory(db, categoryGUID)
+	if err != nil {
+		return nil, err
+	}
+	if category == nil {
+		return nil, fmt.Errorf("%w: %s", ErrCategoryNotFound, categoryGUID)
+	}
+	categoryLangs, err := repo.GetCategoryLanguages(db, []string{category.GUID}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.CategoryDetailResponse{
+		GUID:         category.GUID,
+		Code:         derefString(category.Code),
+		SortOrder:    category.SortOrder,
+		IsActive:     category.IsActive,
+		Remark:       derefString(category.Remark),
+		Translations: make(map[string]models.TaxonomyTranslation, len(categoryLangs)),
+		CreatedAt:    category.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    category.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, categoryLang := range categoryLangs {
+		// 各语言记录的父分类和层级保持一致
+		response.ParentGUID = categoryLang.ParentCategoryGUID
+		response.Level = categoryLang.Level
+		response.Translations[categoryLang.LanguageGUID] = models.TaxonomyTranslation{
+			Name:        categoryLang.Name,
+			Description: categoryLang.Description,
+		}
+	}
+	return response, nil
+}
+
+// checkCategoryCode 校验业务编码未被其他分类使用，code 为空时不校验
+func checkCategoryCode(db *gorm.DB, repo database.CategoryRepository, code, categoryGUID string) error {
+	if code == "" {
+		return nil
+	}
+	existing, err := repo.GetCategoryByCode(db, code)
+	if err != nil {
+		return err
+	}
+	var errs ValidationErrors
+	if existing != nil && existing.GUID != categoryGUID {
+		errs.add("code", "code %s is already used by category %s", code, existing.GUID)
+	}
+	return errs.err()
+}
+
+// containsAll set 是否包含 guids 中的全部元素
+func containsAll(set map[string]bool, guids []string) bool {
+	for _, guid := range guids {
+		if !set[guid] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/database"
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// defaultChanceHistoryWindow 未指定 window 时的时间窗口
+const defaultChanceHistoryWindow = "1d"
+
+// GetChanceHistory 查询子事件各方向在时间窗口内的概率走势
+// 数据来自 index 进程定时写入的 sub_event_chance_stat，每个时间桶保留桶内最后一次采样
+func (h *HandlerSvc) GetChanceHistory(req *models.ChanceHistoryRequest) (*models.ChanceHistoryResponse, error) {
+	if req.Window == "" {
+		req.Window = defaultChanceHistoryWindow
+	}
+	window, ok := database.LookupChanceStatWindow(req.Window)
+	if !ok {
+		return nil, fmt.Errorf("%w: window must be one of 1h, 6h, 1d, 1w, all", ErrInvalidRequest)
+	}
+
+	db := h.db.GetGorm()
+	eventRepo := database.NewEventRepository()
+
+	subEvent, err := eventRepo.GetSubEvent(db, req.SubEventGUID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrSubEventNotFound, req.SubEventGUID)
+		}
+		return nil, err
+	}
+
+	directions, err := eventRepo.GetSubEventDirections(db, subEvent.GUID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := database.NewChanceStatRepository().ListChanceStats(db, subEvent.GUID, window.StatWay, window.Since(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+
+	points := make(map[string][]models.ChanceHistoryPoint, len(directions))
+	for _, stat := range stats {
+		bucket, err := time.Parse(time.RFC3339, stat.Datetime)
+		if err != nil {
+			return nil, fmt.Errorf("invalid chance stat datetime %q: %w", stat.Datetime, err)
+		}
+		points[stat.DirectionGUID] = append(points[stat.DirectionGUID], models.ChanceHistoryPoint{
+			Time:   bucket.Unix(),
+			Chance: stat.Chance,
+		})
+	}
+
+	// 已删除方向的历史不再返回
+	histories := make([]models.DirectionChanceHistory, 0, len(directions))
+	for _, direction := range directions {
+		directionPoints := points[direction.GUID]
+		if directionPoints == nil {
+			directionPoints = []models.ChanceHistoryPoint{}
+		}
+		histories = append(histories, models.DirectionChanceHistory{
+			DirectionGUID: direction.GUID,
+			Direction:     direction.Direction,
+			Chance:        direction.Chance,
+			Points:        directionPoints,
+		})
+	}
+
+	return &models.ChanceHistoryResponse{
+		SubEventGUID:    subEvent.GUID,
+		Window:          window.Name,
+		IntervalSeconds: int64(window.Interval / time.Second),
+		Directions:      histories,
+	}, nil
+}
@@ -0,0 +1,414 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/database"
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+var (
+	// ErrEcosystemNotFound 生态不存在
+	ErrEcosystemNotFound = errors.New("ecosystem not found")
+	// ErrEcosystemInUse 生态仍被事件引用，不能删除或修改所属分类
+	ErrEcosystemInUse = errors.New("ecosystem is referenced by events")
+)
+
+// CreateEcosystem 创建生态及其各语言的名称
+func (h *HandlerSvc) CreateEcosystem(req *models.CreateEcosystemRequest) (*models.EcosystemDetailResponse, error) {
+	var errs ValidationErrors
+	if req.CategoryGUID == "" {
+		errs.add("category_guid", "is required")
+	}
+	validateTaxonomyCode(&errs, req.Code)
+	validateTaxonomyRemark(&errs, req.Remark)
+	if req.SortOrder < 0 {
+		errs.add("sort_order", "must not be negative")
+	}
+	if len(req.Translations) == 0 {
+		errs.add("translations", "at least one language is required")
+	}
+	for _, languageGUID := range slices.Sorted(maps.Keys(req.Translations)) {
+		translation := req.Translations[languageGUID]
+		validateTaxonomyTranslation(&errs, languageGUID, &translation)
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	var response *models.EcosystemDetailResponse
+	repo := database.NewEcosystemRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		if err := checkTaxonomyCategory(db, req.CategoryGUID); err != nil {
+			return err
+		}
+		if err := checkTranslationLanguages(db, slices.Collect(maps.Keys(req.Translations))); err != nil {
+			return err
+		}
+		if err := checkEcosystemCode(db, repo, req.Code, ""); err != nil {
+			return err
+		}
+
+		ecosystem := &database.Ecosystem{
+			CategoryGUID: req.CategoryGUID,
+			Code:         optionalString(req.Code),
+			SortOrder:    req.SortOrder,
+			Remark:       optionalString(req.Remark),
+			Extra:        database.JSONB(req.Extra),
+		}
+		if err := repo.CreateEcosystem(db, ecosystem); err != nil {
+			return err
+		}
+		// is_active 的数据库默认值为 true，GORM 创建时会忽略零值，停用需单独更新
+		if req.IsActive != nil && !*req.IsActive {
+			if err := repo.UpdateEcosystem(db, ecosystem.GUID, map[string]interface{}{"is_active": false}); err != nil {
+				return err
+			}
+		}
+
+		for languageGUID, translation := range req.Translations {
+			ecosystemLang := &database.EcosystemLanguage{
+				LanguageGUID:  languageGUID,
+				EcosystemGUID: ecosystem.GUID,
+				Name:          translation.Name,
+				Description:   translation.Description,
+			}
+			if err := repo.UpsertEcosystemLanguage(db, ecosystemLang); err != nil {
+				return err
+			}
+		}
+
+		detail, err := loadEcosystemDetail(db, repo, ecosystem.GUID)
+		if err != nil {
+			return err
+		}
+		response = detail
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetEcosystem 查询生态详情（包含全部语言）
+func (h *HandlerSvc) GetEcosystem(ecosystemGUID string) (*models.EcosystemDetailResponse, error) {
+	return loadEcosystemDetail(h.db.GetGorm(), database.NewEcosystemRepository(), ecosystemGUID)
+}
+
+// UpdateEcosystem 更新生态字段和翻译，仍被事件引用时不能修改所属分类
+func (h *HandlerSvc) UpdateEcosystem(req *models.UpdateEcosystemRequest) (*models.EcosystemDetailResponse, error) {
+	if req.GUID == "" {
+		return nil, fmt.Errorf("%w: guid is required", ErrInvalidRequest)
+	}
+
+	var errs ValidationErrors
+	if req.CategoryGUID != nil && *req.CategoryGUID == "" {
+		errs.add("category_guid", "must not be empty")
+	}
+	if req.Code != nil {
+		validateTaxonomyCode(&errs, *req.Code)
+	}
+	if req.Remark != nil {
+		validateTaxonomyRemark(&errs, *req.Remark)
+	}
+	if req.SortOrder != nil && *req.SortOrder < 0 {
+		errs.add("sort_order", "must not be negative")
+	}
+	upserts, removed := splitTranslationUpdates(&errs, req.Translations)
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	var response *models.EcosystemDetailResponse
+	repo := database.NewEcosystemRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		ecosystem, err := repo.LockEcosystem(db, req.GUID)
+		if err != nil {
+			return err
+		}
+		if ecosystem == nil {
+			return fmt.Errorf("%w: %s", ErrEcosystemNotFound, req.GUID)
+		}
+
+		columns := make(map[string]interface{})
+		if req.CategoryGUID != nil && *req.CategoryGUID != ecosystem.CategoryGUID {
+			// 事件要求生态与事件属于同一分类，已被引用的生态不能换分类
+			count, err := repo.CountEcosystemEvents(db, ecosystem.GUID)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return fmt.Errorf("%w: %s is used by %d events, category cannot change", ErrEcosystemInUse, ecosystem.GUID, count)
+			}
+			if err := checkTaxonomyCategory(db, *req.CategoryGUID); err != nil {
+				return err
+			}
+			columns["category_guid"] = *req.CategoryGUID
+		}
+		if req.Code != nil {
+			if err := checkEcosystemCode(db, repo, *req.Code, ecosystem.GUID); err != nil {
+				return err
+			}
+			columns["code"] = optionalString(*req.Code)
+		}
+		if req.SortOrder != nil {
+			columns["sort_order"] = *req.SortOrder
+		}
+		if req.IsActive != nil {
+			columns["is_active"] = *req.IsActive
+		}
+		if req.Remark != nil {
+			columns["remark"] = optionalString(*req.Remark)
+		}
+		if req.Extra != nil {
+			columns["extra"] = database.JSONB(req.Extra)
+		}
+		if err := repo.UpdateEcosystem(db, ecosystem.GUID, columns); err != nil {
+			return err
+		}
+
+		if err := checkTranslationLanguages(db, slices.Collect(maps.Keys(upserts))); err != nil {
+			return err
+		}
+		if err := repo.DeleteEcosystemLanguages(db, ecosystem.GUID, removed); err != nil {
+			return err
+		}
+		for languageGUID, translation := range upserts {
+			ecosystemLang := &database.EcosystemLanguage{
+				LanguageGUID:  languageGUID,
+				EcosystemGUID: ecosystem.GUID,
+				Name:          translation.Name,
+				Description:   translation.Description,
+			}
+			if err := repo.UpsertEcosystemLanguage(db, ecosystemLang); err != nil {
+				return err
+			}
+		}
+
+		detail, err := loadEcosystemDetail(db, repo, ecosystem.GUID)
+		if err != nil {
+			return err
+		}
+		if len(detail.Translations) == 0 {
+			return fmt.Errorf("%w: ecosystem must keep at least one translation", ErrInvalidRequest)
+		}
+		response = detail
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// DeleteEcosystem 软删除生态，仍被事件引用时拒绝删除；翻译保留以便恢复
+func (h *HandlerSvc) DeleteEcosystem(ecosystemGUID string) error {
+	repo := database.NewEcosystemRepository()
+
+	return h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		ecosystem, err := repo.LockEcosystem(db, ecosystemGUID)
+		if err != nil {
+			return err
+		}
+		if ecosystem == nil {
+			return fmt.Errorf("%w: %s", ErrEcosystemNotFound, ecosystemGUID)
+		}
+
+		count, err := repo.CountEcosystemEvents(db, ecosystem.GUID)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: %s is used by %d events", ErrEcosystemInUse, ecosystem.GUID, count)
+		}
+
+		return repo.DeleteEcosystem(db, ecosystem.GUID)
+	})
+}
+
+// RestoreEcosystem 恢复已删除的生态及其翻译，所属分类已删除或业务编码已被其他生态使用时拒绝恢复
+func (h *HandlerSvc) RestoreEcosystem(ecosystemGUID string) (*models.EcosystemDetailResponse, error) {
+	var response *models.EcosystemDetailResponse
+	repo := database.NewEcosystemRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		ecosystem, err := repo.LockDeletedEcosystem(db, ecosystemGUID)
+		if err != nil {
+			return err
+		}
+		if ecosystem == nil {
+			return fmt.Errorf("%w: no deleted ecosystem %s", ErrEcosystemNotFound, ecosystemGUID)
+		}
+		if err := checkTaxonomyCategory(db, ecosystem.CategoryGUID); err != nil {
+			return err
+		}
+		if err := checkEcosystemCode(db, repo, derefString(ecosystem.Code), ecosystem.GUID); err != nil {
+			return err
+		}
+		if err := repo.RestoreEcosystem(db, ecosystem.GUID); err != nil {
+			return err
+		}
+
+		response, err = loadEcosystemDetail(db, repo, ecosystem.GUID)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ListEcosystems 查询生态列表，名称按请求语言返回（缺少时回退到默认语言）
+func (h *HandlerSvc) ListEcosystems(req *models.ListEcosystemsRequest) (*models.ListEcosystemsResponse, error) {
+	if req.LanguageGUID == "" {
+		return nil, fmt.Errorf("%w: language_guid is required", ErrInvalidRequest)
+	}
+
+	db := h.db.GetGorm()
+	repo := database.NewEcosystemRepository()
+
+	ecosystems, err := repo.ListEcosystems(db, req.CategoryGUID, !req.IncludeInactive)
+	if err != nil {
+		return nil, err
+	}
+	languageGUIDs, err := languagePreference(db, req.LanguageGUID)
+	if err != nil {
+		return nil, err
+	}
+	ecosystemGUIDs := make([]string, 0, len(ecosystems))
+	for _, ecosystem := range ecosystems {
+		ecosystemGUIDs = append(ecosystemGUIDs, ecosystem.GUID)
+	}
+	ecosystemLangs, err := repo.GetEcosystemLanguages(db, ecosystemGUIDs, languageGUIDs)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]taxonomyLanguage, 0, len(ecosystemLangs))
+	for _, ecosystemLang := range ecosystemLangs {
+		rows = append(rows, taxonomyLanguage{
+			OwnerGUID:    ecosystemLang.EcosystemGUID,
+			LanguageGUID: ecosystemLang.LanguageGUID,
+			Name:         ecosystemLang.Name,
+			Description:  ecosystemLang.Description,
+		})
+	}
+	translations := localizedTaxonomyTranslations(rows, languageGUIDs)
+
+	responses := make([]models.EcosystemResponse, 0, len(ecosystems))
+	for _, ecosystem := range ecosystems {
+		translation := translations[ecosystem.GUID]
+		responses = append(responses, models.EcosystemResponse{
+			GUID:         ecosystem.GUID,
+			CategoryGUID: ecosystem.CategoryGUID,
+			Code:         derefString(ecosystem.Code),
+			SortOrder:    ecosystem.SortOrder,
+			IsActive:     ecosystem.IsActive,
+			EventNum:     ecosystem.EventNum,
+			Name:         translation.Name,
+			Description:  translation.Description,
+		})
+	}
+	return &models.ListEcosystemsResponse{Ecosystems: responses}, nil
+}
+
+// adjustEcosystemEventNums 在事件所属生态变化时调整计数：previous 减 1、current 加 1
+// 按 GUID 顺序加锁，避免两个方向相反的移动互相等待
+func adjustEcosystemEventNums(db *gorm.DB, previousEcosystemGUID, currentEcosystemGUID string) error {
+	if previousEcosystemGUID == currentEcosystemGUID {
+		return nil
+	}
+	repo := database.NewEcosystemRepository()
+	deltas := map[string]int{previousEcosystemGUID: -1, currentEcosystemGUID: 1}
+	for _, ecosystemGUID := range slices.Sorted(maps.Keys(deltas)) {
+		if err := repo.AdjustEcosystemEventNum(db, ecosystemGUID, deltas[ecosystemGUID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// lockEcosystems 按 GUID 顺序锁定多个生态，与删除生态的引用检查互斥；不存在的生态不锁定任何行
+func lockEcosystems(db *gorm.DB, ecosystemGUIDs ...string) error {
+	repo := database.NewEcosystemRepository()
+	for _, ecosystemGUID := range slices.Compact(slices.Sorted(slices.Values(ecosystemGUIDs))) {
+		if _, err := repo.LockEcosystem(db, ecosystemGUID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// loadEcosystemDetail 加载生态及其全部语言的翻译
+func loadEcosystemDetail(db *gorm.DB, repo database.EcosystemRepository, ecosystemGUID string) (*models.EcosystemDetailResponse, error) {
+	ecosystem, err := repo.GetEcosystem(db, ecosystemGUID)
+	if err != nil {
+		return nil, err
+	}
+	if ecosystem == nil {
+		return nil, fmt.Errorf("%w: %s", ErrEcosystemNotFound, ecosystemGUID)
+	}
+	ecosystemLangs, err := repo.GetEcosystemLanguages(db, []string{ecosystem.GUID}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	translations := make(map[string]models.TaxonomyTranslation, len(ecosystemLangs))
+	for _, ecosystemLang := range ecosystemLangs {
+		translations[ecosystemLang.LanguageGUID] = models.TaxonomyTranslation{
+			Name:        ecosystemLang.Name,
+			Description: ecosystemLang.Description,
+		}
+	}
+	return &models.EcosystemDetailResponse{
+		GUID:         ecosystem.GUID,
+		CategoryGUID: ecosystem.CategoryGUID,
+		Code:         derefString(ecosystem.Code),
+		SortOrder:    ecosystem.SortOrder,
+		IsActive:     ecosystem.IsActive,
+		Remark:       derefString(ecosystem.Remark),
+		Extra:        ecosystem.Extra,
+		EventNum:     ecosystem.EventNum,
+		Translations: translations,
+		CreatedAt:    ecosystem.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    ecosystem.UpdatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+// checkEcosystemCode 校验业务编码未被其他生态使用，code 为空时不校验
+func checkEcosystemCode(db *gorm.DB, repo database.EcosystemRepository, code, ecosystemGUID string) error {
+	if code == "" {
+		return nil
+	}
+	existing, err := repo.GetEcosystemByCode(db, code)
+	if err != nil {
+		return err
+	}
+	var errs ValidationErrors
+	if existing != nil && existing.GUID != ecosystemGUID {
+		errs.add("code", "code %s is already used by ecosystem %s", code, existing.GUID)
+	}
+	return errs.err()
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/database"
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// ErrEventNotDeletable 只有草稿事件可以删除，已上线的事件需先取消
+var ErrEventNotDeletable = errors.New("only draft events can be deleted")
+
+// DeleteEvent 软删除草稿事件，并在同一事务中减少所属生态的事件数
+// 翻译、子事件和流转记录保留，恢复前不会出现在任何查询中
+func (h *HandlerSvc) DeleteEvent(eventGUID string) error {
+	repo := database.NewEventRepository()
+
+	return h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		event, err := repo.LockEvent(db, eventGUID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: %s", ErrEventNotFound, eventGUID)
+			}
+			return err
+		}
+		if event.Status != database.EventStatusDraft {
+			return fmt.Errorf("%w: %s is %s", ErrEventNotDeletable, event.GUID, event.Status)
+		}
+
+		if err := repo.DeleteEvent(db, event.GUID); err != nil {
+			return err
+		}
+		return database.NewEcosystemRepository().AdjustEcosystemEventNum(db, event.EcosystemGUID, -1)
+	})
+}
+
+// RestoreEvent 恢复已删除的事件，并在同一事务中增加所属生态的事件数
+// 引用的分类、生态、时间标签或运动队已删除时拒绝恢复，需先恢复这些记录
+func (h *HandlerSvc) RestoreEvent(eventGUID string) (*models.RestoreEventResponse, error) {
+	var response *models.RestoreEventResponse
+	repo := database.NewEventRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		event, err := repo.LockDeletedEvent(db, eventGUID)
+		if err != nil {
+			return err
+		}
+		if event == nil {
+			return fmt.Errorf("%w: no deleted event %s", ErrEventNotFound, eventGUID)
+		}
+		// 与创建事件一致，先对生态加排他锁再以共享锁校验引用，校验通过后才累加事件数
+		ecosystemRepo := database.NewEcosystemRepository()
+		if _, err := ecosystemRepo.LockEcosystem(db, event.EcosystemGUID); err != nil {
+			return err
+		}
+		if err := validateRestoreEventReferences(db, event); err != nil {
+			return err
+		}
+		if err := ecosystemRepo.AdjustEcosystemEventNum(db, event.EcosystemGUID, 1); err != nil {
+			return err
+		}
+		if err := repo.RestoreEvent(db, event.GUID); err != nil {
+			return err
+		}
+
+		restored, err := repo.GetEvent(db, event.GUID)
+		if err != nil {
+			return err
+		}
+		response = &models.RestoreEventResponse{
+			GUID:      restored.GUID,
+			Status:    restored.Status,
+			UpdatedAt: restored.UpdatedAt.Format(time.RFC3339),
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// validateRestoreEventReferences 校验事件引用的分类、生态、时间标签和运动队均未删除
+// 草稿事件允许引用已停用的记录，这里只校验是否存在
+func validateRestoreEventReferences(db *gorm.DB, event *database.Event) error {
+	var errs ValidationErrors
+	taxonomy := database.NewTaxonomyRepository()
+
+	category, err := taxonomy.GetCategory(db, event.CategoryGUID)
+	if err != nil {
+		return err
+	}
+	if category == nil {
+		errs.add("category_guid", "category %s has been deleted", event.CategoryGUID)
+	}
+	ecosystem, err := taxonomy.GetEcosystem(db, event.EcosystemGUID)
+	if err != nil {
+		return err
+	}
+	if ecosystem == nil {
+		errs.add("ecosystem_guid", "ecosystem %s has been deleted", event.EcosystemGUID)
+	}
+	eventPeriod, err := taxonomy.GetEventPeriod(db, event.EventPeriodGUID)
+	if err != nil {
+		return err
+	}
+	if eventPeriod == nil {
+		errs.add("event_period_guid", "event period %s has been deleted", event.EventPeriodGUID)
+	}
+
+	teamGroupFields := map[string]string{
+		"main_team_group_guid":    event.MainTeamGroupGUID,
+		"cluster_team_group_guid": event.ClusterTeamGroupGUID,
+	}
+	var teamGroupGUIDs []string
+	for _, teamGroupGUID := range teamGroupFields {
+		if !isEmptyTeamGroup(teamGroupGUID) {
+			teamGroupGUIDs = append(teamGroupGUIDs, teamGroupGUID)
+		}
+	}
+	teamGroups, err := taxonomy.GetTeamGroupsByGUIDs(db, teamGroupGUIDs)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool, len(teamGroups))
+	for _, teamGroup := range teamGroups {
+		existing[teamGroup.GUID] = true
+	}
+	for _, field := range slices.Sorted(maps.Keys(teamGroupFields)) {
+		teamGroupGUID := teamGroupFields[field]
+		if !isEmptyTeamGroup(teamGroupGUID) && !existing[teamGroupGUID] {
+			errs.add(field, "team group %s has been deleted", teamGroupGUID)
+		}
+	}
+	return errs.err()
+}
@@ -0,0 +1,273 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/database"
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// errImportRolledBack 用于回滚 dry-run 或 all_or_nothing 模式下有失败行的导入事务
+var errImportRolledBack = errors.New("event import rolled back")
+
+// maxImportLineBytes JSONL 单行的最大长度
+const maxImportLineBytes = 1 << 20
+
+// ImportEvents 批量导入事件，返回每行的结果
+// 所有行在同一事务中执行，每行使用独立的 savepoint：失败的行只回滚自身
+// all_or_nothing 模式下任意一行失败则回滚整个事务；dry-run 模式下执行全部校验和写入后回滚
+func (h *HandlerSvc) ImportEvents(req *models.ImportEventsRequest) (*models.ImportEventsResponse, error) {
+	mode := req.Mode
+	if mode == "" {
+		mode = models.ImportModeAllOrNothing
+	}
+	if mode != models.ImportModeAllOrNothing && mode != models.ImportModeBestEffort {
+		return nil, fmt.Errorf("%w: mode must be %s or %s", ErrInvalidRequest, models.ImportModeAllOrNothing, models.ImportModeBestEffort)
+	}
+	if len(req.Lines) == 0 {
+		return nil, fmt.Errorf("%w: no events to import", ErrInvalidRequest)
+	}
+
+	response := &models.ImportEventsResponse{
+		Mode:    mode,
+		DryRun:  req.DryRun,
+		Total:   len(req.Lines),
+		Results: make([]models.ImportEventResult, 0, len(req.Lines)),
+	}
+	repo := database.NewEventRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		for _, line := range req.Lines {
+			result := models.ImportEventResult{Line: line.Line}
+			created, err := h.importEventLine(db, repo, line)
+			if err != nil {
+				result.Status = models.ImportStatusFailed
+				result.Error = err.Error()
+				var validationErrs ValidationErrors
+				if errors.As(err, &validationErrs) {
+					result.Fields = validationErrs
+				}
+				response.Failed++
+			} else {
+				result.Status = models.ImportStatusCreated
+				result.EventGUID = created.GUID
+				response.Succeeded++
+			}
+			response.Results = append(response.Results, result)
+		}
+
+		if req.DryRun || (mode == models.ImportModeAllOrNothing && response.Failed > 0) {
+			return errImportRolledBack
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errImportRolledBack) {
+		return nil, err
+	}
+	response.Committed = err == nil
+
+	// 未提交时，成功的行实际上没有写入
+	if !response.Committed {
+		for i := range response.Results {
+			result := &response.Results[i]
+			if result.Status != models.ImportStatusCreated {
+				continue
+			}
+			result.EventGUID = ""
+			if req.DryRun {
+				result.Status = models.ImportStatusValid
+			} else {
+				result.Status = models.ImportStatusRolledBack
+			}
+		}
+	}
+
+	return response, nil
+}
+
+// importEventLine 校验并在 savepoint 中创建一行事件
+func (h *HandlerSvc) importEventLine(db *gorm.DB, repo database.EventRepository, line models.ImportEventLine) (*models.CreateEventResponse, error) {
+	if line.Request == nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRequest, line.ParseError)
+	}
+	if err := h.validateCreateEventNewRequest(line.Request); err != nil {
+		return nil, err
+	}
+
+	var created *models.CreateEventResponse
+	err := db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		created, err = createEvent(tx, repo, line.Request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// ParseEventImport 解析 JSONL 或 CSV 格式的导入内容，每行（每条记录）一个 CreateEventRequest
+// 单行解析失败不会中断解析，错误记录在对应行中；格式错误或超过 maxLines 行时返回错误
+func ParseEventImport(r io.Reader, format string, maxLines int) ([]models.ImportEventLine, error) {
+	var (
+		lines []models.ImportEventLine
+		err   error
+	)
+	switch format {
+	case models.ImportFormatJSONL:
+		lines, err = parseEventImportJSONL(r)
+	case models.ImportFormatCSV:
+		lines, err = parseEventImportCSV(r)
+	default:
+		return nil, fmt.Errorf("%w: format must be %s or %s", ErrInvalidRequest, models.ImportFormatJSONL, models.ImportFormatCSV)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if maxLines > 0 && len(lines) > maxLines {
+		return nil, fmt.Errorf("%w: at most %d events can be imported at once, got %d", ErrInvalidRequest, maxLines, len(lines))
+	}
+	return lines, nil
+}
+
+// parseEventImportJSONL 解析 JSONL，跳过空行
+func parseEventImportJSONL(r io.Reader) ([]models.ImportEventLine, error) {
+	var lines []models.ImportEventLine
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxImportLineBytes)
+
+	lineNum := 0
+	for scanner.Scan() {
+		lineNum++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		line := models.ImportEventLine{Line: lineNum}
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.DisallowUnknownFields()
+		var req models.CreateEventRequest
+		if err := decoder.Decode(&req); err != nil {
+			line.ParseError = fmt.Sprintf("invalid JSON: %v", err)
+		} else {
+			line.Request = &req
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: failed to read line %d: %v", ErrInvalidRequest, lineNum+1, err)
+	}
+	return lines, nil
+}
+
+// parseEventImportCSV 解析 CSV，首行为表头，列名与 CreateEventRequest 的 JSON 字段一致
+func parseEventImportCSV(r io.Reader) ([]models.ImportEventLine, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 0
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read CSV header: %v", ErrInvalidRequest, err)
+	}
+	for i, column := range header {
+		column = strings.TrimSpace(column)
+		if _, ok := csvEventColumns[column]; !ok {
+			return nil, fmt.Errorf("%w: unknown CSV column %q", ErrInvalidRequest, column)
+		}
+		header[i] = column
+	}
+
+	var lines []models.ImportEventLine
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) && errors.Is(parseErr.Err, csv.ErrFieldCount) {
+				lines = append(lines, models.ImportEventLine{Line: parseErr.StartLine, ParseError: parseErr.Error()})
+				continue
+			}
+			return nil, fmt.Errorf("%w: invalid CSV: %v", ErrInvalidRequest, err)
+		}
+
+		lineNum, _ := reader.FieldPos(0)
+		line := models.ImportEventLine{Line: lineNum}
+		var req models.CreateEventRequest
+		for i, column := range header {
+			if err := csvEventColumns[column](&req, strings.TrimSpace(record[i])); err != nil {
+				line.ParseError = fmt.Sprintf("column %s: %v", column, err)
+				break
+			}
+		}
+		if line.ParseError == "" {
+			line.Request = &req
+		}
+		lines = append(lines, line)
+	}
+	return lines, nil
+}
+
+// csvEventColumns CSV 列名到 CreateEventRequest 字段的映射
+var csvEventColumns = map[string]func(req *models.CreateEventRequest, value string) error{
+	"category_guid":           func(req *models.CreateEventRequest, value string) error { req.CategoryGUID = value; return nil },
+	"ecosystem_guid":          func(req *models.CreateEventRequest, value string) error { req.EcosystemGUID = value; return nil },
+	"event_period_guid":       func(req *models.CreateEventRequest, value string) error { req.EventPeriodGUID = value; return nil },
+	"main_team_group_guid":    func(req *models.CreateEventRequest, value string) error { req.MainTeamGroupGUID = value; return nil },
+	"cluster_team_group_guid": func(req *models.CreateEventRequest, value string) error { req.ClusterTeamGroupGUID = value; return nil },
+	"logo":                    func(req *models.CreateEventRequest, value string) error { req.Logo = value; return nil },
+	"title":                   func(req *models.CreateEventRequest, value string) error { req.Title = value; return nil },
+	"rules":                   func(req *models.CreateEventRequest, value string) error { req.Rules = value; return nil },
+	"language_guid":           func(req *models.CreateEventRequest, value string) error { req.LanguageGUID = value; return nil },
+	"is_sports": func(req *models.CreateEventRequest, value string) error {
+		if value == "" {
+			return nil
+		}
+		isSports, err := strconv.ParseBool(value)
+		req.IsSports = isSports
+		return err
+	},
+	"open_at":  func(req *models.CreateEventRequest, value string) error { return parseCSVUnix(value, &req.OpenAt) },
+	"start_at": func(req *models.CreateEventRequest, value string) error { return parseCSVUnix(value, &req.StartAt) },
+	"close_at": func(req *models.CreateEventRequest, value string) error { return parseCSVUnix(value, &req.CloseAt) },
+	"sub_events": func(req *models.CreateEventRequest, value string) error {
+		if value == "" {
+			return nil
+		}
+		return json.Unmarshal([]byte(value), &req.SubEvents)
+	},
+	"translations": func(req *models.CreateEventRequest, value string) error {
+		if value == "" {
+			return nil
+		}
+		return json.Unmarshal([]byte(value), &req.Translations)
+	},
+}
+
+// parseCSVUnix 解析可选的 Unix 时间戳列，空值表示未设置
+func parseCSVUnix(value string, target **int64) error {
+	if value == "" {
+		return nil
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	*target = &seconds
+	return nil
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+func TestParseEventImportJSONL(t *testing.T) {
+	input := strings.Join([]string{
+		`{"category_guid":"c1","ecosystem_guid":"e1","event_period_guid":"p1","language_guid":"l1","title":"A","sub_events":[{"title":"s","directions":[]}],"open_at":100}`,
+		``,
+		`{"category_guid":"c2",`,
+		`{"category_guid":"c3","unknown":1}`,
+		`   `,
+		`{"category_guid":"c4","is_sports":true}`,
+	}, "\n")
+
+	lines, err := ParseEventImport(strings.NewReader(input), models.ImportFormatJSONL, 0)
+	require.NoError(t, err)
+	require.Len(t, lines, 4)
+
+	// 空行不产生结果，但计入行号
+	require.Equal(t, 1, lines[0].Line)
+	require.Empty(t, lines[0].ParseError)
+	require.Equal(t, "c1", lines[0].Request.CategoryGUID)
+	require.Equal(t, "A", lines[0].Request.Title)
+	require.Len(t, lines[0].Request.SubEvents, 1)
+	require.Equal(t, int64(100), *lines[0].Request.OpenAt)
+
+	require.Equal(t, 3, lines[1].Line)
+	require.Nil(t, lines[1].Request)
+	require.Contains(t, lines[1].ParseError, "invalid JSON")
+
+	require.Equal(t, 4, lines[2].Line)
+	require.Nil(t, lines[2].Request)
+	require.Contains(t, lines[2].ParseError, `unknown field "unknown"`)
+
+	require.Equal(t, 6, lines[3].Line)
+	require.True(t, lines[3].Request.IsSports)
+}
+
+func TestParseEventImportJSONLRejectsOversizedLine(t *testing.T) {
+	input := `{"title":"` + strings.Repeat("x", maxImportLineBytes) + `"}`
+	_, err := ParseEventImport(strings.NewReader(input), models.ImportFormatJSONL, 0)
+	require.ErrorIs(t, err, ErrInvalidRequest)
+	require.ErrorContains(t, err, "failed to read line 1")
+}
+
+func TestParseEventImportCSV(t *testing.T) {
+	input := strings.Join([]string{
+		`category_guid, ecosystem_guid,event_period_guid,language_guid,title,is_sports,open_at,sub_events,translations`,
+		`c1,e1,p1,l1,A,true,100,"[{""title"":""s"",""directions"":[]}]","{""l2"":{""title"":""B""}}"`,
+		`c2,e2,p2,l2,"multi`,
+		`line",,,,`,
+		`c3,e3,p3,l3,C,maybe,,,`,
+		`c4,e4,p4,l4,D,,soon,,`,
+		`c5,e5,p5,l5,E,,,[not json,`,
+		`c6,e6`,
+		`c7,e7,p7,l7,G,false,,,`,
+	}, "\n")
+
+	lines, err := ParseEventImport(strings.NewReader(input), models.ImportFormatCSV, 0)
+	require.NoError(t, err)
+	require.Len(t, lines, 7)
+
+	first := lines[0]
+	require.Equal(t, 2, first.Line)
+	require.Empty(t, first.ParseError)
+	require.Equal(t, "e1", first.Request.EcosystemGUID)
+	require.True(t, first.Request.IsSports)
+	require.Equal(t, int64(100), *first.Request.OpenAt)
+	require.Nil(t, first.Request.CloseAt)
+	require.Len(t, first.Request.SubEvents, 1)
+	require.Equal(t, "B", first.Request.Translations["l2"].Title)
+
+	// 跨行的引号字段以记录起始行为行号
+	require.Equal(t, 3, lines[1].Line)
+	require.Equal(t, "multi\nline", lines[1].Request.Title)
+
+	cases := []struct {
+		line    int
+		wantErr string
+	}{
+		{line: 5, wantErr: "column is_sports"},
+		{line: 6, wantErr: "column open_at"},
+		{line: 7, wantErr: "column sub_events"},
+		{line: 8, wantErr: "wrong number of fields"},
+	}
+	for i, tc := range cases {
+		line := lines[i+2]
+		require.Equal(t, tc.line, line.Line)
+		require.Nil(t, line.Request)
+		require.Contains(t, line.ParseError, tc.wantErr)
+	}
+
+	// 字段数不符的行之后继续解析
+	require.Equal(t, 9, lines[6].Line)
+	require.Equal(t, "c7", lines[6].Request.CategoryGUID)
+	require.False(t, lines[6].Request.IsSports)
+}
+
+func TestParseEventImportRejectsInvalidInput(t *testing.T) {
+	cases := map[string]struct {
+		input    string
+		format   string
+		maxLines int
+		wantErr  string
+	}{
+		"unknown format":     {input: "", format: "xml", wantErr: "format must be jsonl or csv"},
+		"empty CSV":          {input: "", format: models.ImportFormatCSV, wantErr: "failed to read CSV header"},
+		"unknown CSV column": {input: "title,colour\nA,red", format: models.ImportFormatCSV, wantErr: `unknown CSV column "colour"`},
+		"bare quote in CSV":  {input: "title\na\"b", format: models.ImportFormatCSV, wantErr: "invalid CSV"},
+		"too many JSONL lines": {
+			input: "{}\n{}\n{}", format: models.ImportFormatJSONL, maxLines: 2,
+			wantErr: "at most 2 events can be imported at once, got 3",
+		},
+		"too many CSV lines": {
+			input: "title\nA\nB", format: models.ImportFormatCSV, maxLines: 1,
+			wantErr: "at most 1 events can be imported at once, got 2",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseEventImport(strings.NewReader(tc.input), tc.format, tc.maxLines)
+			require.ErrorIs(t, err, ErrInvalidRequest)
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
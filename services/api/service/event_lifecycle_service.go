@@ -0,0 +1,178 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/database"
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// eventTransitions 允许的事件状态流转，ended → resolved 只能通过 ResolveEvent 完成
+// draft → upcoming → live → ended → resolved，除已结算外的任何状态都可以取消（取消时作废全部子事件）；上线未开始的事件可以下线回草稿
+var eventTransitions = map[string][]string{
+	database.EventStatusDraft:    {database.EventStatusUpcoming, database.EventStatusCancelled},
+	database.EventStatusUpcoming: {database.EventStatusLive, database.EventStatusDraft, database.EventStatusCancelled},
+	database.EventStatusLive:     {database.EventStatusEnded, database.EventStatusCancelled},
+	database.EventStatusEnded:    {database.EventStatusResolved, database.EventStatusCancelled},
+}
+
+// canTransition 判断是否允许从 from 流转到 to
+func canTransition(from, to string) bool {
+	for _, allowed := range eventTransitions[from] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// TransitionEvent 流转事件状态并记录操作人和时间
+// 不能流转为已结算：结算需要选择胜出方向并派彩，只能通过 ResolveEvent 完成
+// 取消事件时在同一事务中作废全部子事件，并退还持仓成本
+func (h *HandlerSvc) TransitionEvent(req *models.TransitionEventRequest) (*models.EventTransitionResponse, error) {
+	if req.GUID == "" {
+		return nil, fmt.Errorf("%w: guid is required", ErrInvalidRequest)
+	}
+	if req.Actor == "" {
+		return nil, fmt.Errorf("%w: actor is required", ErrInvalidRequest)
+	}
+	if _, ok := database.EventStatusColumns(req.ToStatus); !ok {
+		return nil, fmt.Errorf("%w: unknown to_status %q", ErrInvalidRequest, req.ToStatus)
+	}
+	if req.ToStatus == database.EventStatusResolved {
+		return nil, fmt.Errorf("%w: use POST /api/v1/events/{guid}/resolution to resolve an event", ErrIllegalTransition)
+	}
+
+	var response *models.EventTransitionResponse
+	repo := database.NewEventRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+		history, err := transitionEventStatus(db, repo, req.GUID, req.ToStatus, req.Actor, req.Reason, nil)
+		if err != nil {
+			return err
+		}
+		if req.ToStatus == database.EventStatusCancelled {
+			if err := voidEventSubEvents(db, repo, database.NewLedgerRepository(), history.EventGUID); err != nil {
+				return err
+			}
+		}
+		response = toEventTransitionResponse(*history)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// transitionEventStatus 在事务中校验并执行状态流转，写入流转记录
+// extraColumns 为随状态一起更新的其他字段（例如结算结果）
+func transitionEventStatus(db *gorm.DB, repo database.EventRepository, eventGUID, toStatus, actor, reason string, extraColumns map[string]interface{}) (*database.EventStatusHistory, error) {
+	event, err := repo.GetEvent(db, eventGUID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrEventNotFound, eventGUID)
+		}
+		return nil, err
+	}
+
+	if !canTransition(event.Status, toStatus) {
+		return nil, fmt.Errorf("%w: %s → %s", ErrIllegalTransition, event.Status, toStatus)
+	}
+
+	columns, _ := database.EventStatusColumns(toStatus)
+	for column, value := range extraColumns {
+		columns[column] = value
+	}
+
+	updated, err := repo.TransitionEventStatus(db, event.GUID, event.Status, toStatus, columns)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, fmt.Errorf("%w: status of %s changed concurrently", ErrEventConflict, event.GUID)
+	}
+
+	history := &database.EventStatusHistory{
+		EventGUID:  event.GUID,
+		FromStatus: event.Status,
+		ToStatus:   toStatus,
+		Actor:      actor,
+		Reason:     reason,
+	}
+	if err := repo.CreateEventStatusHistory(db, history); err != nil {
+		return nil, fmt.Errorf("failed to record event status history: %w", err)
+	}
+	return history, nil
+}
+
+// voidEventSubEvents 作废事件下的全部子事件并退还持仓成本
+// 与结算一样先锁定方向，与同一子事件上的下单互斥，取消后不会再有订单成交
+func voidEventSubEvents(db *gorm.DB, repo database.EventRepository, ledger database.LedgerRepository, eventGUID string) error {
+	subEvents, err := repo.GetSubEventsByEventGUID(db, eventGUID)
+	if err != nil {
+		return err
+	}
+	for _, subEvent := range subEvents {
+		if _, err := repo.LockSubEventDirections(db, subEvent.GUID); err != nil {
+			return err
+		}
+		if err := repo.ResolveSubEvent(db, subEvent.GUID, database.SubEventResolutionVoid, nil); err != nil {
+			return err
+		}
+		if err := settleSubEventPositions(db, ledger, subEvent.GUID, database.SubEventResolutionVoid, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListEventTransitions 查询事件的状态流转记录
+func (h *HandlerSvc) ListEventTransitions(eventGUID string) (*models.ListEventTransitionsResponse, error) {
+	repo := database.NewEventRepository()
+	db := h.db.GetGorm()
+
+	event, err := repo.GetEvent(db, eventGUID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrEventNotFound, eventGUID)
+		}
+		return nil, err
+	}
+
+	histories, err := repo.ListEventStatusHistory(db, event.GUID)
+	if err != nil {
+		return nil, err
+	}
+
+	transitions := make([]models.EventTransitionResponse, 0, len(histories))
+	for _, history := range histories {
+		transitions = append(transitions, *toEventTransitionResponse(history))
+	}
+
+	return &models.ListEventTransitionsResponse{
+		EventGUID:   event.GUID,
+		Status:      event.Status,
+		Transitions: transitions,
+	}, nil
+}
+
+// toEventTransitionResponse 将流转记录转换为响应结构
+func toEventTransitionResponse(history database.EventStatusHistory) *models.EventTransitionResponse {
+	return &models.EventTransitionResponse{
+		GUID:       history.GUID,
+		EventGUID:  history.EventGUID,
+		FromStatus: history.FromStatus,
+		ToStatus:   history.ToStatus,
+		Actor:      history.Actor,
+		Reason:     history.Reason,
+		CreatedAt:  history.CreatedAt.Format(time.RFC3339),
+	}
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/multimarket-labs/event-pod-services/database"
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+func TestCanTransition(t *testing.T) {
+	statuses := []string{
+		database.EventStatusDraft,
+		database.EventStatusUpcoming,
+		database.EventStatusLive,
+		database.EventStatusEnded,
+		database.EventStatusResolved,
+		database.EventStatusCancelled,
+	}
+	allowed := map[string]bool{
+		"draft->upcoming":     true,
+		"draft->cancelled":    true,
+		"upcoming->live":      true,
+		"upcoming->draft":     true,
+		"upcoming->cancelled": true,
+		"live->ended":         true,
+		"live->cancelled":     true,
+		"ended->resolved":     true,
+		"ended->cancelled":    true,
+	}
+	for _, from := range statuses {
+		for _, to := range statuses {
+			name := from + "->" + to
+			t.Run(name, func(t *testing.T) {
+				require.Equal(t, allowed[name], canTransition(from, to))
+			})
+		}
+	}
+
+	// 未知状态不能流转，也不能流转为未知状态
+	require.False(t, canTransition("unknown", database.EventStatusDraft))
+	require.False(t, canTransition(database.EventStatusDraft, "unknown"))
+}
+
+func TestTransitionEventRejectsInvalidRequests(t *testing.T) {
+	h := &HandlerSvc{}
+	cases := map[string]struct {
+		req  models.TransitionEventRequest
+		want error
+	}{
+		"missing guid":     {req: models.TransitionEventRequest{Actor: "op", ToStatus: database.EventStatusLive}, want: ErrInvalidRequest},
+		"missing actor":    {req: models.TransitionEventRequest{GUID: "e1", ToStatus: database.EventStatusLive}, want: ErrInvalidRequest},
+		"unknown status":   {req: models.TransitionEventRequest{GUID: "e1", Actor: "op", ToStatus: "closed"}, want: ErrInvalidRequest},
+		"resolve directly": {req: models.TransitionEventRequest{GUID: "e1", Actor: "op", ToStatus: database.EventStatusResolved}, want: ErrIllegalTransition},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := h.TransitionEvent(&tc.req)
+			require.ErrorIs(t, err, tc.want)
+		})
+	}
+}
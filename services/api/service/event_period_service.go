@@ -0,0 +1,373 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/database"
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+var (
+	// ErrEventPeriodNotFound 时间标签不存在
+	ErrEventPeriodNotFound = errors.New("event period not found")
+	// ErrEventPeriodInUse 时间标签仍被事件引用，不能删除或修改所属分类
+	ErrEventPeriodInUse = errors.New("event period is referenced by events")
+)
+
+// CreateEventPeriod 创建时间标签及其各语言的名称
+func (h *HandlerSvc) CreateEventPeriod(req *models.CreateEventPeriodRequest) (*models.EventPeriodDetailResponse, error) {
+	var errs ValidationErrors
+	if req.CategoryGUID == "" {
+		errs.add("category_guid", "is required")
+	}
+	validateTaxonomyCode(&errs, req.Code)
+	validateTaxonomyRemark(&errs, req.Remark)
+	if len(req.Translations) == 0 {
+		errs.add("translations", "at least one language is required")
+	}
+	for _, languageGUID := range slices.Sorted(maps.Keys(req.Translations)) {
+		translation := req.Translations[languageGUID]
+		validateTaxonomyTranslation(&errs, languageGUID, &translation)
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	var response *models.EventPeriodDetailResponse
+	repo := database.NewEventPeriodRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		if err := checkTaxonomyCategory(db, req.CategoryGUID); err != nil {
+			return err
+		}
+		if err := checkTranslationLanguages(db, slices.Collect(maps.Keys(req.Translations))); err != nil {
+			return err
+		}
+		if err := checkEventPeriodCode(db, repo, req.Code, ""); err != nil {
+			return err
+		}
+
+		eventPeriod := &database.EventPeriod{
+			CategoryGUID: req.CategoryGUID,
+			Code:         optionalString(req.Code),
+			Remark:       optionalString(req.Remark),
+			Extra:        database.JSONB(req.Extra),
+		}
+		if err := repo.CreateEventPeriod(db, eventPeriod); err != nil {
+			return err
+		}
+		// is_active 的数据库默认值为 true，GORM 创建时会忽略零值，停用需单独更新
+		if req.IsActive != nil && !*req.IsActive {
+			if err := repo.UpdateEventPeriod(db, eventPeriod.GUID, map[string]interface{}{"is_active": false}); err != nil {
+				return err
+			}
+		}
+
+		for languageGUID, translation := range req.Translations {
+			eventPeriodLang := &database.EventPeriodLanguage{
+				LanguageGUID:    languageGUID,
+				EventPeriodGUID: eventPeriod.GUID,
+				Name:            translation.Name,
+				Description:     translation.Description,
+			}
+			if err := repo.UpsertEventPeriodLanguage(db, eventPeriodLang); err != nil {
+				return err
+			}
+		}
+
+		detail, err := loadEventPeriodDetail(db, repo, eventPeriod.GUID)
+		if err != nil {
+			return err
+		}
+		response = detail
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetEventPeriod 查询时间标签详情（包含全部语言）
+func (h *HandlerSvc) GetEventPeriod(eventPeriodGUID string) (*models.EventPeriodDetailResponse, error) {
+	return loadEventPeriodDetail(h.db.GetGorm(), database.NewEventPeriodRepository(), eventPeriodGUID)
+}
+
+// UpdateEventPeriod 更新时间标签字段和翻译，仍被事件引用时不能修改所属分类
+func (h *HandlerSvc) UpdateEventPeriod(req *models.UpdateEventPeriodRequest) (*models.EventPeriodDetailResponse, error) {
+	if req.GUID == "" {
+		return nil, fmt.Errorf("%w: guid is required", ErrInvalidRequest)
+	}
+
+	var errs ValidationErrors
+	if req.CategoryGUID != nil && *req.CategoryGUID == "" {
+		errs.add("category_guid", "must not be empty")
+	}
+	if req.Code != nil {
+		validateTaxonomyCode(&errs, *req.Code)
+	}
+	if req.Remark != nil {
+		validateTaxonomyRemark(&errs, *req.Remark)
+	}
+	upserts, removed := splitTranslationUpdates(&errs, req.Translations)
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	var response *models.EventPeriodDetailResponse
+	repo := database.NewEventPeriodRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		eventPeriod, err := repo.LockEventPeriod(db, req.GUID)
+		if err != nil {
+			return err
+		}
+		if eventPeriod == nil {
+			return fmt.Errorf("%w: %s", ErrEventPeriodNotFound, req.GUID)
+		}
+
+		columns := make(map[string]interface{})
+		if req.CategoryGUID != nil && *req.CategoryGUID != eventPeriod.CategoryGUID {
+			// 事件要求时间标签与事件属于同一分类，已被引用的时间标签不能换分类
+			count, err := repo.CountEventPeriodEvents(db, eventPeriod.GUID)
+			if err != nil {
+				return err
+			}
+			if count > 0 {
+				return fmt.Errorf("%w: %s is used by %d events, category cannot change", ErrEventPeriodInUse, eventPeriod.GUID, count)
+			}
+			if err := checkTaxonomyCategory(db, *req.CategoryGUID); err != nil {
+				return err
+			}
+			columns["category_guid"] = *req.CategoryGUID
+		}
+		if req.Code != nil {
+			if err := checkEventPeriodCode(db, repo, *req.Code, eventPeriod.GUID); err != nil {
+				return err
+			}
+			columns["code"] = optionalString(*req.Code)
+		}
+		if req.IsActive != nil {
+			columns["is_active"] = *req.IsActive
+		}
+		if req.Remark != nil {
+			columns["remark"] = optionalString(*req.Remark)
+		}
+		if req.Extra != nil {
+			columns["extra"] = database.JSONB(req.Extra)
+		}
+		if err := repo.UpdateEventPeriod(db, eventPeriod.GUID, columns); err != nil {
+			return err
+		}
+
+		if err := checkTranslationLanguages(db, slices.Collect(maps.Keys(upserts))); err != nil {
+			return err
+		}
+		if err := repo.DeleteEventPeriodLanguages(db, eventPeriod.GUID, removed); err != nil {
+			return err
+		}
+		for languageGUID, translation := range upserts {
+			eventPeriodLang := &database.EventPeriodLanguage{
+				LanguageGUID:    languageGUID,
+				EventPeriodGUID: eventPeriod.GUID,
+				Name:            translation.Name,
+				Description:     translation.Description,
+			}
+			if err := repo.UpsertEventPeriodLanguage(db, eventPeriodLang); err != nil {
+				return err
+			}
+		}
+
+		detail, err := loadEventPeriodDetail(db, repo, eventPeriod.GUID)
+		if err != nil {
+			return err
+		}
+		if len(detail.Translations) == 0 {
+			return fmt.Errorf("%w: event period must keep at least one translation", ErrInvalidRequest)
+		}
+		response = detail
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// DeleteEventPeriod 软删除时间标签，仍被事件引用时拒绝删除；翻译保留以便恢复
+func (h *HandlerSvc) DeleteEventPeriod(eventPeriodGUID string) error {
+	repo := database.NewEventPeriodRepository()
+
+	return h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		eventPeriod, err := repo.LockEventPeriod(db, eventPeriodGUID)
+		if err != nil {
+			return err
+		}
+		if eventPeriod == nil {
+			return fmt.Errorf("%w: %s", ErrEventPeriodNotFound, eventPeriodGUID)
+		}
+
+		count, err := repo.CountEventPeriodEvents(db, eventPeriod.GUID)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: %s is used by %d events", ErrEventPeriodInUse, eventPeriod.GUID, count)
+		}
+
+		return repo.DeleteEventPeriod(db, eventPeriod.GUID)
+	})
+}
+
+// RestoreEventPeriod 恢复已删除的时间标签及其翻译，所属分类已删除或业务编码已被其他时间标签使用时拒绝恢复
+func (h *HandlerSvc) RestoreEventPeriod(eventPeriodGUID string) (*models.EventPeriodDetailResponse, error) {
+	var response *models.EventPeriodDetailResponse
+	repo := database.NewEventPeriodRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		eventPeriod, err := repo.LockDeletedEventPeriod(db, eventPeriodGUID)
+		if err != nil {
+			return err
+		}
+		if eventPeriod == nil {
+			return fmt.Errorf("%w: no deleted event period %s", ErrEventPeriodNotFound, eventPeriodGUID)
+		}
+		if err := checkTaxonomyCategory(db, eventPeriod.CategoryGUID); err != nil {
+			return err
+		}
+		if err := checkEventPeriodCode(db, repo, derefString(eventPeriod.Code), eventPeriod.GUID); err != nil {
+			return err
+		}
+		if err := repo.RestoreEventPeriod(db, eventPeriod.GUID); err != nil {
+			return err
+		}
+
+		response, err = loadEventPeriodDetail(db, repo, eventPeriod.GUID)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ListEventPeriods 查询时间标签列表，名称按请求语言返回（缺少时回退到默认语言）
+func (h *HandlerSvc) ListEventPeriods(req *models.ListEventPeriodsRequest) (*models.ListEventPeriodsResponse, error) {
+	if req.LanguageGUID == "" {
+		return nil, fmt.Errorf("%w: language_guid is required", ErrInvalidRequest)
+	}
+
+	db := h.db.GetGorm()
+	repo := database.NewEventPeriodRepository()
+
+	eventPeriods, err := repo.ListEventPeriods(db, req.CategoryGUID, !req.IncludeInactive)
+	if err != nil {
+		return nil, err
+	}
+	languageGUIDs, err := languagePreference(db, req.LanguageGUID)
+	if err != nil {
+		return nil, err
+	}
+	eventPeriodGUIDs := make([]string, 0, len(eventPeriods))
+	for _, eventPeriod := range eventPeriods {
+		eventPeriodGUIDs = append(eventPeriodGUIDs, eventPeriod.GUID)
+	}
+	eventPeriodLangs, err := repo.GetEventPeriodLanguages(db, eventPeriodGUIDs, languageGUIDs)
+	if err != nil {
+		return nil, err
+	}
+	rows := make([]taxonomyLanguage, 0, len(eventPeriodLangs))
+	for _, eventPeriodLang := range eventPeriodLangs {
+		rows = append(rows, taxonomyLanguage{
+			OwnerGUID:    eventPeriodLang.EventPeriodGUID,
+			LanguageGUID: eventPeriodLang.LanguageGUID,
+			Name:         eventPeriodLang.Name,
+			Description:  eventPeriodLang.Description,
+		})
+	}
+	translations := localizedTaxonomyTranslations(rows, languageGUIDs)
+
+	responses := make([]models.EventPeriodResponse, 0, len(eventPeriods))
+	for _, eventPeriod := range eventPeriods {
+		translation := translations[eventPeriod.GUID]
+		responses = append(responses, models.EventPeriodResponse{
+			GUID:         eventPeriod.GUID,
+			CategoryGUID: eventPeriod.CategoryGUID,
+			Code:         derefString(eventPeriod.Code),
+			IsActive:     eventPeriod.IsActive,
+			Name:         translation.Name,
+			Description:  translation.Description,
+		})
+	}
+	return &models.ListEventPeriodsResponse{EventPeriods: responses}, nil
+}
+
+// loadEventPeriodDetail 加载时间标签及其全部语言的翻译
+func loadEventPeriodDetail(db *gorm.DB, repo database.EventPeriodRepository, eventPeriodGUID string) (*models.EventPeriodDetailResponse, error) {
+	eventPeriod, err := repo.GetEventPeriod(db, eventPeriodGUID)
+	if err != nil {
+		return nil, err
+	}
+	if eventPeriod == nil {
+		return nil, fmt.Errorf("%w: %s", ErrEventPeriodNotFound, eventPeriodGUID)
+	}
+	eventPeriodLangs, err := repo.GetEventPeriodLanguages(db, []string{eventPeriod.GUID}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	translations := make(map[string]models.TaxonomyTranslation, len(eventPeriodLangs))
+	for _, eventPeriodLang := range eventPeriodLangs {
+		translations[eventPeriodLang.LanguageGUID] = models.TaxonomyTranslation{
+			Name:        eventPeriodLang.Name,
+			Description: eventPeriodLang.Description,
+		}
+	}
+	return &models.EventPeriodDetailResponse{
+		GUID:         eventPeriod.GUID,
+		CategoryGUID: eventPeriod.CategoryGUID,
+		Code:         derefString(eventPeriod.Code),
+		IsActive:     eventPeriod.IsActive,
+		Remark:       derefString(eventPeriod.Remark),
+		Extra:        eventPeriod.Extra,
+		Translations: translations,
+		CreatedAt:    eventPeriod.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    eventPeriod.UpdatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+// checkEventPeriodCode 校验业务编码未被其他时间标签使用，code 为空时不校验
+func checkEventPeriodCode(db *gorm.DB, repo database.EventPeriodRepository, code, eventPeriodGUID string) error {
+	if code == "" {
+		return nil
+	}
+	existing, err := repo.GetEventPeriodByCode(db, code)
+	if err != nil {
+		return err
+	}
+	var errs ValidationErrors
+	if existing != nil && existing.GUID != eventPeriodGUID {
+		errs.add("code", "code %s is already used by event period %s", code, existing.GUID)
+	}
+	return errs.err()
+}
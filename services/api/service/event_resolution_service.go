@@ -0,0 +1,215 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/database"
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// ResolveEvent 结算事件：为每个子事件标记胜出方向或作废，并将事件流转为已结算
+// 进行中的事件先流转为已结束再结算；已结算的事件需要 re_resolve 和原因才能重新结算
+func (h *HandlerSvc) ResolveEvent(req *models.ResolveEventRequest) (*models.ResolveEventResponse, error) {
+	if err := validateResolveEventRequest(req); err != nil {
+		return nil, err
+	}
+
+	var response *models.ResolveEventResponse
+	repo := database.NewEventRepository()
+	ledger := database.NewLedgerRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		event, err := repo.GetEvent(db, req.GUID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: %s", ErrEventNotFound, req.GUID)
+			}
+			return err
+		}
+
+		if err := checkResolutionCoverage(db, repo, event.GUID, req.SubEvents); err != nil {
+			return err
+		}
+
+		if err := resolveEventStatus(db, repo, event, req); err != nil {
+			return err
+		}
+
+		detail := make([]interface{}, 0, len(req.SubEvents))
+		for _, subReq := range req.SubEvents {
+			resolution := database.SubEventResolutionResolved
+			if subReq.Void {
+				resolution = database.SubEventResolutionVoid
+			}
+			// 先锁定方向，与同一子事件上的下单互斥，避免结算后仍有订单成交
+			if _, err := repo.LockSubEventDirections(db, subReq.SubEventGUID); err != nil {
+				return err
+			}
+			if err := repo.ResolveSubEvent(db, subReq.SubEventGUID, resolution, subReq.WinningDirectionGUIDs); err != nil {
+				return err
+			}
+			if err := settleSubEventPositions(db, ledger, subReq.SubEventGUID, resolution, subReq.WinningDirectionGUIDs); err != nil {
+				return err
+			}
+			detail = append(detail, map[string]interface{}{
+				"sub_event_guid":          subReq.SubEventGUID,
+				"resolution":              resolution,
+				"winning_direction_guids": subReq.WinningDirectionGUIDs,
+			})
+		}
+
+		record := &database.EventResolution{
+			EventGUID:   event.GUID,
+			Actor:       req.Actor,
+			Result:      req.Result,
+			Reason:      req.Reason,
+			IsReResolve: req.ReResolve,
+			Detail:      database.JSONB{"sub_events": detail},
+		}
+		if err := repo.CreateEventResolution(db, record); err != nil {
+			return fmt.Errorf("failed to record event resolution: %w", err)
+		}
+
+		response = &models.ResolveEventResponse{
+			EventGUID:   event.GUID,
+			Status:      database.EventStatusResolved,
+			Result:      req.Result,
+			IsReResolve: req.ReResolve,
+			SubEvents:   req.SubEvents,
+			ResolvedAt:  record.CreatedAt.Format(time.RFC3339),
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// resolveEventStatus 根据事件当前状态流转到已结算
+func resolveEventStatus(db *gorm.DB, repo database.EventRepository, event *database.Event, req *models.ResolveEventRequest) error {
+	columns := map[string]interface{}{"experiment_result": req.Result}
+
+	switch event.Status {
+	case database.EventStatusLive:
+		if _, err := transitionEventStatus(db, repo, event.GUID, database.EventStatusEnded, req.Actor, "resolution", nil); err != nil {
+			return err
+		}
+		_, err := transitionEventStatus(db, repo, event.GUID, database.EventStatusResolved, req.Actor, req.Reason, columns)
+		return err
+	case database.EventStatusEnded:
+		_, err := transitionEventStatus(db, repo, event.GUID, database.EventStatusResolved, req.Actor, req.Reason, columns)
+		return err
+	case database.EventStatusResolved:
+		if !req.ReResolve {
+			return fmt.Errorf("%w: set re_resolve with a reason to resolve %s again", ErrAlreadyResolved, event.GUID)
+		}
+		// 重新结算不改变状态，仅更新结算结果并留下流转记录
+		updated, err := repo.TransitionEventStatus(db, event.GUID, event.Status, event.Status, columns)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return fmt.Errorf("%w: status of %s changed concurrently", ErrEventConflict, event.GUID)
+		}
+		history := &database.EventStatusHistory{
+			EventGUID:  event.GUID,
+			FromStatus: event.Status,
+			ToStatus:   event.Status,
+			Actor:      req.Actor,
+			Reason:     req.Reason,
+		}
+		if err := repo.CreateEventStatusHistory(db, history); err != nil {
+			return fmt.Errorf("failed to record event status history: %w", err)
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: cannot resolve event in status %s", ErrIllegalTransition, event.Status)
+}
+
+// checkResolutionCoverage 校验请求覆盖事件下全部子事件，且胜出方向属于对应子事件
+func checkResolutionCoverage(db *gorm.DB, repo database.EventRepository, eventGUID string, subReqs []models.ResolveSubEventRequest) error {
+	subEvents, err := repo.GetSubEventsByEventGUID(db, eventGUID)
+	if err != nil {
+		return err
+	}
+
+	requested := make(map[string]models.ResolveSubEventRequest, len(subReqs))
+	for _, subReq := range subReqs {
+		requested[subReq.SubEventGUID] = subReq
+	}
+	if len(requested) != len(subEvents) {
+		return fmt.Errorf("%w: expected results for %d sub events, got %d", ErrInvalidRequest, len(subEvents), len(requested))
+	}
+
+	for _, subEvent := range subEvents {
+		subReq, ok := requested[subEvent.GUID]
+		if !ok {
+			return fmt.Errorf("%w: missing result for sub event %s", ErrInvalidRequest, subEvent.GUID)
+		}
+
+		directions, err := repo.GetSubEventDirections(db, subEvent.GUID)
+		if err != nil {
+			return err
+		}
+		owned := make(map[string]bool, len(directions))
+		for _, direction := range directions {
+			owned[direction.GUID] = true
+		}
+		for _, directionGUID := range subReq.WinningDirectionGUIDs {
+			if !owned[directionGUID] {
+				return fmt.Errorf("%w: direction %s does not belong to sub event %s", ErrInvalidRequest, directionGUID, subEvent.GUID)
+			}
+		}
+	}
+	return nil
+}
+
+// validateResolveEventRequest 校验结算请求
+func validateResolveEventRequest(req *models.ResolveEventRequest) error {
+	if req.GUID == "" {
+		return fmt.Errorf("%w: guid is required", ErrInvalidRequest)
+	}
+	if req.Actor == "" {
+		return fmt.Errorf("%w: actor is required", ErrInvalidRequest)
+	}
+	if req.Result == "" {
+		return fmt.Errorf("%w: result is required", ErrInvalidRequest)
+	}
+	if req.ReResolve && req.Reason == "" {
+		return fmt.Errorf("%w: reason is required when re_resolve is set", ErrInvalidRequest)
+	}
+	if len(req.SubEvents) == 0 {
+		return fmt.Errorf("%w: sub_events is required", ErrInvalidRequest)
+	}
+
+	subEventGUIDs := make([]string, 0, len(req.SubEvents))
+	for i, subReq := range req.SubEvents {
+		if subReq.SubEventGUID == "" {
+			return fmt.Errorf("%w: sub_events[%d].sub_event_guid is required", ErrInvalidRequest, i)
+		}
+		if subReq.Void && len(subReq.WinningDirectionGUIDs) > 0 {
+			return fmt.Errorf("%w: sub_events[%d] is void and cannot have winning directions", ErrInvalidRequest, i)
+		}
+		if !subReq.Void && len(subReq.WinningDirectionGUIDs) == 0 {
+			return fmt.Errorf("%w: sub_events[%d] needs at least one winning direction or void", ErrInvalidRequest, i)
+		}
+		if hasDuplicates(subReq.WinningDirectionGUIDs) {
+			return fmt.Errorf("%w: sub_events[%d] has duplicate winning directions", ErrInvalidRequest, i)
+		}
+		subEventGUIDs = append(subEventGUIDs, subReq.SubEventGUID)
+	}
+	if hasDuplicates(subEventGUIDs) {
+		return fmt.Errorf("%w: sub_events contains duplicate sub_event_guid", ErrInvalidRequest)
+	}
+	return nil
+}
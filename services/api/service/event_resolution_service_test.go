@@ -0,0 +1,89 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// validResolveEventRequest 返回一个能通过校验的结算请求，用例在此基础上修改
+func validResolveEventRequest() models.ResolveEventRequest {
+	return models.ResolveEventRequest{
+		GUID:   "e1",
+		Actor:  "op",
+		Result: "home win",
+		SubEvents: []models.ResolveSubEventRequest{
+			{SubEventGUID: "s1", WinningDirectionGUIDs: []string{"d1"}},
+			{SubEventGUID: "s2", Void: true},
+		},
+	}
+}
+
+func TestValidateResolveEventRequest(t *testing.T) {
+	cases := map[string]struct {
+		mutate  func(req *models.ResolveEventRequest)
+		wantErr string
+	}{
+		"valid": {
+			mutate: func(req *models.ResolveEventRequest) {},
+		},
+		"re-resolve with reason": {
+			mutate: func(req *models.ResolveEventRequest) { req.ReResolve, req.Reason = true, "wrong score" },
+		},
+		"missing guid": {
+			mutate:  func(req *models.ResolveEventRequest) { req.GUID = "" },
+			wantErr: "guid is required",
+		},
+		"missing actor": {
+			mutate:  func(req *models.ResolveEventRequest) { req.Actor = "" },
+			wantErr: "actor is required",
+		},
+		"missing result": {
+			mutate:  func(req *models.ResolveEventRequest) { req.Result = "" },
+			wantErr: "result is required",
+		},
+		"re-resolve without reason": {
+			mutate:  func(req *models.ResolveEventRequest) { req.ReResolve = true },
+			wantErr: "reason is required when re_resolve is set",
+		},
+		"no sub events": {
+			mutate:  func(req *models.ResolveEventRequest) { req.SubEvents = nil },
+			wantErr: "sub_events is required",
+		},
+		"missing sub event guid": {
+			mutate:  func(req *models.ResolveEventRequest) { req.SubEvents[1].SubEventGUID = "" },
+			wantErr: "sub_events[1].sub_event_guid is required",
+		},
+		"void with winners": {
+			mutate:  func(req *models.ResolveEventRequest) { req.SubEvents[1].WinningDirectionGUIDs = []string{"d3"} },
+			wantErr: "sub_events[1] is void and cannot have winning directions",
+		},
+		"no winner and not void": {
+			mutate:  func(req *models.ResolveEventRequest) { req.SubEvents[0].WinningDirectionGUIDs = nil },
+			wantErr: "sub_events[0] needs at least one winning direction or void",
+		},
+		"duplicate winners": {
+			mutate:  func(req *models.ResolveEventRequest) { req.SubEvents[0].WinningDirectionGUIDs = []string{"d1", "d1"} },
+			wantErr: "sub_events[0] has duplicate winning directions",
+		},
+		"duplicate sub events": {
+			mutate:  func(req *models.ResolveEventRequest) { req.SubEvents[1] = req.SubEvents[0] },
+			wantErr: "sub_events contains duplicate sub_event_guid",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			req := validResolveEventRequest()
+			tc.mutate(&req)
+			err := validateResolveEventRequest(&req)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, ErrInvalidRequest)
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package service
+
+import "time"
+
+// unixToTime 将 Unix 时间戳（秒）转换为 UTC 时间，nil 或 0 表示未设置
+func unixToTime(seconds *int64) *time.Time {
+	if seconds == nil || *seconds == 0 {
+		return nil
+	}
+	t := time.Unix(*seconds, 0).UTC()
+	return &t
+}
+
+// timeToUnix 将时间转换为 Unix 时间戳（秒），未设置时返回 nil
+func timeToUnix(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+	seconds := t.Unix()
+	return &seconds
+}
+
+// openTimeString 生成与 open_at 对应的 open_time 字符串（RFC3339 UTC），兼容按 open_time 过滤和排序
+func openTimeString(openAt *time.Time) string {
+	if openAt == nil {
+		return ""
+	}
+	return openAt.UTC().Format(time.RFC3339)
+}
+
+// validateEventSchedule 校验开盘、开始、收盘时间的先后：open_at ≤ start_at < close_at，open_at < close_at
+func validateEventSchedule(errs *ValidationErrors, openAt, startAt, closeAt *time.Time) {
+	if openAt != nil && startAt != nil && startAt.Before(*openAt) {
+		errs.add("start_at", "must not be earlier than open_at")
+	}
+	if closeAt != nil {
+		if openAt != nil && !closeAt.After(*openAt) {
+			errs.add("close_at", "must be later than open_at")
+		}
+		if startAt != nil && !closeAt.After(*startAt) {
+			errs.add("close_at", "must be later than start_at")
+		}
+	}
+}
+
+// validateUnix 校验可选的 Unix 时间戳不为负数
+func validateUnix(errs *ValidationErrors, field string, seconds *int64) {
+	if seconds != nil && *seconds < 0 {
+		errs.add(field, "must be a unix timestamp in seconds")
+	}
+}
@@ -0,0 +1,256 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/database"
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// ErrScoreUpdateRejected 事件不接受比分推送（非运动类、未上线或已结束）
+var ErrScoreUpdateRejected = errors.New("score update rejected")
+
+// 运动项目
+const (
+	SportBasketball       = "basketball"
+	SportAmericanFootball = "american_football"
+	SportSoccer           = "soccer"
+	SportIceHockey        = "ice_hockey"
+	SportTennis           = "tennis"
+)
+
+// sportStages 各运动项目的比赛阶段，按比赛进行的顺序排列
+// 阶段只能停留或前进，可以跳过（例如没有加时直接 FT）；OT 可覆盖多个加时
+var sportStages = map[string][]string{
+	SportBasketball:       {"Q1", "Q2", "HT", "Q3", "Q4", "OT", "FT"},
+	SportAmericanFootball: {"Q1", "Q2", "HT", "Q3", "Q4", "OT", "FT"},
+	SportSoccer:           {"H1", "HT", "H2", "ET1", "ET2", "PEN", "FT"},
+	SportIceHockey:        {"P1", "P2", "P3", "OT", "SO", "FT"},
+	SportTennis:           {"S1", "S2", "S3", "S4", "S5", "FT"},
+}
+
+// scoreUpdateStatuses 接受比分推送的事件状态
+var scoreUpdateStatuses = []string{database.EventStatusUpcoming, database.EventStatusLive}
+
+// maxScoreSourceLength 比分源名称的最大长度，与 event_score_history.source 一致
+const maxScoreSourceLength = 100
+
+// initialStage 运动项目的第一个比赛阶段，未设置运动项目时沿用默认的 Q1
+func initialStage(sport string) string {
+	if stages, ok := sportStages[sport]; ok {
+		return stages[0]
+	}
+	return "Q1"
+}
+
+// canAdvanceStage 判断比赛阶段能否从 from 变为 to：to 必须是该项目的阶段，且不早于 from
+// from 不属于该项目（例如设置运动项目前的默认 Q1）时可以进入任意阶段
+func canAdvanceStage(sport, from, to string) bool {
+	stages := sportStages[sport]
+	toIndex := slices.Index(stages, to)
+	if toIndex < 0 {
+		return false
+	}
+	return toIndex >= slices.Index(stages, from)
+}
+
+// validateSport 校验运动项目，空字符串表示未设置
+func validateSport(errs *ValidationErrors, field, sport string, isSports bool) {
+	if sport == "" {
+		return
+	}
+	if !isSports {
+		errs.add(field, "must be empty for non-sports events")
+		return
+	}
+	if _, ok := sportStages[sport]; !ok {
+		errs.add(field, "unknown sport %q", sport)
+	}
+}
+
+// validateScore 校验得分为不超过 UINT256 的非负整数，并去掉前导零
+func validateScore(errs *ValidationErrors, field string, score *string) {
+	if score == nil {
+		return
+	}
+	value, ok := new(big.Int).SetString(*score, 10)
+	if !ok || strings.ContainsAny(*score, "+-") || value.BitLen() > 256 {
+		errs.add(field, "must be a non-negative integer")
+		return
+	}
+	*score = value.String()
+}
+
+// UpdateEventScore 比分源推送运动类事件的比分和比赛阶段
+// 逻辑流程：
+// 1. 校验得分格式和比分源
+// 2. 校验事件为运动类、处于预热或进行中，阶段按运动项目的顺序前进
+// 3. 以 updated_at 作为乐观锁更新比分和阶段，并写入变更记录；比分和阶段都未变化时不做修改
+func (h *HandlerSvc) UpdateEventScore(req *models.UpdateEventScoreRequest) (*models.EventScoreResponse, error) {
+	if req.GUID == "" {
+		return nil, fmt.Errorf("%w: guid is required", ErrInvalidRequest)
+	}
+
+	if req.MainScore == nil && req.ClusterScore == nil && req.Stage == nil {
+		return nil, fmt.Errorf("%w: at least one of main_score, cluster_score or stage is required", ErrInvalidRequest)
+	}
+
+	var errs ValidationErrors
+	validateScore(&errs, "main_score", req.MainScore)
+	validateScore(&errs, "cluster_score", req.ClusterScore)
+	if req.Stage != nil && *req.Stage == "" {
+		errs.add("stage", "must not be empty")
+	}
+	if req.Source == "" {
+		errs.add("source", "is required")
+	} else if len(req.Source) > maxScoreSourceLength {
+		errs.add("source", "must be at most %d characters", maxScoreSourceLength)
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	var response *models.EventScoreResponse
+	repo := database.NewEventRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		event, err := repo.GetEvent(db, req.GUID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: %s", ErrEventNotFound, req.GUID)
+			}
+			return err
+		}
+		if !event.IsSports {
+			return fmt.Errorf("%w: event %s is not a sports event", ErrScoreUpdateRejected, event.GUID)
+		}
+		if !slices.Contains(scoreUpdateStatuses, event.Status) {
+			return fmt.Errorf("%w: event %s is %s", ErrScoreUpdateRejected, event.GUID, event.Status)
+		}
+
+		history := &database.EventScoreHistory{
+			EventGUID:        event.GUID,
+			FromMainScore:    event.MainScore,
+			FromClusterScore: event.ClusterScore,
+			FromStage:        event.Stage,
+			MainScore:        event.MainScore,
+			ClusterScore:     event.ClusterScore,
+			Stage:            event.Stage,
+			Source:           req.Source,
+		}
+		if req.MainScore != nil {
+			history.MainScore = *req.MainScore
+		}
+		if req.ClusterScore != nil {
+			history.ClusterScore = *req.ClusterScore
+		}
+		if req.Stage != nil && *req.Stage != event.Stage {
+			if event.Sport == "" {
+				return fmt.Errorf("%w: event %s has no sport, set it before pushing stages", ErrInvalidRequest, event.GUID)
+			}
+			if !canAdvanceStage(event.Sport, event.Stage, *req.Stage) {
+				return fmt.Errorf("%w: %s stage %s → %s", ErrIllegalTransition, event.Sport, event.Stage, *req.Stage)
+			}
+			history.Stage = *req.Stage
+		}
+
+		response = &models.EventScoreResponse{
+			EventGUID:    event.GUID,
+			Sport:        event.Sport,
+			MainScore:    history.MainScore,
+			ClusterScore: history.ClusterScore,
+			Stage:        history.Stage,
+			UpdatedAt:    event.UpdatedAt.Format(time.RFC3339),
+		}
+		if history.MainScore == history.FromMainScore && history.ClusterScore == history.FromClusterScore &&
+			history.Stage == history.FromStage {
+			return nil
+		}
+
+		updated, err := repo.UpdateEventWithVersion(db, event.GUID, event.UpdatedAt, map[string]interface{}{
+			"main_score":    history.MainScore,
+			"cluster_score": history.ClusterScore,
+			"stage":         history.Stage,
+		})
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return fmt.Errorf("%w: %s", ErrEventConflict, event.GUID)
+		}
+		if err := repo.CreateEventScoreHistory(db, history); err != nil {
+			return err
+		}
+
+		event, err = repo.GetEvent(db, event.GUID)
+		if err != nil {
+			return err
+		}
+		response.UpdatedAt = event.UpdatedAt.Format(time.RFC3339)
+		response.Change = toEventScoreHistoryResponse(*history)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ListEventScoreHistory 查询事件的比分变更记录
+func (h *HandlerSvc) ListEventScoreHistory(eventGUID string) (*models.ListEventScoreHistoryResponse, error) {
+	repo := database.NewEventRepository()
+	db := h.db.GetGorm()
+
+	event, err := repo.GetEvent(db, eventGUID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrEventNotFound, eventGUID)
+		}
+		return nil, err
+	}
+
+	histories, err := repo.ListEventScoreHistory(db, event.GUID)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.EventScoreHistoryResponse, 0, len(histories))
+	for _, history := range histories {
+		responses = append(responses, *toEventScoreHistoryResponse(history))
+	}
+
+	return &models.ListEventScoreHistoryResponse{
+		EventGUID:    event.GUID,
+		Sport:        event.Sport,
+		MainScore:    event.MainScore,
+		ClusterScore: event.ClusterScore,
+		Stage:        event.Stage,
+		History:      responses,
+	}, nil
+}
+
+// toEventScoreHistoryResponse 将比分变更记录转换为响应结构
+func toEventScoreHistoryResponse(history database.EventScoreHistory) *models.EventScoreHistoryResponse {
+	return &models.EventScoreHistoryResponse{
+		GUID:             history.GUID,
+		FromMainScore:    history.FromMainScore,
+		FromClusterScore: history.FromClusterScore,
+		FromStage:        history.FromStage,
+		MainScore:        history.MainScore,
+		ClusterScore:     history.ClusterScore,
+		Stage:            history.Stage,
+		Source:           history.Source,
+		CreatedAt:        history.CreatedAt.Format(time.RFC3339),
+	}
+}
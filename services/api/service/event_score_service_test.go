@@ -0,0 +1,155 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+func TestInitialStage(t *testing.T) {
+	require.Equal(t, "Q1", initialStage(SportBasketball))
+	require.Equal(t, "H1", initialStage(SportSoccer))
+	require.Equal(t, "P1", initialStage(SportIceHockey))
+	require.Equal(t, "S1", initialStage(SportTennis))
+	require.Equal(t, "Q1", initialStage(""))
+	require.Equal(t, "Q1", initialStage("curling"))
+}
+
+func TestCanAdvanceStage(t *testing.T) {
+	cases := map[string]struct {
+		sport, from, to string
+		want            bool
+	}{
+		"stay in stage":           {sport: SportBasketball, from: "Q2", to: "Q2", want: true},
+		"next stage":              {sport: SportBasketball, from: "Q2", to: "HT", want: true},
+		"skip overtime":           {sport: SportBasketball, from: "Q4", to: "FT", want: true},
+		"go back":                 {sport: SportBasketball, from: "Q3", to: "HT", want: false},
+		"leave full time":         {sport: SportBasketball, from: "FT", to: "OT", want: false},
+		"stage of another sport":  {sport: SportBasketball, from: "Q1", to: "H2", want: false},
+		"soccer extra time":       {sport: SportSoccer, from: "H2", to: "ET1", want: true},
+		"soccer penalties to FT":  {sport: SportSoccer, from: "PEN", to: "FT", want: true},
+		"hockey shootout back":    {sport: SportIceHockey, from: "SO", to: "OT", want: false},
+		"tennis skip sets":        {sport: SportTennis, from: "S1", to: "S3", want: true},
+		"default stage to soccer": {sport: SportSoccer, from: "Q1", to: "H1", want: true},
+		"default stage to any":    {sport: SportTennis, from: "Q1", to: "S4", want: true},
+		"empty from":              {sport: SportIceHockey, from: "", to: "P2", want: true},
+		"unknown sport":           {sport: "curling", from: "Q1", to: "Q2", want: false},
+		"unset sport":             {sport: "", from: "Q1", to: "Q1", want: false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, tc.want, canAdvanceStage(tc.sport, tc.from, tc.to))
+		})
+	}
+}
+
+func TestValidateSport(t *testing.T) {
+	cases := map[string]struct {
+		sport    string
+		isSports bool
+		wantErr  string
+	}{
+		"unset":              {sport: "", isSports: false},
+		"unset for sports":   {sport: "", isSports: true},
+		"known sport":        {sport: SportIceHockey, isSports: true},
+		"non-sports event":   {sport: SportSoccer, isSports: false, wantErr: "must be empty for non-sports events"},
+		"unknown sport":      {sport: "curling", isSports: true, wantErr: `unknown sport "curling"`},
+		"case sensitive":     {sport: "Soccer", isSports: true, wantErr: `unknown sport "Soccer"`},
+		"not trimmed":        {sport: " soccer", isSports: true, wantErr: "unknown sport"},
+		"alias not accepted": {sport: "football", isSports: true, wantErr: "unknown sport"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var errs ValidationErrors
+			validateSport(&errs, "sport", tc.sport, tc.isSports)
+			if tc.wantErr == "" {
+				require.NoError(t, errs.err())
+				return
+			}
+			require.Len(t, errs, 1)
+			require.Equal(t, "sport", errs[0].Field)
+			require.Contains(t, errs[0].Message, tc.wantErr)
+		})
+	}
+}
+
+func TestValidateScore(t *testing.T) {
+	cases := map[string]struct {
+		score string
+		want  string
+		ok    bool
+	}{
+		"zero":               {score: "0", want: "0", ok: true},
+		"leading zeros":      {score: "007", want: "7", ok: true},
+		"max uint256":        {score: "115792089237316195423570985008687907853269984665640564039457584007913129639935", want: "115792089237316195423570985008687907853269984665640564039457584007913129639935", ok: true},
+		"above uint256":      {score: "115792089237316195423570985008687907853269984665640564039457584007913129639936"},
+		"negative":           {score: "-1"},
+		"explicit plus":      {score: "+1"},
+		"decimal":            {score: "1.5"},
+		"empty":              {score: ""},
+		"not a number":       {score: "ten"},
+		"surrounding spaces": {score: " 1"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			var errs ValidationErrors
+			score := tc.score
+			validateScore(&errs, "main_score", &score)
+			if !tc.ok {
+				require.Len(t, errs, 1)
+				require.Equal(t, "main_score", errs[0].Field)
+				return
+			}
+			require.NoError(t, errs.err())
+			require.Equal(t, tc.want, score)
+		})
+	}
+
+	var errs ValidationErrors
+	validateScore(&errs, "main_score", nil)
+	require.NoError(t, errs.err())
+}
+
+func TestUpdateEventScoreRejectsInvalidRequests(t *testing.T) {
+	h := &HandlerSvc{}
+	score := func(s string) *string { return &s }
+	cases := map[string]struct {
+		req     models.UpdateEventScoreRequest
+		wantErr string
+	}{
+		"missing guid": {
+			req:     models.UpdateEventScoreRequest{MainScore: score("1"), Source: "feed"},
+			wantErr: "guid is required",
+		},
+		"nothing to update": {
+			req:     models.UpdateEventScoreRequest{GUID: "e1", Source: "feed"},
+			wantErr: "at least one of main_score, cluster_score or stage is required",
+		},
+		"invalid score": {
+			req:     models.UpdateEventScoreRequest{GUID: "e1", ClusterScore: score("-3"), Source: "feed"},
+			wantErr: "cluster_score: must be a non-negative integer",
+		},
+		"empty stage": {
+			req:     models.UpdateEventScoreRequest{GUID: "e1", Stage: score(""), Source: "feed"},
+			wantErr: "stage: must not be empty",
+		},
+		"missing source": {
+			req:     models.UpdateEventScoreRequest{GUID: "e1", Stage: score("Q2")},
+			wantErr: "source: is required",
+		},
+		"source too long": {
+			req:     models.UpdateEventScoreRequest{GUID: "e1", Stage: score("Q2"), Source: strings.Repeat("s", maxScoreSourceLength+1)},
+			wantErr: "source: must be at most 100 characters",
+		},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := h.UpdateEventScore(&tc.req)
+			require.ErrorIs(t, err, ErrInvalidRequest)
+			require.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+}
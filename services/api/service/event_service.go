@@ -1,22 +1,64 @@
 package service
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"strings"
 	"time"
 
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/common/decimal"
 	"github.com/multimarket-labs/event-pod-services/database"
 	"github.com/multimarket-labs/event-pod-services/services/api/models"
 )
 
+var (
+	// ErrEventNotFound 事件不存在或缺少请求语言的翻译
+	ErrEventNotFound = errors.New("event not found")
+	// ErrSubEventNotFound 子事件不存在
+	ErrSubEventNotFound = errors.New("sub event not found")
+	// ErrInvalidCursor 分页游标无法解析
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrInvalidFilter 列表过滤或排序参数不合法
+	ErrInvalidFilter = errors.New("invalid filter")
+	// ErrInvalidRequest 请求参数不合法
+	ErrInvalidRequest = errors.New("invalid request")
+	// ErrEventConflict 事件已被他人修改（updated_at 不一致）
+	ErrEventConflict = errors.New("event was modified by someone else")
+	// ErrIllegalTransition 不允许的事件状态流转
+	ErrIllegalTransition = errors.New("illegal event status transition")
+	// ErrAlreadyResolved 事件已结算，重新结算需显式声明并说明原因
+	ErrAlreadyResolved = errors.New("event already resolved")
+	// ErrIdempotencyKeyReused 同一个 Idempotency-Key 被用于不同的请求
+	ErrIdempotencyKeyReused = errors.New("idempotency key reused with a different request")
+)
+
 // CreateEvent 创建新的预测事件（基于新表结构）
 // 逻辑流程：
-// 1. 开启事务
+// 1. 开启事务，带 Idempotency-Key 时占用幂等键，重复请求直接返回首次的响应
 // 2. 插入 event 表
-// 3. 插入 event_language 表
-// 4. 插入 sub_event 表
+// 3. 按语言插入 event_language 表（引用的分类、生态、时间标签、运动队和语言需存在且已启用）
+// 4. 插入 sub_event 表及 sub_event_language 表
 // 5. 插入 sub_event_direction 表
-// 6. 提交事务
+// 6. 保存幂等响应并提交事务
 func (h *HandlerSvc) CreateEvent(req *models.CreateEventRequest) (*models.CreateEventResponse, error) {
+	// 校验会规范化请求，因此先按原始请求计算幂等哈希
+	var hash string
+	if req.IdempotencyKey != "" {
+		if len(req.IdempotencyKey) > maxIdempotencyKeyLength {
+			return nil, fmt.Errorf("%w: Idempotency-Key must be at most %d characters", ErrInvalidRequest, maxIdempotencyKeyLength)
+		}
+		var err error
+		if hash, err = requestHash(req); err != nil {
+			return nil, err
+		}
+	}
+
 	// 验证请求
 	if err := h.validateCreateEventNewRequest(req); err != nil {
 		return nil, err
@@ -29,125 +71,26 @@ func (h *HandlerSvc) CreateEvent(req *models.CreateEventRequest) (*models.Create
 	err := h.db.Transaction(func(txDB *database.DB) error {
 		db := txDB.GetGorm()
 
-		// Step 1: 创建 Event（GUID 由数据库自动生成）
-		event := &database.Event{
-			CategoryGUID:         req.CategoryGUID,
-			EcosystemGUID:        req.EcosystemGUID,
-			EventPeriodGUID:      req.EventPeriodGUID,
-			MainTeamGroupGUID:    req.MainTeamGroupGUID,
-			ClusterTeamGroupGUID: req.ClusterTeamGroupGUID,
-			MainScore:            "0", // 初始分数为 0
-			ClusterScore:         "0", // 初始分数为 0
-			Logo:                 req.Logo,
-			OrderType:            0,   // 默认为热门话题
-			OrderNum:             "0", // 初始订单数为 0
-			OpenTime:             "",  // 开盘时间稍后设置
-			TradeVolume:          0,   // 初始交易量为 0
-			ExperimentResult:     "",  // 实验结果为空
-			Info:                 database.JSONB{},
-			IsOnline:             false, // 默认不上线
-			IsLive:               1,     // 默认为未来事件
-			IsSports:             req.IsSports,
-			Stage:                "Q1", // 默认阶段
-		}
-
-		if err := repo.CreateEvent(db, event); err != nil {
-			return fmt.Errorf("failed to create event: %w", err)
-		}
-
-		// 获取数据库生成的 GUID
-		if err := db.Where("category_guid = ? AND ecosystem_guid = ? AND event_period_guid = ?",
-			req.CategoryGUID, req.EcosystemGUID, req.EventPeriodGUID).
-			Order("created_at DESC").First(event).Error; err != nil {
-			return fmt.Errorf("failed to retrieve created event: %w", err)
-		}
-
-		eventGUID := event.GUID
-
-		// Step 2: 创建 EventLanguage
-		eventLang := &database.EventLanguage{
-			EventGUID:    eventGUID,
-			LanguageGUID: req.LanguageGUID,
-			Title:        req.Title,
-			Rules:        req.Rules,
-		}
-
-		if err := repo.CreateEventLanguage(db, eventLang); err != nil {
-			return fmt.Errorf("failed to create event language: %w", err)
-		}
-
-		// Step 3 & 4: 创建 SubEvent 和 SubEventDirection
-		var subEventResponses []models.SubEventResponse
-		for _, subEventReq := range req.SubEvents {
-			// 创建子事件
-			subEvent := &database.SubEvent{
-				ParentEventGUID: eventGUID,
-				Title:           subEventReq.Title,
-				Logo:            req.Logo, // 使用事件的 Logo
-				TradeVolume:     0,
-			}
-
-			if err := repo.CreateSubEvent(db, subEvent); err != nil {
-				return fmt.Errorf("failed to create sub event: %w", err)
-			}
-
-			// 获取数据库生成的 GUID
-			if err := db.Where("parent_event_guid = ? AND title = ?", eventGUID, subEventReq.Title).
-				Order("created_at DESC").First(subEvent).Error; err != nil {
-				return fmt.Errorf("failed to retrieve created sub event: %w", err)
+		if req.IdempotencyKey != "" {
+			replayed, err := claimIdempotencyKey(db, idempotencyScopeCreateEvent, req.IdempotencyKey, hash, &response)
+			if err != nil {
+				return err
 			}
-
-			subEventGUID := subEvent.GUID
-
-			// 创建子事件方向
-			var directionResponses []models.SubEventDirectionResponse
-			for _, dirReq := range subEventReq.Directions {
-				direction := &database.SubEventDirection{
-					SubEventGUID: subEventGUID,
-					Direction:    dirReq.Direction,
-					Chance:       dirReq.Chance,
-					NewAskPrice:  "0",
-					NewBidPrice:  "0",
-					Info:         database.JSONB{},
-				}
-
-				if err := repo.CreateSubEventDirection(db, direction); err != nil {
-					return fmt.Errorf("failed to create sub event direction: %w", err)
-				}
-
-				// 获取数据库生成的 GUID
-				if err := db.Where("sub_event_guid = ? AND direction = ?", subEventGUID, dirReq.Direction).
-					Order("created_at DESC").First(direction).Error; err != nil {
-					return fmt.Errorf("failed to retrieve created direction: %w", err)
-				}
-
-				directionResponses = append(directionResponses, models.SubEventDirectionResponse{
-					GUID:        direction.GUID,
-					Direction:   direction.Direction,
-					Chance:      direction.Chance,
-					NewAskPrice: direction.NewAskPrice,
-					NewBidPrice: direction.NewBidPrice,
-				})
+			if replayed {
+				response.Replayed = true
+				return nil
 			}
-
-			subEventResponses = append(subEventResponses, models.SubEventResponse{
-				GUID:       subEvent.GUID,
-				Title:      subEvent.Title,
-				Logo:       subEvent.Logo,
-				Directions: directionResponses,
-			})
 		}
 
-		// 构建响应
-		response = &models.CreateEventResponse{
-			GUID:      eventGUID,
-			Title:     req.Title,
-			Rules:     req.Rules,
-			Logo:      req.Logo,
-			SubEvents: subEventResponses,
-			CreatedAt: event.CreatedAt.Format(time.RFC3339),
+		created, err := createEvent(db, repo, req)
+		if err != nil {
+			return err
 		}
+		response = created
 
+		if req.IdempotencyKey != "" {
+			return saveIdempotencyResponse(db, idempotencyScopeCreateEvent, req.IdempotencyKey, response)
+		}
 		return nil
 	})
 
@@ -158,6 +101,90 @@ func (h *HandlerSvc) CreateEvent(req *models.CreateEventRequest) (*models.Create
 	return response, nil
 }
 
+// createEvent 在事务中创建已通过基本校验的事件：校验引用数据后插入 event、event_language、sub_event 及方向
+func createEvent(db *gorm.DB, repo database.EventRepository, req *models.CreateEventRequest) (*models.CreateEventResponse, error) {
+	// 先对生态加排他锁再校验引用：校验对生态加共享锁，之后再更新事件数会升级为排他锁，
+	// 并发创建同一生态下的事件时可能互相等待；生态不存在时不锁定任何行，由校验返回错误
+	ecosystemRepo := database.NewEcosystemRepository()
+	if _, err := ecosystemRepo.LockEcosystem(db, req.EcosystemGUID); err != nil {
+		return nil, err
+	}
+
+	// 校验引用的分类、生态、时间标签、运动队和语言
+	if err := validateCreateEventReferences(db, req); err != nil {
+		return nil, err
+	}
+	if err := ecosystemRepo.AdjustEcosystemEventNum(db, req.EcosystemGUID, 1); err != nil {
+		return nil, err
+	}
+
+	// Step 1: 创建 Event（GUID 由数据库自动生成）
+	openAt := unixToTime(req.OpenAt)
+	event := &database.Event{
+		CategoryGUID:         req.CategoryGUID,
+		EcosystemGUID:        req.EcosystemGUID,
+		EventPeriodGUID:      req.EventPeriodGUID,
+		MainTeamGroupGUID:    req.MainTeamGroupGUID,
+		ClusterTeamGroupGUID: req.ClusterTeamGroupGUID,
+		MainScore:            "0", // 初始分数为 0
+		ClusterScore:         "0", // 初始分数为 0
+		Logo:                 req.Logo,
+		OrderType:            0,   // 默认为热门话题
+		OrderNum:             "0", // 初始订单数为 0
+		OpenTime:             openTimeString(openAt),
+		OpenAt:               openAt,
+		StartAt:              unixToTime(req.StartAt),
+		CloseAt:              unixToTime(req.CloseAt),
+		TradeVolume:          decimal.Zero(), // 初始交易量为 0
+		ExperimentResult:     "",             // 实验结果为空
+		Info:                 database.JSONB{},
+		IsOnline:             false, // 默认不上线
+		IsLive:               1,     // 默认为未来事件
+		IsSports:             req.IsSports,
+		Sport:                req.Sport,
+		Stage:                initialStage(req.Sport),   // 运动项目的第一个阶段，未设置时为 Q1
+		Status:               database.EventStatusDraft, // 新建事件为草稿
+	}
+
+	// GUID 由 INSERT ... RETURNING 直接回填，不再按条件回查
+	if err := repo.CreateEvent(db, event); err != nil {
+		return nil, fmt.Errorf("failed to create event: %w", err)
+	}
+
+	eventGUID := event.GUID
+
+	// Step 2: 为每种语言创建 EventLanguage
+	for _, languageGUID := range slices.Sorted(maps.Keys(req.Translations)) {
+		translation := req.Translations[languageGUID]
+		eventLang := &database.EventLanguage{
+			EventGUID:    eventGUID,
+			LanguageGUID: languageGUID,
+			Title:        translation.Title,
+			Rules:        translation.Rules,
+		}
+
+		if err := repo.CreateEventLanguage(db, eventLang); err != nil {
+			return nil, fmt.Errorf("failed to create event language: %w", err)
+		}
+	}
+
+	// Step 3 & 4: 创建 SubEvent 和 SubEventDirection
+	subEventResponses, err := createSubEvents(db, repo, eventGUID, req.Logo, req.SubEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	// 构建响应
+	return &models.CreateEventResponse{
+		GUID:      eventGUID,
+		Title:     req.Title,
+		Rules:     req.Rules,
+		Logo:      req.Logo,
+		SubEvents: subEventResponses,
+		CreatedAt: event.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
 // ListEvents 查询事件列表（基于新表结构，支持多语言）
 func (h *HandlerSvc) ListEvents(req *models.ListEventsRequest) (*models.ListEventsResponse, error) {
 	// 验证请求
@@ -168,76 +195,106 @@ func (h *HandlerSvc) ListEvents(req *models.ListEventsRequest) (*models.ListEven
 	// 验证和规范化分页参数
 	page, limit := validatePagination(req.Page, req.Limit)
 
-	repo := database.NewEventRepository()
-	db := h.db.GetGorm()
-
-	// 查询事件列表
-	events, total, err := repo.ListEvents(db, req.LanguageGUID, req.CategoryGUID, req.IsLive, page, limit)
+	// 验证过滤和排序参数
+	filter, err := h.buildEventFilter(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list events: %w", err)
+		return nil, err
 	}
 
-	// 构建响应
-	var eventItems []models.EventListItem
-	for _, event := range events {
-		// 获取事件的多语言信息
-		var eventLang database.EventLanguage
-		if err := db.Where("event_guid = ? AND language_guid = ?", event.GUID, req.LanguageGUID).
-			First(&eventLang).Error; err != nil {
-			// 如果没有找到对应语言，跳过该事件
-			continue
-		}
+	repo := database.NewEventRepository()
+	db := h.db.GetGorm()
 
-		// 获取子事件列表
-		subEvents, err := repo.GetSubEventsByEventGUID(db, event.GUID)
+	// 查询事件列表：传入 cursor 时使用游标分页，否则使用页码分页
+	var (
+		events     []database.Event
+		total      int64
+		nextCursor string
+	)
+	if req.Cursor != nil {
+		cursor, err := decodeEventCursor(*req.Cursor, filter)
+		if err != nil {
+			return nil, err
+		}
+		var hasMore bool
+		events, hasMore, err = repo.ListEventsByCursor(db, filter, cursor, limit)
 		if err != nil {
-			return nil, fmt.Errorf("failed to get sub events for event %s: %w", event.GUID, err)
+			return nil, fmt.Errorf("failed to list events: %w", err)
 		}
+		if hasMore && len(events) > 0 {
+			nextCursor = encodeEventCursor(database.NewEventCursor(filter, events[len(events)-1]))
+		}
+	} else {
+		events, total, err = repo.ListEvents(db, req.LanguageGUID, filter, page, limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list events: %w", err)
+		}
+	}
 
-		// 构建子事件响应
-		var subEventResponses []models.SubEventResponse
-		for _, subEvent := range subEvents {
-			// 获取子事件方向
-			directions, err := repo.GetSubEventDirections(db, subEvent.GUID)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get directions for sub event %s: %w", subEvent.GUID, err)
-			}
+	// 批量加载多语言、子事件和方向，避免逐条查询
+	// 缺少请求语言的事件依次回退到默认语言、任意已有翻译，保证每页条数和总数一致
+	languageGUIDs, err := languagePreference(db, req.LanguageGUID)
+	if err != nil {
+		return nil, err
+	}
+	trees, err := repo.LoadEventTrees(db, events, languageGUIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load event details: %w", err)
+	}
 
-			// 构建方向响应
-			var directionResponses []models.SubEventDirectionResponse
-			for _, dir := range directions {
-				directionResponses = append(directionResponses, models.SubEventDirectionResponse{
-					GUID:        dir.GUID,
-					Direction:   dir.Direction,
-					Chance:      dir.Chance,
-					NewAskPrice: dir.NewAskPrice,
-					NewBidPrice: dir.NewBidPrice,
-				})
-			}
+	// 一次查询加载本页运动类事件的主客队
+	var teamGroupGUIDs []string
+	for _, event := range events {
+		if event.IsSports {
+			teamGroupGUIDs = append(teamGroupGUIDs, event.MainTeamGroupGUID, event.ClusterTeamGroupGUID)
+		}
+	}
+	teams, err := h.loadTeamGroups(db, repo, teamGroupGUIDs, languageGUIDs)
+	if err != nil {
+		return nil, err
+	}
 
-			subEventResponses = append(subEventResponses, models.SubEventResponse{
-				GUID:       subEvent.GUID,
-				Title:      subEvent.Title,
-				Logo:       subEvent.Logo,
-				Directions: directionResponses,
-			})
+	// 构建响应
+	var eventItems []models.EventListItem
+	for _, tree := range trees {
+		// 事件没有任何翻译时标题和规则为空，language_guid 也为空
+		var eventLang database.EventLanguage
+		if tree.Language != nil {
+			eventLang = *tree.Language
 		}
 
-		eventItems = append(eventItems, models.EventListItem{
+		event := tree.Event
+		item := models.EventListItem{
 			GUID:            event.GUID,
 			Title:           eventLang.Title,
 			Rules:           eventLang.Rules,
+			LanguageGUID:    eventLang.LanguageGUID,
 			Logo:            event.Logo,
 			CategoryGUID:    event.CategoryGUID,
 			EcosystemGUID:   event.EcosystemGUID,
 			EventPeriodGUID: event.EventPeriodGUID,
 			IsLive:          event.IsLive,
+			Status:          event.Status,
 			IsSports:        event.IsSports,
 			OpenTime:        event.OpenTime,
+			OpenAt:          timeToUnix(event.OpenAt),
+			StartAt:         timeToUnix(event.StartAt),
+			CloseAt:         timeToUnix(event.CloseAt),
 			TradeVolume:     event.TradeVolume,
-			SubEvents:       subEventResponses,
+			SubEvents:       buildSubEventResponses(tree.SubEvents),
 			CreatedAt:       event.CreatedAt.Format(time.RFC3339),
-		})
+		}
+		if event.IsSports {
+			item.MainTeamGroup = teams[event.MainTeamGroupGUID]
+			item.ClusterTeamGroup = teams[event.ClusterTeamGroupGUID]
+		}
+		eventItems = append(eventItems, item)
+	}
+
+	if req.Cursor != nil {
+		return &models.ListEventsResponse{
+			Events:     eventItems,
+			NextCursor: nextCursor,
+		}, nil
 	}
 
 	// 计算分页信息
@@ -248,7 +305,7 @@ func (h *HandlerSvc) ListEvents(req *models.ListEventsRequest) (*models.ListEven
 
 	response := &models.ListEventsResponse{
 		Events: eventItems,
-		Pagination: models.PaginationInfo{
+		Pagination: &models.PaginationInfo{
 			Page:       page,
 			Limit:      limit,
 			Total:      int(total),
@@ -259,37 +316,535 @@ func (h *HandlerSvc) ListEvents(req *models.ListEventsRequest) (*models.ListEven
 	return response, nil
 }
 
+// GetEventDetail 查询单个事件详情（包含子事件、方向、运动队及分类/生态/时间标签名称）
+func (h *HandlerSvc) GetEventDetail(req *models.GetEventDetailRequest) (*models.EventDetailResponse, error) {
+	if req.GUID == "" {
+		return nil, fmt.Errorf("guid is required")
+	}
+	if req.LanguageGUID == "" {
+		return nil, fmt.Errorf("language_guid is required")
+	}
+
+	repo := database.NewEventRepository()
+	db := h.db.GetGorm()
+
+	// 缺少请求语言时依次回退到默认语言、任意已有翻译
+	languageGUIDs, err := languagePreference(db, req.LanguageGUID)
+	if err != nil {
+		return nil, err
+	}
+
+	event, eventLang, err := repo.GetEventWithLanguage(db, req.GUID, languageGUIDs)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			if event == nil {
+				return nil, fmt.Errorf("%w: %s", ErrEventNotFound, req.GUID)
+			}
+			return nil, fmt.Errorf("%w: %s has no translation", ErrEventNotFound, req.GUID)
+		}
+		return nil, err
+	}
+
+	subEvents, err := repo.GetSubEventsByEventGUID(db, event.GUID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sub events for event %s: %w", event.GUID, err)
+	}
+
+	subEventGUIDs := make([]string, 0, len(subEvents))
+	for _, subEvent := range subEvents {
+		subEventGUIDs = append(subEventGUIDs, subEvent.GUID)
+	}
+	subEventLangs, err := repo.GetSubEventLanguages(db, subEventGUIDs, eventLang.LanguageGUID)
+	if err != nil {
+		return nil, err
+	}
+	subEventTitles := make(map[string]string, len(subEventLangs))
+	for _, subEventLang := range subEventLangs {
+		subEventTitles[subEventLang.SubEventGUID] = subEventLang.Title
+	}
+
+	var subEventResponses []models.SubEventResponse
+	for _, subEvent := range subEvents {
+		directions, err := repo.GetSubEventDirections(db, subEvent.GUID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get directions for sub event %s: %w", subEvent.GUID, err)
+		}
+
+		// 优先使用子事件的多语言标题，缺失时回退到 sub_event.title
+		title := subEvent.Title
+		if localized, ok := subEventTitles[subEvent.GUID]; ok && localized != "" {
+			title = localized
+		}
+
+		subEventResponses = append(subEventResponses, models.SubEventResponse{
+			GUID:       subEvent.GUID,
+			Title:      title,
+			Logo:       subEvent.Logo,
+			Resolution: subEvent.Resolution,
+			EndAt:      timeToUnix(subEvent.EndAt),
+			Liquidity:  subEvent.Liquidity,
+			Directions: buildDirectionResponses(directions),
+		})
+	}
+
+	response := &models.EventDetailResponse{
+		GUID:             event.GUID,
+		Title:            eventLang.Title,
+		Rules:            eventLang.Rules,
+		LanguageGUID:     eventLang.LanguageGUID,
+		Logo:             event.Logo,
+		CategoryGUID:     event.CategoryGUID,
+		EcosystemGUID:    event.EcosystemGUID,
+		EventPeriodGUID:  event.EventPeriodGUID,
+		MainScore:        event.MainScore,
+		ClusterScore:     event.ClusterScore,
+		Stage:            event.Stage,
+		Sport:            event.Sport,
+		OrderType:        event.OrderType,
+		IsOnline:         event.IsOnline,
+		IsLive:           event.IsLive,
+		Status:           event.Status,
+		IsSports:         event.IsSports,
+		OpenTime:         event.OpenTime,
+		OpenAt:           timeToUnix(event.OpenAt),
+		StartAt:          timeToUnix(event.StartAt),
+		CloseAt:          timeToUnix(event.CloseAt),
+		TradeVolume:      event.TradeVolume,
+		ExperimentResult: event.ExperimentResult,
+		SubEvents:        subEventResponses,
+		CreatedAt:        event.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        event.UpdatedAt.Format(time.RFC3339),
+	}
+
+	// 分类、生态、时间标签名称：与标题一样按请求语言、默认语言的顺序选择翻译，都缺少时名称留空
+	response.CategoryName, response.EcosystemName, response.EventPeriodName, err = eventTaxonomyNames(db, event, languageGUIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// 运动类事件附带主客队信息
+	if event.IsSports {
+		teams, err := h.loadTeamGroups(db, repo, []string{event.MainTeamGroupGUID, event.ClusterTeamGroupGUID}, languageGUIDs)
+		if err != nil {
+			return nil, err
+		}
+		response.MainTeamGroup = teams[event.MainTeamGroupGUID]
+		response.ClusterTeamGroup = teams[event.ClusterTeamGroupGUID]
+	}
+
+	return response, nil
+}
+
+// loadTeamGroups 批量加载运动队并按 GUID 建立索引，忽略空值和 "0" 占位符
+// 名称按 languageGUIDs 的顺序选择第一个已有的翻译
+func (h *HandlerSvc) loadTeamGroups(db *gorm.DB, repo database.EventRepository, guids []string, languageGUIDs []string) (map[string]*models.TeamGroupResponse, error) {
+	var teamGroupGUIDs []string
+	for _, guid := range guids {
+		if guid != "" && guid != "0" {
+			teamGroupGUIDs = append(teamGroupGUIDs, guid)
+		}
+	}
+
+	teamGroups, teamGroupLangs, err := repo.GetTeamGroupsWithLanguage(db, teamGroupGUIDs, languageGUIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	names := localizedTeamGroupNames(teamGroupLangs, languageGUIDs)
+
+	teams := make(map[string]*models.TeamGroupResponse, len(teamGroups))
+	for _, teamGroup := range teamGroups {
+		teams[teamGroup.GUID] = &models.TeamGroupResponse{
+			GUID: teamGroup.GUID,
+			Name: names[teamGroup.GUID],
+			Logo: teamGroup.Logo,
+		}
+	}
+	return teams, nil
+}
+
+// createSubEvents 在事务中创建子事件及其方向
+func createSubEvents(db *gorm.DB, repo database.EventRepository, eventGUID, logo string, subEventReqs []models.SubEventRequest) ([]models.SubEventResponse, error) {
+	var subEventResponses []models.SubEventResponse
+	for _, subEventReq := range subEventReqs {
+		// 创建子事件
+		subEvent := &database.SubEvent{
+			ParentEventGUID: eventGUID,
+			Title:           subEventReq.Title,
+			Logo:            logo, // 使用事件的 Logo
+			EndAt:           unixToTime(subEventReq.EndAt),
+			Liquidity:       subEventLiquidity(subEventReq.Liquidity),
+		}
+
+		if err := repo.CreateSubEvent(db, subEvent); err != nil {
+			return nil, fmt.Errorf("failed to create sub event: %w", err)
+		}
+
+		subEventGUID := subEvent.GUID
+
+		// 创建子事件多语言标题
+		for _, languageGUID := range slices.Sorted(maps.Keys(subEventReq.Translations)) {
+			subEventLang := &database.SubEventLanguage{
+				SubEventGUID: subEventGUID,
+				LanguageGUID: languageGUID,
+				Title:        subEventReq.Translations[languageGUID],
+			}
+			if err := repo.CreateSubEventLanguage(db, subEventLang); err != nil {
+				return nil, fmt.Errorf("failed to create sub event language: %w", err)
+			}
+		}
+
+		// 创建子事件方向
+		directions, err := createSubEventDirections(db, repo, subEvent, directionsFromRequest(subEventReq.Directions))
+		if err != nil {
+			return nil, err
+		}
+
+		subEventResponses = append(subEventResponses, models.SubEventResponse{
+			GUID:       subEvent.GUID,
+			Title:      subEvent.Title,
+			Logo:       subEvent.Logo,
+			Resolution: database.SubEventResolutionPending,
+			EndAt:      timeToUnix(subEvent.EndAt),
+			Liquidity:  subEvent.Liquidity,
+			Directions: buildDirectionResponses(directions),
+		})
+	}
+	return subEventResponses, nil
+}
+
+// directionsFromRequest 将方向请求转换为待创建的方向
+func directionsFromRequest(dirReqs []models.SubEventDirectionRequest) []database.SubEventDirection {
+	directions := make([]database.SubEventDirection, 0, len(dirReqs))
+	for _, dirReq := range dirReqs {
+		directions = append(directions, database.SubEventDirection{
+			Direction: dirReq.Direction,
+			Chance:    dirReq.Chance,
+			Info:      database.JSONB{},
+		})
+	}
+	return directions
+}
+
+// createSubEventDirections 在事务中为子事件创建方向，返回子事件定价后的全部方向（新方向在后）
+// 新方向的 Chance 作为做市商的初始概率，创建后所有方向的概率和买卖价由做市商重新计算
+func createSubEventDirections(db *gorm.DB, repo database.EventRepository, subEvent *database.SubEvent, directions []database.SubEventDirection) ([]database.SubEventDirection, error) {
+	market, err := loadSubEventMarket(db, repo, subEvent)
+	if err != nil {
+		return nil, err
+	}
+	market.addDirections(directions)
+	if err := market.reprice(); err != nil {
+		return nil, err
+	}
+	if err := market.save(db, repo); err != nil {
+		return nil, err
+	}
+	return market.directions, nil
+}
+
+// buildDirectionResponses 将方向转换为响应结构
+func buildDirectionResponses(directions []database.SubEventDirection) []models.SubEventDirectionResponse {
+	var directionResponses []models.SubEventDirectionResponse
+	for _, dir := range directions {
+		directionResponses = append(directionResponses, models.SubEventDirectionResponse{
+			GUID:        dir.GUID,
+			Direction:   dir.Direction,
+			Chance:      dir.Chance,
+			NewAskPrice: dir.NewAskPrice,
+			NewBidPrice: dir.NewBidPrice,
+			IsWin:       dir.IsWin,
+		})
+	}
+	return directionResponses
+}
+
+// buildSubEventResponses 将子事件及方向转换为响应结构
+func buildSubEventResponses(subEvents []database.SubEventTree) []models.SubEventResponse {
+	var subEventResponses []models.SubEventResponse
+	for _, subEvent := range subEvents {
+		// 与详情接口一致，优先使用子事件的多语言标题，缺失时回退到 sub_event.title
+		title := subEvent.SubEvent.Title
+		if subEvent.Language != nil && subEvent.Language.Title != "" {
+			title = subEvent.Language.Title
+		}
+		subEventResponses = append(subEventResponses, models.SubEventResponse{
+			GUID:       subEvent.SubEvent.GUID,
+			Title:      title,
+			Logo:       subEvent.SubEvent.Logo,
+			Resolution: subEvent.SubEvent.Resolution,
+			EndAt:      timeToUnix(subEvent.SubEvent.EndAt),
+			Liquidity:  subEvent.SubEvent.Liquidity,
+			Directions: buildDirectionResponses(subEvent.Directions),
+		})
+	}
+	return subEventResponses
+}
+
 // validateCreateEventNewRequest 验证创建事件请求
 func (h *HandlerSvc) validateCreateEventNewRequest(req *models.CreateEventRequest) error {
+	var errs ValidationErrors
 	if req.CategoryGUID == "" {
-		return fmt.Errorf("category_guid is required")
+		errs.add("category_guid", "is required")
 	}
 	if req.EcosystemGUID == "" {
-		return fmt.Errorf("ecosystem_guid is required")
+		errs.add("ecosystem_guid", "is required")
 	}
 	if req.EventPeriodGUID == "" {
-		return fmt.Errorf("event_period_guid is required")
+		errs.add("event_period_guid", "is required")
 	}
-	if req.Title == "" {
-		return fmt.Errorf("title is required")
+	validateSport(&errs, "sport", req.Sport, req.IsSports)
+	if req.IsSports {
+		if isEmptyTeamGroup(req.MainTeamGroupGUID) {
+			errs.add("main_team_group_guid", "is required for sports events")
+		}
+		if isEmptyTeamGroup(req.ClusterTeamGroupGUID) {
+			errs.add("cluster_team_group_guid", "is required for sports events")
+		}
+		if !isEmptyTeamGroup(req.MainTeamGroupGUID) && req.MainTeamGroupGUID == req.ClusterTeamGroupGUID {
+			errs.add("cluster_team_group_guid", "must differ from main_team_group_guid")
+		}
 	}
 	if req.LanguageGUID == "" {
-		return fmt.Errorf("language_guid is required")
+		errs.add("language_guid", "is required")
+		return errs.err()
 	}
 	if len(req.SubEvents) == 0 {
-		return fmt.Errorf("at least one sub_event is required")
+		errs.add("sub_events", "at least one sub_event is required")
+	}
+
+	// 合并 title/rules 与 translations，主语言必须有标题
+	translations, err := mergeEventTranslations(req.LanguageGUID, req.Title, req.Rules, req.Translations)
+	if err != nil {
+		errs.add("translations", "%s", strings.TrimPrefix(err.Error(), ErrInvalidRequest.Error()+": "))
+	} else if primary, ok := translations[req.LanguageGUID]; !ok {
+		errs.add("title", "is required for language %s", req.LanguageGUID)
+	} else {
+		req.Translations = translations
+		req.Title = primary.Title
+		req.Rules = primary.Rules
 	}
 
-	// 验证每个子事件至少有两个方向
+	// 开盘、开始、收盘时间
+	validateUnix(&errs, "open_at", req.OpenAt)
+	validateUnix(&errs, "start_at", req.StartAt)
+	validateUnix(&errs, "close_at", req.CloseAt)
+	closeAt := unixToTime(req.CloseAt)
+	validateEventSchedule(&errs, unixToTime(req.OpenAt), unixToTime(req.StartAt), closeAt)
+
 	for i, subEvent := range req.SubEvents {
+		// 验证每个子事件至少有两个方向
 		if len(subEvent.Directions) < 2 {
-			return fmt.Errorf("sub_event %d must have at least 2 directions", i)
+			errs.add(fmt.Sprintf("sub_events[%d].directions", i), "must have at least 2 directions")
+		}
+		validateUnix(&errs, fmt.Sprintf("sub_events[%d].end_at", i), subEvent.EndAt)
+		validateLiquidity(&errs, fmt.Sprintf("sub_events[%d].liquidity", i), subEvent.Liquidity)
+		if endAt := unixToTime(subEvent.EndAt); endAt != nil && closeAt != nil && endAt.After(*closeAt) {
+			errs.add(fmt.Sprintf("sub_events[%d].end_at", i), "must not be later than close_at")
+		}
+
+		subTranslations, err := mergeSubEventTranslations(req.LanguageGUID, subEvent.Title, subEvent.Translations)
+		if err != nil {
+			errs.add(fmt.Sprintf("sub_events[%d].translations", i), "%v", err)
+			continue
 		}
+		title, ok := subTranslations[req.LanguageGUID]
+		if !ok {
+			errs.add(fmt.Sprintf("sub_events[%d].title", i), "is required for language %s", req.LanguageGUID)
+			continue
+		}
+		req.SubEvents[i].Title = title
+		req.SubEvents[i].Translations = subTranslations
+	}
+
+	return errs.err()
+}
+
+// validateCreateEventReferences 在事务中校验请求引用的分类、生态、时间标签、运动队和语言
+// 被引用的记录需存在且已启用，生态和时间标签需属于所选分类
+func validateCreateEventReferences(db *gorm.DB, req *models.CreateEventRequest) error {
+	var errs ValidationErrors
+	if err := validateEventReferences(db, &errs, req.CategoryGUID, req.EcosystemGUID, req.EventPeriodGUID,
+		req.MainTeamGroupGUID, req.ClusterTeamGroupGUID); err != nil {
+		return err
+	}
+
+	unknown, err := inactiveLanguages(db, createEventLanguageGUIDs(req))
+	if err != nil {
+		return err
+	}
+	for _, languageGUID := range unknown {
+		if _, ok := req.Translations[languageGUID]; ok {
+			errs.add(fmt.Sprintf("translations.%s", languageGUID), "language does not exist or is inactive")
+		}
+		for i, subEvent := range req.SubEvents {
+			if _, ok := subEvent.Translations[languageGUID]; ok {
+				errs.add(fmt.Sprintf("sub_events[%d].translations.%s", i, languageGUID), "language does not exist or is inactive")
+			}
+		}
+	}
+
+	return errs.err()
+}
+
+// validateEventReferences 校验事件引用的分类、生态、时间标签和运动队，创建和修改事件共用
+func validateEventReferences(db *gorm.DB, errs *ValidationErrors, categoryGUID, ecosystemGUID, eventPeriodGUID, mainTeamGroupGUID, clusterTeamGroupGUID string) error {
+	if err := validateEventTaxonomyReferences(db, errs, categoryGUID, ecosystemGUID, eventPeriodGUID); err != nil {
+		return err
+	}
+
+	teamGroupFields := map[string]string{
+		"main_team_group_guid":    mainTeamGroupGUID,
+		"cluster_team_group_guid": clusterTeamGroupGUID,
+	}
+	var teamGroupGUIDs []string
+	for _, teamGroupGUID := range teamGroupFields {
+		if !isEmptyTeamGroup(teamGroupGUID) {
+			teamGroupGUIDs = append(teamGroupGUIDs, teamGroupGUID)
+		}
+	}
+	teamGroups, err := database.NewTaxonomyRepository().GetTeamGroupsByGUIDs(db, teamGroupGUIDs)
+	if err != nil {
+		return err
+	}
+	existing := make(map[string]bool, len(teamGroups))
+	for _, teamGroup := range teamGroups {
+		existing[teamGroup.GUID] = true
+	}
+	for _, field := range slices.Sorted(maps.Keys(teamGroupFields)) {
+		teamGroupGUID := teamGroupFields[field]
+		if !isEmptyTeamGroup(teamGroupGUID) && !existing[teamGroupGUID] {
+			errs.add(field, "team group %s does not exist", teamGroupGUID)
+		}
+	}
+	return nil
+}
+
+// validateEventTaxonomyReferences 校验事件引用的分类、生态和时间标签存在且已启用，生态和时间标签需属于该分类
+func validateEventTaxonomyReferences(db *gorm.DB, errs *ValidationErrors, categoryGUID, ecosystemGUID, eventPeriodGUID string) error {
+	taxonomy := database.NewTaxonomyRepository()
+
+	category, err := taxonomy.GetCategory(db, categoryGUID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case category == nil:
+		errs.add("category_guid", "category %s does not exist", categoryGUID)
+	case !category.IsActive:
+		errs.add("category_guid", "category %s is inactive", categoryGUID)
 	}
 
+	ecosystem, err := taxonomy.GetEcosystem(db, ecosystemGUID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case ecosystem == nil:
+		errs.add("ecosystem_guid", "ecosystem %s does not exist", ecosystemGUID)
+	case !ecosystem.IsActive:
+		errs.add("ecosystem_guid", "ecosystem %s is inactive", ecosystemGUID)
+	case ecosystem.CategoryGUID != categoryGUID:
+		errs.add("ecosystem_guid", "ecosystem %s does not belong to category %s", ecosystemGUID, categoryGUID)
+	}
+
+	eventPeriod, err := taxonomy.GetEventPeriod(db, eventPeriodGUID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case eventPeriod == nil:
+		errs.add("event_period_guid", "event period %s does not exist", eventPeriodGUID)
+	case !eventPeriod.IsActive:
+		errs.add("event_period_guid", "event period %s is inactive", eventPeriodGUID)
+	case eventPeriod.CategoryGUID != categoryGUID:
+		errs.add("event_period_guid", "event period %s does not belong to category %s", eventPeriodGUID, categoryGUID)
+	}
 	return nil
 }
 
+// isEmptyTeamGroup 非运动类事件的运动队 GUID 为空或 "0"
+func isEmptyTeamGroup(teamGroupGUID string) bool {
+	return teamGroupGUID == "" || teamGroupGUID == "0"
+}
+
+// buildEventFilter 验证事件列表的过滤和排序参数并转换为查询条件
+func (h *HandlerSvc) buildEventFilter(req *models.ListEventsRequest) (database.EventFilter, error) {
+	filter := database.EventFilter{
+		CategoryGUID:    req.CategoryGUID,
+		EcosystemGUID:   req.EcosystemGUID,
+		EventPeriodGUID: req.EventPeriodGUID,
+		TeamGroupGUID:   req.TeamGroupGUID,
+		IsLive:          req.IsLive,
+		IsSports:        req.IsSports,
+		IsOnline:        req.IsOnline,
+		OrderType:       req.OrderType,
+		SortBy:          req.SortBy,
+		Order:           h.v.ValidateOrder(req.Order),
+	}
+
+	if filter.SortBy != "" && !database.IsValidEventSortKey(filter.SortBy) {
+		return filter, fmt.Errorf("%w: sort_by must be one of trade_volume, created_at, open_time", ErrInvalidFilter)
+	}
+	if req.IsLive != nil && (*req.IsLive < 0 || *req.IsLive > 2) {
+		return filter, fmt.Errorf("%w: is_live must be 0, 1 or 2", ErrInvalidFilter)
+	}
+	if req.OrderType != nil && (*req.OrderType < 0 || *req.OrderType > 2) {
+		return filter, fmt.Errorf("%w: order_type must be 0, 1 or 2", ErrInvalidFilter)
+	}
+
+	var err error
+	if filter.CreatedFrom, filter.CreatedTo, err = unixRange("created", req.CreatedFrom, req.CreatedTo); err != nil {
+		return filter, err
+	}
+	if filter.OpenTimeFrom, filter.OpenTimeTo, err = unixRange("open_time", req.OpenTimeFrom, req.OpenTimeTo); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+// unixRange 将 Unix 时间戳区间转换为时间，并校验下限不大于上限
+func unixRange(name string, from, to *int64) (*time.Time, *time.Time, error) {
+	var fromTime, toTime *time.Time
+	if from != nil {
+		t := time.Unix(*from, 0).UTC()
+		fromTime = &t
+	}
+	if to != nil {
+		t := time.Unix(*to, 0).UTC()
+		toTime = &t
+	}
+	if fromTime != nil && toTime != nil && fromTime.After(*toTime) {
+		return nil, nil, fmt.Errorf("%w: %s_from must not be later than %s_to", ErrInvalidFilter, name, name)
+	}
+	return fromTime, toTime, nil
+}
+
+// encodeEventCursor 将游标编码为不透明字符串
+func encodeEventCursor(cursor database.EventCursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeEventCursor 解析游标字符串并校验与当前排序条件一致，空字符串表示从第一页开始
+func decodeEventCursor(raw string, filter database.EventFilter) (*database.EventCursor, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	var cursor database.EventCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	if err := cursor.ValidateFor(filter); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCursor, err)
+	}
+	return &cursor, nil
+}
+
 // validatePagination 验证和规范化分页参数
 func validatePagination(page, limit int) (int, int) {
 	// 默认页码为 1
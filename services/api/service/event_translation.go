@@ -0,0 +1,139 @@
+package service
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/database"
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// mergeEventTranslations 合并单语言字段与 translations，返回按语言 GUID 索引的标题与规则
+// 两处都提供同一语言时内容必须一致
+func mergeEventTranslations(languageGUID, title, rules string, translations map[string]models.EventTranslation) (map[string]models.EventTranslation, error) {
+	merged := make(map[string]models.EventTranslation, len(translations)+1)
+	for lang, translation := range translations {
+		if lang == "" {
+			return nil, fmt.Errorf("%w: translations contains an empty language guid", ErrInvalidRequest)
+		}
+		if translation.Title == "" {
+			return nil, fmt.Errorf("%w: translations[%s].title is required", ErrInvalidRequest, lang)
+		}
+		merged[lang] = translation
+	}
+
+	if title != "" {
+		single := models.EventTranslation{Title: title, Rules: rules}
+		if existing, ok := merged[languageGUID]; ok && existing != single {
+			return nil, fmt.Errorf("%w: title/rules conflict with translations[%s]", ErrInvalidRequest, languageGUID)
+		}
+		merged[languageGUID] = single
+	}
+	return merged, nil
+}
+
+// mergeSubEventTranslations 合并子事件的单语言标题与 translations
+func mergeSubEventTranslations(languageGUID, title string, translations map[string]string) (map[string]string, error) {
+	merged := make(map[string]string, len(translations)+1)
+	for lang, translated := range translations {
+		if lang == "" {
+			return nil, fmt.Errorf("translations contains an empty language guid")
+		}
+		if translated == "" {
+			return nil, fmt.Errorf("translations[%s] is empty", lang)
+		}
+		merged[lang] = translated
+	}
+
+	if title != "" && languageGUID != "" {
+		if existing, ok := merged[languageGUID]; ok && existing != title {
+			return nil, fmt.Errorf("title conflicts with translations[%s]", languageGUID)
+		}
+		merged[languageGUID] = title
+	}
+	return merged, nil
+}
+
+// createEventLanguageGUIDs 创建事件请求中用到的全部语言 GUID
+func createEventLanguageGUIDs(req *models.CreateEventRequest) []string {
+	set := make(map[string]bool)
+	for lang := range req.Translations {
+		set[lang] = true
+	}
+	for _, subEvent := range req.SubEvents {
+		for lang := range subEvent.Translations {
+			set[lang] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(set))
+}
+
+// updateEventLanguageGUIDs 更新事件请求中用到的全部语言 GUID
+func updateEventLanguageGUIDs(req *models.UpdateEventRequest) []string {
+	set := make(map[string]bool)
+	for lang := range req.Translations {
+		set[lang] = true
+	}
+	for _, translations := range req.SubEventTranslations {
+		for lang := range translations {
+			set[lang] = true
+		}
+	}
+	for _, subEvent := range req.AddSubEvents {
+		for lang := range subEvent.Translations {
+			set[lang] = true
+		}
+	}
+	return slices.Sorted(maps.Keys(set))
+}
+
+// validateLanguages 校验语言 GUID 均存在于 languages 表且已启用
+func validateLanguages(db *gorm.DB, languageGUIDs []string) error {
+	unknown, err := inactiveLanguages(db, languageGUIDs)
+	if err != nil {
+		return err
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: unknown or inactive languages: %s", ErrInvalidRequest, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// inactiveLanguages 返回不存在或未启用的语言 GUID
+func inactiveLanguages(db *gorm.DB, languageGUIDs []string) ([]string, error) {
+	if len(languageGUIDs) == 0 {
+		return nil, nil
+	}
+	languages, err := database.NewLanguageRepository().GetActiveLanguagesByGUIDs(db, languageGUIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make(map[string]bool, len(languages))
+	for _, language := range languages {
+		active[language.GUID] = true
+	}
+	var unknown []string
+	for _, languageGUID := range languageGUIDs {
+		if !active[languageGUID] {
+			unknown = append(unknown, languageGUID)
+		}
+	}
+	return unknown, nil
+}
+
+// languagePreference 返回选择翻译时的语言优先顺序：请求语言，其次默认语言
+func languagePreference(db *gorm.DB, languageGUID string) ([]string, error) {
+	defaultLanguage, err := database.NewLanguageRepository().GetDefaultLanguage(db)
+	if err != nil {
+		return nil, err
+	}
+	if defaultLanguage == nil || defaultLanguage.GUID == languageGUID {
+		return []string{languageGUID}, nil
+	}
+	return []string{languageGUID, defaultLanguage.GUID}, nil
+}
@@ -0,0 +1,450 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/database"
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// UpdateEvent 更新事件（PUT 全量更新事件字段 / PATCH 只更新提供的字段）
+// 逻辑流程：
+// 1. 验证请求
+// 2. 开启事务，锁定事件并校验状态：已结束、已结算或已取消的事件不能修改，增删子事件或方向只允许在没有持仓的草稿或未开始事件上进行
+// 3. 修改引用时锁定新旧生态并按创建事件的规则校验合并后的引用
+// 4. 以 updated_at 作为乐观锁更新 event 表并刷新 updated_at；修改生态时同步两个生态的事件数
+// 5. 新增或修改 event_language、sub_event_language（语言需已启用）
+// 6. 删除子事件、方向，再新增子事件、方向
+// 7. 校验事件至少保留 1 个子事件、每个子事件至少保留 2 个方向
+// 8. 提交事务
+func (h *HandlerSvc) UpdateEvent(req *models.UpdateEventRequest) (*models.UpdateEventResponse, error) {
+	expectedUpdatedAt, err := h.validateUpdateEventRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var response *models.UpdateEventResponse
+	repo := database.NewEventRepository()
+
+	err = h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		// 先锁定事件，与状态流转互斥；原生态用于调整两个生态的事件数
+		previous, err := repo.LockEvent(db, req.GUID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("%w: %s", ErrEventNotFound, req.GUID)
+			}
+			return err
+		}
+		if err := checkEventEditable(db, repo, previous, isStructuralEventUpdate(req)); err != nil {
+			return err
+		}
+		previousEcosystemGUID := previous.EcosystemGUID
+
+		// 修改引用时先按 GUID 顺序锁定新旧生态，再以合并后的值校验引用，
+		// 避免指向已删除或未启用的记录，也避免与删除生态的引用检查并发
+		if isReferenceEventUpdate(req) {
+			if req.EcosystemGUID != nil {
+				if err := lockEcosystems(db, previousEcosystemGUID, *req.EcosystemGUID); err != nil {
+					return err
+				}
+			}
+			if err := validateUpdateEventReferences(db, previous, req); err != nil {
+				return err
+			}
+		}
+
+		// Step 1: 乐观锁更新事件字段
+		updated, err := repo.UpdateEventWithVersion(db, req.GUID, expectedUpdatedAt, eventUpdateColumns(req))
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return fmt.Errorf("%w: %s (updated_at %s is stale)", ErrEventConflict, req.GUID, req.UpdatedAt)
+		}
+
+		event, err := repo.GetEvent(db, req.GUID)
+		if err != nil {
+			return err
+		}
+		if req.EcosystemGUID != nil {
+			if err := adjustEcosystemEventNums(db, previousEcosystemGUID, event.EcosystemGUID); err != nil {
+				return err
+			}
+		}
+
+		// 运动项目和 is_sports 可能只改了其中一个，按更新后的值校验两者一致
+		if req.Sport != nil || req.IsSports != nil {
+			var errs ValidationErrors
+			validateSport(&errs, "sport", event.Sport, event.IsSports)
+			if err := errs.err(); err != nil {
+				return err
+			}
+		}
+
+		// 调度时间可能只改了一部分，按更新后的值校验先后
+		if req.OpenAt != nil || req.StartAt != nil || req.CloseAt != nil {
+			var errs ValidationErrors
+			validateEventSchedule(&errs, event.OpenAt, event.StartAt, event.CloseAt)
+			if err := errs.err(); err != nil {
+				return err
+			}
+		}
+
+		// Step 2: 多语言标题与规则
+		if err := validateLanguages(db, updateEventLanguageGUIDs(req)); err != nil {
+			return err
+		}
+		for _, languageGUID := range slices.Sorted(maps.Keys(req.Translations)) {
+			translation := req.Translations[languageGUID]
+			if err := repo.UpsertEventLanguage(db, &database.EventLanguage{
+				EventGUID:    event.GUID,
+				LanguageGUID: languageGUID,
+				Title:        translation.Title,
+				Rules:        translation.Rules,
+			}); err != nil {
+				return err
+			}
+		}
+
+		if len(req.SubEventTranslations) > 0 {
+			subEvents, err := repo.GetSubEventsByEventGUID(db, event.GUID)
+			if err != nil {
+				return err
+			}
+			owned := make(map[string]bool, len(subEvents))
+			for _, subEvent := range subEvents {
+				owned[subEvent.GUID] = true
+			}
+			for _, subEventGUID := range slices.Sorted(maps.Keys(req.SubEventTranslations)) {
+				if !owned[subEventGUID] {
+					return fmt.Errorf("%w: sub event %s does not belong to event %s", ErrInvalidRequest, subEventGUID, event.GUID)
+				}
+				translations := req.SubEventTranslations[subEventGUID]
+				for _, languageGUID := range slices.Sorted(maps.Keys(translations)) {
+					if err := repo.UpsertSubEventLanguage(db, &database.SubEventLanguage{
+						SubEventGUID: subEventGUID,
+						LanguageGUID: languageGUID,
+						Title:        translations[languageGUID],
+					}); err != nil {
+						return err
+					}
+				}
+			}
+		}
+
+		// Step 3: 先删除，再新增（允许同名方向被替换）
+		removed, err := repo.DeleteSubEvents(db, event.GUID, req.RemoveSubEventGUIDs)
+		if err != nil {
+			return err
+		}
+		if int(removed) != len(req.RemoveSubEventGUIDs) {
+			return fmt.Errorf("%w: some remove_sub_event_guids do not belong to event %s", ErrInvalidRequest, event.GUID)
+		}
+
+		removed, err = repo.DeleteSubEventDirections(db, event.GUID, req.RemoveDirectionGUIDs)
+		if err != nil {
+			return err
+		}
+		if int(removed) != len(req.RemoveDirectionGUIDs) {
+			return fmt.Errorf("%w: some remove_direction_guids do not belong to event %s", ErrInvalidRequest, event.GUID)
+		}
+
+		if len(req.AddDirections) > 0 {
+			subEvents, err := repo.GetSubEventsByEventGUID(db, event.GUID)
+			if err != nil {
+				return err
+			}
+			owned := make(map[string]*database.SubEvent, len(subEvents))
+			for i := range subEvents {
+				owned[subEvents[i].GUID] = &subEvents[i]
+			}
+			for _, addReq := range req.AddDirections {
+				subEvent, ok := owned[addReq.SubEventGUID]
+				if !ok {
+					return fmt.Errorf("%w: sub event %s does not belong to event %s", ErrInvalidRequest, addReq.SubEventGUID, event.GUID)
+				}
+				if _, err := createSubEventDirections(db, repo, subEvent, directionsFromRequest(addReq.Directions)); err != nil {
+					return err
+				}
+			}
+		}
+
+		if _, err := createSubEvents(db, repo, event.GUID, event.Logo, req.AddSubEvents); err != nil {
+			return err
+		}
+
+		// Step 4: 校验修改后的结构
+		trees, err := repo.LoadEventTrees(db, []database.Event{*event}, nil)
+		if err != nil {
+			return err
+		}
+		subEventTrees := trees[0].SubEvents
+		if len(subEventTrees) == 0 {
+			return fmt.Errorf("%w: event must keep at least one sub_event", ErrInvalidRequest)
+		}
+		for i, subEvent := range subEventTrees {
+			if len(subEvent.Directions) < 2 {
+				return fmt.Errorf("%w: sub_event %s must keep at least 2 directions", ErrInvalidRequest, subEvent.SubEvent.GUID)
+			}
+			// 删除方向后剩余方向的价格需重新归一
+			if len(req.RemoveDirectionGUIDs) > 0 {
+				directions, err := repriceSubEvent(db, repo, &subEventTrees[i].SubEvent)
+				if err != nil {
+					return err
+				}
+				subEventTrees[i].Directions = directions
+			}
+		}
+
+		response = &models.UpdateEventResponse{
+			GUID:      event.GUID,
+			UpdatedAt: event.UpdatedAt.Format(time.RFC3339),
+			SubEvents: buildSubEventResponses(subEventTrees),
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// isStructuralEventUpdate 判断请求是否增删子事件或方向
+func isStructuralEventUpdate(req *models.UpdateEventRequest) bool {
+	return len(req.RemoveSubEventGUIDs) > 0 || len(req.RemoveDirectionGUIDs) > 0 ||
+		len(req.AddSubEvents) > 0 || len(req.AddDirections) > 0
+}
+
+// isReferenceEventUpdate 判断请求是否修改事件引用的分类、生态、时间标签或运动队
+func isReferenceEventUpdate(req *models.UpdateEventRequest) bool {
+	return req.CategoryGUID != nil || req.EcosystemGUID != nil || req.EventPeriodGUID != nil ||
+		req.MainTeamGroupGUID != nil || req.ClusterTeamGroupGUID != nil
+}
+
+// validateUpdateEventReferences 以请求中的值覆盖事件当前的引用后，按创建事件的规则校验引用
+func validateUpdateEventReferences(db *gorm.DB, event *database.Event, req *models.UpdateEventRequest) error {
+	merged := *event
+	if req.CategoryGUID != nil {
+		merged.CategoryGUID = *req.CategoryGUID
+	}
+	if req.EcosystemGUID != nil {
+		merged.EcosystemGUID = *req.EcosystemGUID
+	}
+	if req.EventPeriodGUID != nil {
+		merged.EventPeriodGUID = *req.EventPeriodGUID
+	}
+	if req.MainTeamGroupGUID != nil {
+		merged.MainTeamGroupGUID = *req.MainTeamGroupGUID
+	}
+	if req.ClusterTeamGroupGUID != nil {
+		merged.ClusterTeamGroupGUID = *req.ClusterTeamGroupGUID
+	}
+
+	var errs ValidationErrors
+	if err := validateEventReferences(db, &errs, merged.CategoryGUID, merged.EcosystemGUID, merged.EventPeriodGUID,
+		merged.MainTeamGroupGUID, merged.ClusterTeamGroupGUID); err != nil {
+		return err
+	}
+	return errs.err()
+}
+
+// checkEventEditable 校验事件当前状态是否允许修改
+// 已结束、已结算或已取消的事件不能修改；增删子事件或方向会改变市场结构并重新定价，
+// 只允许在没有持仓的草稿或未开始事件上进行，否则会删除带持仓的方向或在交易中途改价
+func checkEventEditable(db *gorm.DB, repo database.EventRepository, event *database.Event, structural bool) error {
+	switch event.Status {
+	case database.EventStatusDraft, database.EventStatusUpcoming, database.EventStatusLive:
+	default:
+		return fmt.Errorf("%w: cannot edit event %s in status %s", ErrEventConflict, event.GUID, event.Status)
+	}
+	if !structural {
+		return nil
+	}
+	if event.Status == database.EventStatusLive {
+		return fmt.Errorf("%w: cannot change sub events or directions of live event %s", ErrEventConflict, event.GUID)
+	}
+
+	// 锁定全部方向，与下单互斥，检查期间不会产生新的持仓
+	subEvents, err := repo.GetSubEventsByEventGUID(db, event.GUID)
+	if err != nil {
+		return err
+	}
+	for _, subEvent := range subEvents {
+		if _, err := repo.LockSubEventDirections(db, subEvent.GUID); err != nil {
+			return err
+		}
+	}
+	hasPositions, err := database.NewLedgerRepository().HasEventPositions(db, event.GUID)
+	if err != nil {
+		return err
+	}
+	if hasPositions {
+		return fmt.Errorf("%w: cannot change sub events or directions of event %s after trading started", ErrEventConflict, event.GUID)
+	}
+	return nil
+}
+
+// eventUpdateColumns 根据请求中提供的字段生成需要更新的列
+func eventUpdateColumns(req *models.UpdateEventRequest) map[string]interface{} {
+	columns := make(map[string]interface{})
+	if req.CategoryGUID != nil {
+		columns["category_guid"] = *req.CategoryGUID
+	}
+	if req.EcosystemGUID != nil {
+		columns["ecosystem_guid"] = *req.EcosystemGUID
+	}
+	if req.EventPeriodGUID != nil {
+		columns["event_period_guid"] = *req.EventPeriodGUID
+	}
+	if req.MainTeamGroupGUID != nil {
+		columns["main_team_group_guid"] = *req.MainTeamGroupGUID
+	}
+	if req.ClusterTeamGroupGUID != nil {
+		columns["cluster_team_group_guid"] = *req.ClusterTeamGroupGUID
+	}
+	if req.Logo != nil {
+		columns["logo"] = *req.Logo
+	}
+	if req.OrderType != nil {
+		columns["order_type"] = *req.OrderType
+	}
+	if req.OpenTime != nil {
+		columns["open_time"] = *req.OpenTime
+	}
+	if req.IsSports != nil {
+		columns["is_sports"] = *req.IsSports
+	}
+	if req.Sport != nil {
+		columns["sport"] = *req.Sport
+	}
+	// open_at 同时更新 open_time（覆盖请求中的 open_time），保持按 open_time 过滤和排序的结果一致
+	if req.OpenAt != nil {
+		openAt := unixToTime(req.OpenAt)
+		columns["open_at"] = openAt
+		columns["open_time"] = openTimeString(openAt)
+	}
+	if req.StartAt != nil {
+		columns["start_at"] = unixToTime(req.StartAt)
+	}
+	if req.CloseAt != nil {
+		columns["close_at"] = unixToTime(req.CloseAt)
+	}
+	return columns
+}
+
+// validateUpdateEventRequest 验证更新事件请求，返回客户端读取时的 updated_at
+func (h *HandlerSvc) validateUpdateEventRequest(req *models.UpdateEventRequest) (time.Time, error) {
+	if req.GUID == "" {
+		return time.Time{}, fmt.Errorf("%w: guid is required", ErrInvalidRequest)
+	}
+	if req.UpdatedAt == "" {
+		return time.Time{}, fmt.Errorf("%w: updated_at is required", ErrInvalidRequest)
+	}
+	expectedUpdatedAt, err := time.Parse(time.RFC3339, req.UpdatedAt)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: updated_at must be RFC3339: %v", ErrInvalidRequest, err)
+	}
+
+	// PUT 需要提供全部事件字段
+	if req.Replace {
+		if req.CategoryGUID == nil || req.EcosystemGUID == nil || req.EventPeriodGUID == nil ||
+			req.MainTeamGroupGUID == nil || req.ClusterTeamGroupGUID == nil || req.Logo == nil ||
+			req.OrderType == nil || req.OpenTime == nil || req.IsSports == nil {
+			return time.Time{}, fmt.Errorf("%w: PUT requires category_guid, ecosystem_guid, event_period_guid, "+
+				"main_team_group_guid, cluster_team_group_guid, logo, order_type, open_time and is_sports", ErrInvalidRequest)
+		}
+	}
+
+	for name, value := range map[string]*string{
+		"category_guid":     req.CategoryGUID,
+		"ecosystem_guid":    req.EcosystemGUID,
+		"event_period_guid": req.EventPeriodGUID,
+	} {
+		if value != nil && *value == "" {
+			return time.Time{}, fmt.Errorf("%w: %s must not be empty", ErrInvalidRequest, name)
+		}
+	}
+	if req.OrderType != nil && (*req.OrderType < 0 || *req.OrderType > 2) {
+		return time.Time{}, fmt.Errorf("%w: order_type must be 0, 1 or 2", ErrInvalidRequest)
+	}
+	var fieldErrs ValidationErrors
+	if req.Sport != nil {
+		validateSport(&fieldErrs, "sport", *req.Sport, req.IsSports == nil || *req.IsSports)
+	}
+	validateUnix(&fieldErrs, "open_at", req.OpenAt)
+	validateUnix(&fieldErrs, "start_at", req.StartAt)
+	validateUnix(&fieldErrs, "close_at", req.CloseAt)
+	for i, subEvent := range req.AddSubEvents {
+		validateUnix(&fieldErrs, fmt.Sprintf("add_sub_events[%d].end_at", i), subEvent.EndAt)
+		validateLiquidity(&fieldErrs, fmt.Sprintf("add_sub_events[%d].liquidity", i), subEvent.Liquidity)
+	}
+	if err := fieldErrs.err(); err != nil {
+		return time.Time{}, err
+	}
+
+	// languages 与 translations 合并为 translations，同一语言不能重复提供
+	translations, err := mergeEventTranslations("", "", "", req.Translations)
+	if err != nil {
+		return time.Time{}, err
+	}
+	for i, lang := range req.Languages {
+		if lang.LanguageGUID == "" || lang.Title == "" {
+			return time.Time{}, fmt.Errorf("%w: languages[%d] requires language_guid and title", ErrInvalidRequest, i)
+		}
+		if _, ok := translations[lang.LanguageGUID]; ok {
+			return time.Time{}, fmt.Errorf("%w: duplicate language %s", ErrInvalidRequest, lang.LanguageGUID)
+		}
+		translations[lang.LanguageGUID] = models.EventTranslation{Title: lang.Title, Rules: lang.Rules}
+	}
+	req.Translations = translations
+
+	for subEventGUID, subTranslations := range req.SubEventTranslations {
+		if _, err := mergeSubEventTranslations("", "", subTranslations); err != nil {
+			return time.Time{}, fmt.Errorf("%w: sub_event_translations[%s] %v", ErrInvalidRequest, subEventGUID, err)
+		}
+	}
+
+	for i, subEvent := range req.AddSubEvents {
+		if subEvent.Title == "" {
+			return time.Time{}, fmt.Errorf("%w: add_sub_events[%d] title is required", ErrInvalidRequest, i)
+		}
+		if len(subEvent.Directions) < 2 {
+			return time.Time{}, fmt.Errorf("%w: add_sub_events[%d] must have at least 2 directions", ErrInvalidRequest, i)
+		}
+		if _, err := mergeSubEventTranslations("", "", subEvent.Translations); err != nil {
+			return time.Time{}, fmt.Errorf("%w: add_sub_events[%d] %v", ErrInvalidRequest, i, err)
+		}
+	}
+	for i, addReq := range req.AddDirections {
+		if addReq.SubEventGUID == "" || len(addReq.Directions) == 0 {
+			return time.Time{}, fmt.Errorf("%w: add_directions[%d] requires sub_event_guid and directions", ErrInvalidRequest, i)
+		}
+	}
+	if hasDuplicates(req.RemoveSubEventGUIDs) || hasDuplicates(req.RemoveDirectionGUIDs) {
+		return time.Time{}, fmt.Errorf("%w: duplicate guids in remove lists", ErrInvalidRequest)
+	}
+
+	return expectedUpdatedAt, nil
+}
+
+// hasDuplicates 判断字符串切片中是否有重复值
+func hasDuplicates(values []string) bool {
+	seen := make(map[string]bool, len(values))
+	for _, value := range values {
+		if seen[value] {
+			return true
+		}
+		seen[value] = true
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/database"
+)
+
+// 幂等键的接口范围
+const (
+	idempotencyScopeCreateEvent = "create_event"
+	idempotencyScopePlaceOrder  = "place_order"
+	idempotencyScopeWalletLogin = "wallet_login"
+)
+
+// maxIdempotencyKeyLength Idempotency-Key 的最大长度，与 idempotency_key 表字段一致
+const maxIdempotencyKeyLength = 255
+
+// userIdempotencyScope 按用户隔离的幂等键范围，例如 place_order:0xabc...
+// 钱包地址为 42 个字符，加上接口范围不超过 idempotency_key.scope 的 64 个字符
+func userIdempotencyScope(scope, userAddress string) string {
+	return scope + ":" + userAddress
+}
+
+// requestHash 计算请求的 SHA-256，用于识别同一个幂等键被用于不同的请求
+func requestHash(req interface{}) (string, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash request: %w", err)
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// claimIdempotencyKey 在事务中占用幂等键；键已被使用时将首次的响应解码到 response 并返回 true
+// 并发的相同键会阻塞在唯一索引上，直到先占用的事务提交（返回其响应）或回滚（由本次请求占用）
+func claimIdempotencyKey(db *gorm.DB, scope, key, hash string, response interface{}) (bool, error) {
+	claimed, record, err := database.NewIdempotencyRepository().ClaimIdempotencyKey(db, scope, key, hash)
+	if err != nil {
+		return false, err
+	}
+	if claimed {
+		return false, nil
+	}
+	if record.RequestHash != hash {
+		return false, fmt.Errorf("%w: %s", ErrIdempotencyKeyReused, key)
+	}
+	if err := json.Unmarshal([]byte(record.Response), response); err != nil {
+		return false, fmt.Errorf("failed to decode stored response for idempotency key %s: %w", key, err)
+	}
+	return true, nil
+}
+
+// saveIdempotencyResponse 保存首次请求的响应，与业务数据在同一事务中提交
+func saveIdempotencyResponse(db *gorm.DB, scope, key string, response interface{}) error {
+	body, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("failed to encode response for idempotency key %s: %w", key, err)
+	}
+	return database.NewIdempotencyRepository().SaveIdempotencyResponse(db, scope, key, string(body))
+}
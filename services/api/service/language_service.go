@@ -0,0 +1,469 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/database"
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+const (
+	// languageCacheTimeout 语言缓存的有效期，languages 表很少变化
+	languageCacheTimeout = 5 * time.Minute
+	// maxLanguageNameLength 语言代码最大长度，与 languages.language_name 列定义一致
+	maxLanguageNameLength = 20
+	// maxLanguageLabelLength 语言显示名称最大长度
+	maxLanguageLabelLength = 50
+	// maxMissingTranslationGUIDs 缺少翻译报告中每类最多列出的 GUID 数量
+	maxMissingTranslationGUIDs = 100
+)
+
+// languageNamePattern 语言代码格式：主语言 2~3 个字母，可带地区、文字等子标签（如 zh-tw、pt-br）
+var languageNamePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z0-9]{2,8})*$`)
+
+var (
+	// ErrLanguageNotFound 语言不存在
+	ErrLanguageNotFound = errors.New("language not found")
+	// ErrDefaultLanguage 默认语言不能停用或删除，需先将其他语言设为默认语言
+	ErrDefaultLanguage = errors.New("default language cannot be deactivated or deleted")
+)
+
+// languageCache 缓存 language_name → GUID 的映射及默认语言，过期后从 languages 表重新加载
+type languageCache struct {
+	byName      map[string]string
+	defaultGUID string
+	expiresAt   time.Time
+	mutex       sync.RWMutex
+	timeout     time.Duration
+}
+
+func newLanguageCache() *languageCache {
+	return &languageCache{timeout: languageCacheTimeout}
+}
+
+// invalidate 使缓存失效，下次查询时重新加载
+func (c *languageCache) invalidate() {
+	c.mutex.Lock()
+	c.expiresAt = time.Time{}
+	c.mutex.Unlock()
+}
+
+// ResolveLanguage 按顺序将语言标签（如 en-US、zh）匹配到 languages.language_name，返回第一个命中的语言 GUID
+// 都未命中时返回默认语言 GUID，matched 为 false
+func (h *HandlerSvc) ResolveLanguage(tags []string) (string, bool, error) {
+	byName, defaultGUID, err := h.loadLanguages()
+	if err != nil {
+		return "", false, err
+	}
+	for _, tag := range tags {
+		if guid, ok := byName[strings.ToLower(tag)]; ok {
+			return guid, true, nil
+		}
+	}
+	return defaultGUID, false, nil
+}
+
+// loadLanguages 返回缓存的语言映射，过期时从数据库重新加载
+func (h *HandlerSvc) loadLanguages() (map[string]string, string, error) {
+	cache := h.languages
+	cache.mutex.RLock()
+	if time.Now().Before(cache.expiresAt) {
+		byName, defaultGUID := cache.byName, cache.defaultGUID
+		cache.mutex.RUnlock()
+		return byName, defaultGUID, nil
+	}
+	cache.mutex.RUnlock()
+
+	languages, err := database.NewLanguageRepository().ListActiveLanguages(h.db.GetGorm())
+	if err != nil {
+		return nil, "", err
+	}
+
+	byName := make(map[string]string, len(languages))
+	defaultGUID := ""
+	for _, language := range languages {
+		byName[strings.ToLower(language.LanguageName)] = language.GUID
+		if language.IsDefault {
+			defaultGUID = language.GUID
+		}
+	}
+
+	cache.mutex.Lock()
+	cache.byName = byName
+	cache.defaultGUID = defaultGUID
+	cache.expiresAt = time.Now().Add(cache.timeout)
+	cache.mutex.Unlock()
+
+	return byName, defaultGUID, nil
+}
+
+// ListLanguages 查询全部语言（包含停用的语言）
+func (h *HandlerSvc) ListLanguages() (*models.ListLanguagesResponse, error) {
+	languages, err := database.NewLanguageRepository().ListLanguages(h.db.GetGorm())
+	if err != nil {
+		return nil, err
+	}
+	responses := make([]models.LanguageResponse, 0, len(languages))
+	for i := range languages {
+		responses = append(responses, toLanguageResponse(&languages[i]))
+	}
+	return &models.ListLanguagesResponse{Languages: responses}, nil
+}
+
+// CreateLanguage 创建语言，is_default 为 true 时在同一事务中取消原默认语言
+func (h *HandlerSvc) CreateLanguage(req *models.CreateLanguageRequest) (*models.LanguageResponse, error) {
+	languageName := strings.ToLower(strings.TrimSpace(req.LanguageName))
+	isActive := req.IsActive == nil || *req.IsActive
+
+	var errs ValidationErrors
+	switch {
+	case languageName == "":
+		errs.add("language_name", "is required")
+	case len(languageName) > maxLanguageNameLength:
+		errs.add("language_name", "must be at most %d characters", maxLanguageNameLength)
+	case !languageNamePattern.MatchString(languageName):
+		errs.add("language_name", "must be a language tag such as en, zh or zh-tw")
+	}
+	validateLanguageLabel(&errs, req.LanguageLabel)
+	if req.IsDefault && !isActive {
+		errs.add("is_default", "an inactive language cannot be the default language")
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	var response models.LanguageResponse
+	repo := database.NewLanguageRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		existing, err := repo.GetLanguageByName(db, languageName)
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			var errs ValidationErrors
+			if existing.DeletedAt.Valid {
+				errs.add("language_name", "language %s was deleted as %s, restore it instead", languageName, existing.GUID)
+			} else {
+				errs.add("language_name", "language %s already exists as %s", languageName, existing.GUID)
+			}
+			return errs.err()
+		}
+
+		language := &database.Languages{
+			LanguageName:  languageName,
+			LanguageLabel: req.LanguageLabel,
+		}
+		if err := repo.CreateLanguage(db, language); err != nil {
+			return err
+		}
+		// is_active 的数据库默认值为 true，GORM 创建时会忽略零值，停用需单独更新
+		if !isActive {
+			if err := repo.UpdateLanguage(db, language.GUID, map[string]interface{}{"is_active": false}); err != nil {
+				return err
+			}
+		}
+		if req.IsDefault {
+			if err := repo.SetDefaultLanguage(db, language.GUID); err != nil {
+				return err
+			}
+		}
+
+		created, err := loadLanguage(db, repo, language.GUID)
+		if err != nil {
+			return err
+		}
+		response = toLanguageResponse(created)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	h.languages.invalidate()
+	return &response, nil
+}
+
+// UpdateLanguage 更新语言显示名称或设为默认语言
+func (h *HandlerSvc) UpdateLanguage(req *models.UpdateLanguageRequest) (*models.LanguageResponse, error) {
+	if req.GUID == "" {
+		return nil, fmt.Errorf("%w: guid is required", ErrInvalidRequest)
+	}
+
+	var errs ValidationErrors
+	if req.LanguageLabel != nil {
+		validateLanguageLabel(&errs, *req.LanguageLabel)
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	var response models.LanguageResponse
+	repo := database.NewLanguageRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		language, err := repo.LockLanguage(db, req.GUID)
+		if err != nil {
+			return err
+		}
+		if language == nil {
+			return fmt.Errorf("%w: %s", ErrLanguageNotFound, req.GUID)
+		}
+
+		var errs ValidationErrors
+		if req.IsDefault != nil {
+			switch {
+			case *req.IsDefault && !language.IsActive:
+				errs.add("is_default", "an inactive language cannot be the default language")
+			case !*req.IsDefault && language.IsDefault:
+				errs.add("is_default", "set another language as the default language instead")
+			}
+		}
+		if err := errs.err(); err != nil {
+			return err
+		}
+
+		if req.LanguageLabel != nil {
+			if err := repo.UpdateLanguage(db, language.GUID, map[string]interface{}{"language_label": *req.LanguageLabel}); err != nil {
+				return err
+			}
+		}
+		if req.IsDefault != nil && *req.IsDefault && !language.IsDefault {
+			if err := repo.SetDefaultLanguage(db, language.GUID); err != nil {
+				return err
+			}
+		}
+
+		updated, err := loadLanguage(db, repo, language.GUID)
+		if err != nil {
+			return err
+		}
+		response = toLanguageResponse(updated)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	h.languages.invalidate()
+	return &response, nil
+}
+
+// ActivateLanguage 启用语言，并返回仍缺少该语言翻译的事件、分类、运动队
+// 已启用的语言重复启用时只返回报告
+func (h *HandlerSvc) ActivateLanguage(languageGUID string) (*models.ActivateLanguageResponse, error) {
+	var response *models.ActivateLanguageResponse
+	repo := database.NewLanguageRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		language, err := repo.LockLanguage(db, languageGUID)
+		if err != nil {
+			return err
+		}
+		if language == nil {
+			return fmt.Errorf("%w: %s", ErrLanguageNotFound, languageGUID)
+		}
+		if !language.IsActive {
+			if err := repo.UpdateLanguage(db, language.GUID, map[string]interface{}{"is_active": true}); err != nil {
+				return err
+			}
+		}
+
+		activated, err := loadLanguage(db, repo, language.GUID)
+		if err != nil {
+			return err
+		}
+		gaps, err := loadTranslationGaps(db, repo, language.GUID)
+		if err != nil {
+			return err
+		}
+		response = &models.ActivateLanguageResponse{
+			Language:            toLanguageResponse(activated),
+			MissingTranslations: *gaps,
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	h.languages.invalidate()
+	return response, nil
+}
+
+// DeactivateLanguage 停用语言，默认语言不能停用
+func (h *HandlerSvc) DeactivateLanguage(languageGUID string) (*models.LanguageResponse, error) {
+	var response models.LanguageResponse
+	repo := database.NewLanguageRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		language, err := repo.LockLanguage(db, languageGUID)
+		if err != nil {
+			return err
+		}
+		if language == nil {
+			return fmt.Errorf("%w: %s", ErrLanguageNotFound, languageGUID)
+		}
+		if language.IsDefault {
+			return fmt.Errorf("%w: %s is the default language", ErrDefaultLanguage, language.LanguageName)
+		}
+		if language.IsActive {
+			if err := repo.UpdateLanguage(db, language.GUID, map[string]interface{}{"is_active": false}); err != nil {
+				return err
+			}
+		}
+
+		deactivated, err := loadLanguage(db, repo, language.GUID)
+		if err != nil {
+			return err
+		}
+		response = toLanguageResponse(deactivated)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	h.languages.invalidate()
+	return &response, nil
+}
+
+// DeleteLanguage 软删除语言，默认语言不能删除；各表中该语言的翻译保留以便恢复
+func (h *HandlerSvc) DeleteLanguage(languageGUID string) error {
+	repo := database.NewLanguageRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		language, err := repo.LockLanguage(db, languageGUID)
+		if err != nil {
+			return err
+		}
+		if language == nil {
+			return fmt.Errorf("%w: %s", ErrLanguageNotFound, languageGUID)
+		}
+		if language.IsDefault {
+			return fmt.Errorf("%w: %s is the default language", ErrDefaultLanguage, language.LanguageName)
+		}
+		return repo.DeleteLanguage(db, language.GUID)
+	})
+
+	if err != nil {
+		return err
+	}
+
+	h.languages.invalidate()
+	return nil
+}
+
+// RestoreLanguage 恢复已删除的语言，保持删除前的启用状态
+func (h *HandlerSvc) RestoreLanguage(languageGUID string) (*models.LanguageResponse, error) {
+	var response models.LanguageResponse
+	repo := database.NewLanguageRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		language, err := repo.LockDeletedLanguage(db, languageGUID)
+		if err != nil {
+			return err
+		}
+		if language == nil {
+			return fmt.Errorf("%w: no deleted language %s", ErrLanguageNotFound, languageGUID)
+		}
+		if err := repo.RestoreLanguage(db, language.GUID); err != nil {
+			return err
+		}
+
+		restored, err := loadLanguage(db, repo, language.GUID)
+		if err != nil {
+			return err
+		}
+		response = toLanguageResponse(restored)
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	h.languages.invalidate()
+	return &response, nil
+}
+
+// GetMissingTranslations 查询缺少某语言翻译的事件、分类、运动队
+func (h *HandlerSvc) GetMissingTranslations(languageGUID string) (*models.TranslationGapsResponse, error) {
+	db := h.db.GetGorm()
+	repo := database.NewLanguageRepository()
+
+	if _, err := loadLanguage(db, repo, languageGUID); err != nil {
+		return nil, err
+	}
+	return loadTranslationGaps(db, repo, languageGUID)
+}
+
+// validateLanguageLabel 校验语言显示名称长度
+func validateLanguageLabel(errs *ValidationErrors, label string) {
+	if utf8.RuneCountInString(label) > maxLanguageLabelLength {
+		errs.add("language_label", "must be at most %d characters", maxLanguageLabelLength)
+	}
+}
+
+// loadLanguage 加载语言，不存在时返回 ErrLanguageNotFound
+func loadLanguage(db *gorm.DB, repo database.LanguageRepository, languageGUID string) (*database.Languages, error) {
+	language, err := repo.GetLanguage(db, languageGUID)
+	if err != nil {
+		return nil, err
+	}
+	if language == nil {
+		return nil, fmt.Errorf("%w: %s", ErrLanguageNotFound, languageGUID)
+	}
+	return language, nil
+}
+
+// loadTranslationGaps 统计缺少某语言翻译的事件、分类、运动队
+func loadTranslationGaps(db *gorm.DB, repo database.LanguageRepository, languageGUID string) (*models.TranslationGapsResponse, error) {
+	gaps, err := repo.GetTranslationGaps(db, languageGUID, maxMissingTranslationGUIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &models.TranslationGapsResponse{
+		LanguageGUID: languageGUID,
+		Events:       models.MissingTranslationsResponse{Total: gaps.Events.Total, GUIDs: gaps.Events.GUIDs},
+		Categories:   models.MissingTranslationsResponse{Total: gaps.Categories.Total, GUIDs: gaps.Categories.GUIDs},
+		TeamGroups:   models.MissingTranslationsResponse{Total: gaps.TeamGroups.Total, GUIDs: gaps.TeamGroups.GUIDs},
+	}, nil
+}
+
+// toLanguageResponse 将语言记录转换为响应
+func toLanguageResponse(language *database.Languages) models.LanguageResponse {
+	return models.LanguageResponse{
+		GUID:          language.GUID,
+		LanguageName:  language.LanguageName,
+		LanguageLabel: language.LanguageLabel,
+		IsDefault:     language.IsDefault,
+		IsActive:      language.IsActive,
+		CreatedAt:     language.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     language.UpdatedAt.Format(time.RFC3339),
+	}
+}
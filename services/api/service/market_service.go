@@ -0,0 +1,217 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/database"
+	"github.com/multimarket-labs/event-pod-services/pricing"
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// marketPriceDigits 买卖价写入数据库时保留的小数位数
+const marketPriceDigits = 8
+
+// subEventMarket 子事件的 LMSR 做市状态：做市商与加锁读取的各方向份额
+type subEventMarket struct {
+	subEvent   *database.SubEvent
+	maker      *pricing.LMSR
+	directions []database.SubEventDirection
+}
+
+// loadSubEventMarket 在事务中锁定子事件的方向并构造做市状态
+func loadSubEventMarket(db *gorm.DB, repo database.EventRepository, subEvent *database.SubEvent) (*subEventMarket, error) {
+	maker, err := pricing.NewLMSR(subEvent.Liquidity)
+	if err != nil {
+		return nil, fmt.Errorf("sub event %s: %w", subEvent.GUID, err)
+	}
+	directions, err := repo.LockSubEventDirections(db, subEvent.GUID)
+	if err != nil {
+		return nil, err
+	}
+	return &subEventMarket{subEvent: subEvent, maker: maker, directions: directions}, nil
+}
+
+// shares 各方向的份额，顺序与 directions 一致
+func (m *subEventMarket) shares() []float64 {
+	shares := make([]float64, len(m.directions))
+	for i, direction := range m.directions {
+		shares[i] = direction.Shares
+	}
+	return shares
+}
+
+// index 方向在 directions 中的下标
+func (m *subEventMarket) index(directionGUID string) (int, bool) {
+	for i, direction := range m.directions {
+		if direction.GUID == directionGUID {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// addDirections 加入新方向并按其 Chance 确定初始份额
+// 子事件还没有方向时按全部新方向的概率整体初始化，否则新方向依次按各自概率加入、原有方向价格等比例摊薄
+func (m *subEventMarket) addDirections(directions []database.SubEventDirection) {
+	if len(m.directions) == 0 {
+		probabilities := make([]float64, len(directions))
+		for i, direction := range directions {
+			probabilities[i] = float64(direction.Chance)
+		}
+		for i, shares := range m.maker.SeedShares(probabilities) {
+			directions[i].Shares = shares
+		}
+		m.directions = append(m.directions, directions...)
+		return
+	}
+	for _, direction := range directions {
+		direction.Shares = m.maker.EntryShares(m.shares(), float64(direction.Chance)/100)
+		m.directions = append(m.directions, direction)
+	}
+}
+
+// reprice 按当前份额重新计算各方向的概率和买卖价
+func (m *subEventMarket) reprice() error {
+	shares := m.shares()
+	chances := pricing.Chances(m.maker.Prices(shares))
+	for i := range m.directions {
+		ask, bid, err := m.maker.Quote(shares, i, pricing.QuoteUnit)
+		if err != nil {
+			return err
+		}
+		m.directions[i].Chance = chances[i]
+		m.directions[i].NewAskPrice = formatPrice(ask)
+		m.directions[i].NewBidPrice = formatPrice(bid)
+	}
+	return nil
+}
+
+// trade 按 LMSR 成交 amount 份方向 directionGUID（负数为卖出），返回成交金额（卖出为负数）并重新定价
+func (m *subEventMarket) trade(directionGUID string, amount float64) (float64, error) {
+	i, ok := m.index(directionGUID)
+	if !ok {
+		return 0, fmt.Errorf("%w: direction %s does not belong to sub event %s", ErrInvalidRequest, directionGUID, m.subEvent.GUID)
+	}
+	cost, err := m.maker.TradeCost(m.shares(), i, amount)
+	if err != nil {
+		return 0, err
+	}
+	m.directions[i].Shares += amount
+	return cost, m.reprice()
+}
+
+// save 写入各方向的份额、概率和买卖价，尚未创建的方向（GUID 为空）会被创建
+func (m *subEventMarket) save(db *gorm.DB, repo database.EventRepository) error {
+	for i := range m.directions {
+		direction := &m.directions[i]
+		if direction.GUID == "" {
+			direction.SubEventGUID = m.subEvent.GUID
+			if direction.Info == nil {
+				direction.Info = database.JSONB{}
+			}
+			if err := repo.CreateSubEventDirection(db, direction); err != nil {
+				return fmt.Errorf("failed to create sub event direction: %w", err)
+			}
+			continue
+		}
+		if err := repo.UpdateDirectionPricing(db, direction); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// repriceSubEvent 在事务中按当前份额重新计算子事件全部方向的概率和买卖价，返回更新后的方向
+func repriceSubEvent(db *gorm.DB, repo database.EventRepository, subEvent *database.SubEvent) ([]database.SubEventDirection, error) {
+	market, err := loadSubEventMarket(db, repo, subEvent)
+	if err != nil {
+		return nil, err
+	}
+	if err := market.reprice(); err != nil {
+		return nil, err
+	}
+	if err := market.save(db, repo); err != nil {
+		return nil, err
+	}
+	return market.directions, nil
+}
+
+// QuoteSubEventTrade 按子事件当前的做市状态试算一笔交易的成交金额及成交后的价格，不写入数据库
+func (h *HandlerSvc) QuoteSubEventTrade(req *models.MarketQuoteRequest) (*models.MarketQuoteResponse, error) {
+	if req.SubEventGUID == "" || req.DirectionGUID == "" {
+		return nil, fmt.Errorf("%w: sub event guid and direction_guid are required", ErrInvalidRequest)
+	}
+	if req.Amount == 0 || math.IsNaN(req.Amount) || math.IsInf(req.Amount, 0) {
+		return nil, fmt.Errorf("%w: amount must be a non-zero number", ErrInvalidRequest)
+	}
+
+	db := h.db.GetGorm()
+	repo := database.NewEventRepository()
+
+	subEvent, err := repo.GetSubEvent(db, req.SubEventGUID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrSubEventNotFound
+		}
+		return nil, err
+	}
+	maker, err := pricing.NewLMSR(subEvent.Liquidity)
+	if err != nil {
+		return nil, fmt.Errorf("sub event %s: %w", subEvent.GUID, err)
+	}
+	directions, err := repo.GetSubEventDirections(db, subEvent.GUID)
+	if err != nil {
+		return nil, err
+	}
+
+	market := &subEventMarket{subEvent: subEvent, maker: maker, directions: directions}
+	i, ok := market.index(req.DirectionGUID)
+	if !ok {
+		return nil, fmt.Errorf("%w: direction %s does not belong to sub event %s", ErrInvalidRequest, req.DirectionGUID, subEvent.GUID)
+	}
+	priceBefore := maker.Prices(market.shares())[i]
+	cost, err := market.trade(req.DirectionGUID, req.Amount)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MarketQuoteResponse{
+		SubEventGUID:  subEvent.GUID,
+		DirectionGUID: req.DirectionGUID,
+		Amount:        req.Amount,
+		Liquidity:     subEvent.Liquidity,
+		Cost:          cost,
+		AveragePrice:  cost / req.Amount,
+		PriceBefore:   priceBefore,
+		PriceAfter:    maker.Prices(market.shares())[i],
+		Directions:    buildDirectionResponses(market.directions),
+	}, nil
+}
+
+// validateLiquidity 校验子事件的流动性参数，未提供时使用默认值
+func validateLiquidity(errs *ValidationErrors, field string, liquidity *float64) {
+	if liquidity == nil {
+		return
+	}
+	if _, err := pricing.NewLMSR(*liquidity); err != nil {
+		errs.add(field, "must be a positive number")
+	}
+}
+
+// subEventLiquidity 请求中的流动性参数，未提供时为默认值
+func subEventLiquidity(liquidity *float64) float64 {
+	if liquidity == nil {
+		return pricing.DefaultLiquidity
+	}
+	return *liquidity
+}
+
+// formatPrice 将价格格式化为十进制字符串
+func formatPrice(price float64) string {
+	return strconv.FormatFloat(price, 'f', marketPriceDigits, 64)
+}
@@ -0,0 +1,539 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/common/decimal"
+	"github.com/multimarket-labs/event-pod-services/database"
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+var (
+	// ErrMarketClosed 子事件当前不可交易（事件未上线、已收盘或子事件已结算）
+	ErrMarketClosed = errors.New("market is not open for trading")
+	// ErrInsufficientBalance 可用余额不足
+	ErrInsufficientBalance = errors.New("insufficient balance")
+	// ErrInsufficientShares 卖出份额超过持有份额
+	ErrInsufficientShares = errors.New("insufficient shares")
+	// ErrPriceLimitExceeded 成交均价超出限价
+	ErrPriceLimitExceeded = errors.New("price limit exceeded")
+)
+
+const (
+	// amountPlaces 资金金额保留的小数位数：买入支付向上取整，卖出所得向下取整
+	amountPlaces = 6
+	// sharesPlaces 下单份额允许的最大小数位数
+	sharesPlaces = 6
+)
+
+// PlaceOrder 下单：按做市商当前价格买入或卖出方向份额
+// 逻辑流程：
+// 1. 开启事务，带 Idempotency-Key 时占用幂等键，重复请求直接返回首次的响应
+// 2. 校验事件和子事件可交易，锁定子事件的方向（同一子事件的订单串行成交）
+// 3. 按 LMSR 计算成交金额，校验限价，扣减或增加余额
+// 4. 更新持仓、方向价格、子事件及事件成交额，写入订单和成交记录
+func (h *HandlerSvc) PlaceOrder(req *models.PlaceOrderRequest) (*models.PlaceOrderResponse, error) {
+	// 校验会规范化请求，因此先按原始请求计算幂等哈希
+	var hash string
+	if req.IdempotencyKey != "" {
+		if len(req.IdempotencyKey) > maxIdempotencyKeyLength {
+			return nil, fmt.Errorf("%w: Idempotency-Key must be at most %d characters", ErrInvalidRequest, maxIdempotencyKeyLength)
+		}
+		var err error
+		if hash, err = requestHash(req); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := h.validatePlaceOrderRequest(req); err != nil {
+		return nil, err
+	}
+
+	// 幂等键按用户隔离，不同用户使用相同的键互不影响
+	scope := userIdempotencyScope(idempotencyScopePlaceOrder, req.UserAddress)
+
+	var response *models.PlaceOrderResponse
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		if req.IdempotencyKey != "" {
+			replayed, err := claimIdempotencyKey(db, scope, req.IdempotencyKey, hash, &response)
+			if err != nil {
+				return err
+			}
+			if replayed {
+				response.Replayed = true
+				return nil
+			}
+		}
+
+		placed, err := placeOrder(db, req, time.Now())
+		if err != nil {
+			return err
+		}
+		response = placed
+
+		if req.IdempotencyKey != "" {
+			return saveIdempotencyResponse(db, scope, req.IdempotencyKey, response)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// placeOrder 在事务中执行已通过校验的订单
+func placeOrder(db *gorm.DB, req *models.PlaceOrderRequest, now time.Time) (*models.PlaceOrderResponse, error) {
+	eventRepo := database.NewEventRepository()
+	ledger := database.NewLedgerRepository()
+
+	subEvent, err := eventRepo.GetSubEvent(db, req.SubEventGUID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrSubEventNotFound, req.SubEventGUID)
+		}
+		return nil, err
+	}
+	event, err := eventRepo.GetEvent(db, subEvent.ParentEventGUID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrEventNotFound, subEvent.ParentEventGUID)
+		}
+		return nil, err
+	}
+
+	// 锁定方向后重新读取子事件，同一子事件的订单与结算互斥，结算后不会再有订单成交
+	market, err := loadSubEventMarket(db, eventRepo, subEvent)
+	if err != nil {
+		return nil, err
+	}
+	if subEvent, err = eventRepo.GetSubEvent(db, subEvent.GUID); err != nil {
+		return nil, err
+	}
+	if err := checkMarketOpen(event, subEvent, now); err != nil {
+		return nil, err
+	}
+	index, ok := market.index(req.DirectionGUID)
+	if !ok {
+		return nil, fmt.Errorf("%w: direction %s does not belong to sub event %s", ErrInvalidRequest, req.DirectionGUID, subEvent.GUID)
+	}
+
+	position, err := ledger.LockPosition(db, req.UserAddress, req.DirectionGUID)
+	if err != nil {
+		return nil, err
+	}
+	if position == nil {
+		position = &database.Position{
+			UserAddress:   req.UserAddress,
+			EventGUID:     event.GUID,
+			SubEventGUID:  subEvent.GUID,
+			DirectionGUID: req.DirectionGUID,
+			Status:        database.PositionStatusOpen,
+		}
+	}
+
+	// 按 LMSR 成交
+	delta := req.Shares.Float64()
+	if req.Side == database.OrderSideSell {
+		if position.Shares.Cmp(req.Shares) < 0 {
+			return nil, fmt.Errorf("%w: holding %s shares, selling %s", ErrInsufficientShares, position.Shares, req.Shares)
+		}
+		delta = -delta
+	}
+	priceBefore := market.maker.Prices(market.shares())[index]
+	cost, err := market.trade(req.DirectionGUID, delta)
+	if err != nil {
+		return nil, err
+	}
+	priceAfter := market.maker.Prices(market.shares())[index]
+
+	amount, err := decimal.FromFloat(math.Abs(cost))
+	if err != nil {
+		return nil, fmt.Errorf("failed to price order: %w", err)
+	}
+	if req.Side == database.OrderSideBuy {
+		amount = amount.RoundUp(amountPlaces)
+	} else {
+		amount = amount.RoundDown(amountPlaces)
+	}
+	averagePrice := amount.Div(req.Shares)
+	if err := checkLimitPrice(req, averagePrice); err != nil {
+		return nil, err
+	}
+
+	// 资金与持仓
+	var balance *database.UserBalance
+	if req.Side == database.OrderSideBuy {
+		if balance, err = ledger.DebitBalance(db, req.UserAddress, amount); err != nil {
+			return nil, err
+		}
+		if balance == nil {
+			return nil, fmt.Errorf("%w: order costs %s", ErrInsufficientBalance, amount)
+		}
+		position.Shares = position.Shares.Add(req.Shares)
+		position.CostBasis = position.CostBasis.Add(amount)
+	} else {
+		if balance, err = ledger.CreditBalance(db, req.UserAddress, amount); err != nil {
+			return nil, err
+		}
+		// 卖出部分按持仓均价结转成本，全部卖出时成本清零
+		removedCost := position.CostBasis
+		if req.Shares.Cmp(position.Shares) < 0 {
+			removedCost = position.CostBasis.Mul(req.Shares).Div(position.Shares)
+		}
+		position.RealizedPnl = position.RealizedPnl.Add(amount.Sub(removedCost))
+		position.CostBasis = position.CostBasis.Sub(removedCost)
+		position.Shares = position.Shares.Sub(req.Shares)
+	}
+	if err := ledger.SavePosition(db, position); err != nil {
+		return nil, err
+	}
+
+	// 方向价格与成交额
+	if err := market.save(db, eventRepo); err != nil {
+		return nil, err
+	}
+	if err := eventRepo.AddTradeVolume(db, event.GUID, subEvent.GUID, amount); err != nil {
+		return nil, err
+	}
+
+	order := &database.TradeOrder{
+		UserAddress:   req.UserAddress,
+		EventGUID:     event.GUID,
+		SubEventGUID:  subEvent.GUID,
+		DirectionGUID: req.DirectionGUID,
+		Side:          req.Side,
+		Shares:        req.Shares,
+		LimitPrice:    req.LimitPrice,
+		Amount:        amount,
+		AveragePrice:  averagePrice,
+		Status:        database.OrderStatusFilled,
+	}
+	if err := ledger.CreateOrder(db, order); err != nil {
+		return nil, err
+	}
+	fill := &database.TradeFill{
+		OrderGUID:     order.GUID,
+		UserAddress:   req.UserAddress,
+		SubEventGUID:  subEvent.GUID,
+		DirectionGUID: req.DirectionGUID,
+		Side:          req.Side,
+		Shares:        req.Shares,
+		Price:         averagePrice,
+		Amount:        amount,
+		PriceBefore:   priceDecimal(priceBefore),
+		PriceAfter:    priceDecimal(priceAfter),
+	}
+	if err := ledger.CreateFill(db, fill); err != nil {
+		return nil, err
+	}
+
+	return &models.PlaceOrderResponse{
+		Order: buildOrderResponse(order),
+		Fill: models.FillResponse{
+			GUID:        fill.GUID,
+			OrderGUID:   fill.OrderGUID,
+			Shares:      fill.Shares,
+			Price:       fill.Price,
+			Amount:      fill.Amount,
+			PriceBefore: fill.PriceBefore,
+			PriceAfter:  fill.PriceAfter,
+			CreatedAt:   fill.CreatedAt.Format(time.RFC3339),
+		},
+		Position:   buildPositionResponse(position),
+		Balance:    models.BalanceResponse{UserAddress: balance.UserAddress, Available: balance.Available},
+		Directions: buildDirectionResponses(market.directions),
+	}, nil
+}
+
+// checkMarketOpen 事件处于预热或进行中、子事件未结算且未到截止时间时才可交易
+func checkMarketOpen(event *database.Event, subEvent *database.SubEvent, now time.Time) error {
+	if event.Status != database.EventStatusUpcoming && event.Status != database.EventStatusLive {
+		return fmt.Errorf("%w: event %s is %s", ErrMarketClosed, event.GUID, event.Status)
+	}
+	if subEvent.Resolution != database.SubEventResolutionPending {
+		return fmt.Errorf("%w: sub event %s is %s", ErrMarketClosed, subEvent.GUID, subEvent.Resolution)
+	}
+	if event.CloseAt != nil && !now.Before(*event.CloseAt) {
+		return fmt.Errorf("%w: event %s closed at %s", ErrMarketClosed, event.GUID, event.CloseAt.UTC().Format(time.RFC3339))
+	}
+	if subEvent.EndAt != nil && !now.Before(*subEvent.EndAt) {
+		return fmt.Errorf("%w: sub event %s ended at %s", ErrMarketClosed, subEvent.GUID, subEvent.EndAt.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// checkLimitPrice 买入均价不得高于限价，卖出均价不得低于限价
+func checkLimitPrice(req *models.PlaceOrderRequest, averagePrice decimal.Decimal) error {
+	if req.LimitPrice == nil {
+		return nil
+	}
+	if req.Side == database.OrderSideBuy && averagePrice.Cmp(*req.LimitPrice) > 0 {
+		return fmt.Errorf("%w: average price %s is above limit %s", ErrPriceLimitExceeded, averagePrice, req.LimitPrice)
+	}
+	if req.Side == database.OrderSideSell && averagePrice.Cmp(*req.LimitPrice) < 0 {
+		return fmt.Errorf("%w: average price %s is below limit %s", ErrPriceLimitExceeded, averagePrice, req.LimitPrice)
+	}
+	return nil
+}
+
+// settleSubEventPositions 子事件结算后为持仓派彩：胜出方向每份派 1，作废时退还持仓成本
+// 重新结算时按新旧派彩的差额调整余额
+func settleSubEventPositions(db *gorm.DB, ledger database.LedgerRepository, subEventGUID, resolution string, winningDirectionGUIDs []string) error {
+	positions, err := ledger.LockSubEventPositions(db, subEventGUID)
+	if err != nil {
+		return err
+	}
+
+	winners := make(map[string]bool, len(winningDirectionGUIDs))
+	for _, directionGUID := range winningDirectionGUIDs {
+		winners[directionGUID] = true
+	}
+
+	now := time.Now().UTC()
+	for i := range positions {
+		position := &positions[i]
+
+		payout := positionPayout(position, resolution, winners)
+		if delta := payout.Sub(position.Payout); !delta.IsZero() {
+			if _, err := ledger.CreditBalance(db, position.UserAddress, delta); err != nil {
+				return err
+			}
+		}
+		position.Payout = payout
+		position.Status = database.PositionStatusSettled
+		position.SettledAt = &now
+		if err := ledger.SavePosition(db, position); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// positionPayout 计算持仓的结算派彩：作废时退还持仓成本，胜出方向每份派 1，其余为 0
+func positionPayout(position *database.Position, resolution string, winners map[string]bool) decimal.Decimal {
+	switch {
+	case resolution == database.SubEventResolutionVoid:
+		return position.CostBasis
+	case winners[position.DirectionGUID]:
+		return position.Shares
+	}
+	return decimal.Zero()
+}
+
+// CreditBalance 后台为用户入账，并在同一事务中记录入账金额和操作人
+func (h *HandlerSvc) CreditBalance(req *models.CreditBalanceRequest) (*models.CreditBalanceResponse, error) {
+	var errs ValidationErrors
+	if address, err := h.normalizeUserAddress(req.UserAddress); err != nil {
+		errs.add("user_address", "%v", err)
+	} else {
+		req.UserAddress = address
+	}
+	if req.Amount.Sign() <= 0 {
+		errs.add("amount", "must be positive")
+	}
+	if req.Operator == "" {
+		errs.add("operator", "is required")
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	var response *models.CreditBalanceResponse
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+		ledger := database.NewLedgerRepository()
+
+		balance, err := ledger.CreditBalance(db, req.UserAddress, req.Amount)
+		if err != nil {
+			return err
+		}
+		credit := &database.BalanceCredit{
+			UserAddress: req.UserAddress,
+			Amount:      req.Amount,
+			Operator:    req.Operator,
+		}
+		if err := ledger.CreateBalanceCredit(db, credit); err != nil {
+			return err
+		}
+
+		response = &models.CreditBalanceResponse{
+			CreditGUID:  credit.GUID,
+			UserAddress: balance.UserAddress,
+			Amount:      credit.Amount,
+			Operator:    credit.Operator,
+			Available:   balance.Available,
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// ListOrders 按时间倒序分页查询用户的订单
+func (h *HandlerSvc) ListOrders(req *models.ListOrdersRequest) (*models.ListOrdersResponse, error) {
+	address, err := h.normalizeUserAddress(req.UserAddress)
+	if err != nil {
+		return nil, fmt.Errorf("%w: user_address %v", ErrInvalidRequest, err)
+	}
+	page, limit := validatePagination(req.Page, req.Limit)
+
+	orders, total, err := database.NewLedgerRepository().ListOrders(h.db.GetGorm(), address, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]models.OrderResponse, 0, len(orders))
+	for i := range orders {
+		responses = append(responses, buildOrderResponse(&orders[i]))
+	}
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+	return &models.ListOrdersResponse{
+		Orders: responses,
+		Pagination: &models.PaginationInfo{
+			Page:       page,
+			Limit:      limit,
+			Total:      int(total),
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+// ListPositions 查询用户的持仓及可用余额
+func (h *HandlerSvc) ListPositions(req *models.ListPositionsRequest) (*models.ListPositionsResponse, error) {
+	address, err := h.normalizeUserAddress(req.UserAddress)
+	if err != nil {
+		return nil, fmt.Errorf("%w: user_address %v", ErrInvalidRequest, err)
+	}
+	if req.Status != "" && req.Status != database.PositionStatusOpen && req.Status != database.PositionStatusSettled {
+		return nil, fmt.Errorf("%w: status must be open or settled", ErrInvalidRequest)
+	}
+
+	db := h.db.GetGorm()
+	ledger := database.NewLedgerRepository()
+
+	positions, err := ledger.ListPositions(db, address, req.Status)
+	if err != nil {
+		return nil, err
+	}
+	balance, err := ledger.GetBalance(db, address)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &models.ListPositionsResponse{
+		UserAddress: address,
+		Positions:   make([]models.PositionResponse, 0, len(positions)),
+	}
+	if balance != nil {
+		response.Balance = balance.Available
+	}
+	for i := range positions {
+		response.Positions = append(response.Positions, buildPositionResponse(&positions[i]))
+	}
+	return response, nil
+}
+
+// validatePlaceOrderRequest 校验下单请求，并将钱包地址规范化为小写
+func (h *HandlerSvc) validatePlaceOrderRequest(req *models.PlaceOrderRequest) error {
+	var errs ValidationErrors
+
+	if address, err := h.normalizeUserAddress(req.UserAddress); err != nil {
+		errs.add("user_address", "%v", err)
+	} else {
+		req.UserAddress = address
+	}
+	if req.SubEventGUID == "" {
+		errs.add("sub_event_guid", "is required")
+	}
+	if req.DirectionGUID == "" {
+		errs.add("direction_guid", "is required")
+	}
+	if req.Side != database.OrderSideBuy && req.Side != database.OrderSideSell {
+		errs.add("side", "must be buy or sell")
+	}
+	if req.Shares.Sign() <= 0 {
+		errs.add("shares", "must be positive")
+	} else if req.Shares.RoundDown(sharesPlaces).Cmp(req.Shares) != 0 {
+		errs.add("shares", "must have at most %d decimal places", sharesPlaces)
+	}
+	if req.LimitPrice != nil && (req.LimitPrice.Sign() <= 0 || req.LimitPrice.Cmp(decimal.New(1)) > 0) {
+		errs.add("limit_price", "must be greater than 0 and at most 1")
+	}
+
+	return errs.err()
+}
+
+// normalizeUserAddress 校验钱包地址并转换为小写
+func (h *HandlerSvc) normalizeUserAddress(address string) (string, error) {
+	if address == "" {
+		return "", errors.New("is required")
+	}
+	parsed, err := h.v.ParseValidateAddress(address)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Big().Sign() == 0 {
+		return "", errors.New("cannot be the zero address")
+	}
+	return strings.ToLower(parsed.Hex()), nil
+}
+
+// buildOrderResponse 将订单转换为响应结构
+func buildOrderResponse(order *database.TradeOrder) models.OrderResponse {
+	return models.OrderResponse{
+		GUID:          order.GUID,
+		UserAddress:   order.UserAddress,
+		EventGUID:     order.EventGUID,
+		SubEventGUID:  order.SubEventGUID,
+		DirectionGUID: order.DirectionGUID,
+		Side:          order.Side,
+		Shares:        order.Shares,
+		LimitPrice:    order.LimitPrice,
+		Amount:        order.Amount,
+		AveragePrice:  order.AveragePrice,
+		Status:        order.Status,
+		CreatedAt:     order.CreatedAt.Format(time.RFC3339),
+	}
+}
+
+// buildPositionResponse 将持仓转换为响应结构
+func buildPositionResponse(position *database.Position) models.PositionResponse {
+	return models.PositionResponse{
+		GUID:          position.GUID,
+		UserAddress:   position.UserAddress,
+		EventGUID:     position.EventGUID,
+		SubEventGUID:  position.SubEventGUID,
+		DirectionGUID: position.DirectionGUID,
+		Shares:        position.Shares,
+		CostBasis:     position.CostBasis,
+		RealizedPnl:   position.RealizedPnl,
+		Payout:        position.Payout,
+		Status:        position.Status,
+		SettledAt:     timeToUnix(position.SettledAt),
+	}
+}
+
+// priceDecimal 将做市商价格转换为定点小数，保留 marketPriceDigits 位
+func priceDecimal(price float64) decimal.Decimal {
+	d, err := decimal.FromFloat(price)
+	if err != nil {
+		return decimal.Zero()
+	}
+	return d.RoundDown(marketPriceDigits)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/multimarket-labs/event-pod-services/common/decimal"
+	"github.com/multimarket-labs/event-pod-services/database"
+)
+
+func TestPositionPayout(t *testing.T) {
+	position := &database.Position{
+		DirectionGUID: "d1",
+		Shares:        decimal.MustParse("10"),
+		CostBasis:     decimal.MustParse("4.25"),
+	}
+	cases := map[string]struct {
+		resolution string
+		winners    map[string]bool
+		want       string
+	}{
+		"winning direction pays one per share": {resolution: database.SubEventResolutionResolved, winners: map[string]bool{"d1": true}, want: "10"},
+		"losing direction pays nothing":        {resolution: database.SubEventResolutionResolved, winners: map[string]bool{"d2": true}, want: "0"},
+		"one of several winners":               {resolution: database.SubEventResolutionResolved, winners: map[string]bool{"d1": true, "d2": true}, want: "10"},
+		"void refunds cost basis":              {resolution: database.SubEventResolutionVoid, want: "4.25"},
+		"void ignores winners":                 {resolution: database.SubEventResolutionVoid, winners: map[string]bool{"d1": true}, want: "4.25"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			payout := positionPayout(position, tc.resolution, tc.winners)
+			require.Zero(t, payout.Cmp(decimal.MustParse(tc.want)), "got %s", payout)
+		})
+	}
+}
+
+func TestPositionPayoutReResolveDelta(t *testing.T) {
+	// 重新结算时按新旧派彩的差额调整余额：原先胜出改为落败需扣回全部派彩，改为作废则扣回派彩超出成本的部分
+	position := &database.Position{
+		DirectionGUID: "d1",
+		Shares:        decimal.MustParse("10"),
+		CostBasis:     decimal.MustParse("4.25"),
+		Payout:        decimal.MustParse("10"),
+	}
+
+	lost := positionPayout(position, database.SubEventResolutionResolved, map[string]bool{"d2": true})
+	require.Zero(t, lost.Sub(position.Payout).Cmp(decimal.MustParse("-10")))
+
+	voided := positionPayout(position, database.SubEventResolutionVoid, nil)
+	require.Zero(t, voided.Sub(position.Payout).Cmp(decimal.MustParse("-5.75")))
+
+	same := positionPayout(position, database.SubEventResolutionResolved, map[string]bool{"d1": true})
+	require.True(t, same.Sub(position.Payout).IsZero())
+}
@@ -20,9 +20,126 @@ type Service interface {
 
 	// CreateEvent 创建新的预测事件
 	CreateEvent(req *models.CreateEventRequest) (*models.CreateEventResponse, error)
+	// CreateAdminEvent 按后台文档格式（title/sub_events[].question/outcomes）创建事件
+	CreateAdminEvent(req *models.AdminCreateEventRequest) (*models.AdminEventResponse, error)
 
 	// ListEvents 查询事件列表（支持多语言）
 	ListEvents(req *models.ListEventsRequest) (*models.ListEventsResponse, error)
+
+	// GetEventDetail 查询单个事件详情（支持多语言）
+	GetEventDetail(req *models.GetEventDetailRequest) (*models.EventDetailResponse, error)
+
+	// UpdateEvent 更新事件（PUT 全量 / PATCH 部分），基于 updated_at 乐观锁
+	UpdateEvent(req *models.UpdateEventRequest) (*models.UpdateEventResponse, error)
+	// DeleteEvent 软删除草稿事件
+	DeleteEvent(eventGUID string) error
+	// RestoreEvent 恢复已删除的事件
+	RestoreEvent(eventGUID string) (*models.RestoreEventResponse, error)
+
+	// TransitionEvent 流转事件生命周期状态
+	TransitionEvent(req *models.TransitionEventRequest) (*models.EventTransitionResponse, error)
+
+	// ListEventTransitions 查询事件的状态流转记录
+	ListEventTransitions(eventGUID string) (*models.ListEventTransitionsResponse, error)
+
+	// ResolveEvent 结算事件，标记子事件胜出方向
+	ResolveEvent(req *models.ResolveEventRequest) (*models.ResolveEventResponse, error)
+
+	// ImportEvents 批量导入事件，返回每行的结果
+	ImportEvents(req *models.ImportEventsRequest) (*models.ImportEventsResponse, error)
+	// GetChanceHistory 查询子事件各方向在时间窗口内的概率走势
+	GetChanceHistory(req *models.ChanceHistoryRequest) (*models.ChanceHistoryResponse, error)
+	// QuoteSubEventTrade 按做市商当前状态试算子事件方向的交易
+	QuoteSubEventTrade(req *models.MarketQuoteRequest) (*models.MarketQuoteResponse, error)
+	// UpdateEventScore 比分源推送运动类事件的比分和比赛阶段
+	UpdateEventScore(req *models.UpdateEventScoreRequest) (*models.EventScoreResponse, error)
+	// ListEventScoreHistory 查询事件的比分变更记录
+	ListEventScoreHistory(eventGUID string) (*models.ListEventScoreHistoryResponse, error)
+	// IssueWalletNonce 签发钱包登录随机数
+	IssueWalletNonce() (*models.WalletNonceResponse, error)
+	// WalletLogin 校验钱包签名并签发钱包令牌
+	WalletLogin(req *models.WalletLoginRequest) (*models.WalletLoginResponse, error)
+	// VerifyWalletToken 校验钱包令牌，返回钱包地址
+	VerifyWalletToken(token string) (string, error)
+	// PlaceOrder 按做市商当前价格买入或卖出方向份额
+	PlaceOrder(req *models.PlaceOrderRequest) (*models.PlaceOrderResponse, error)
+	// CreditBalance 后台为用户入账
+	CreditBalance(req *models.CreditBalanceRequest) (*models.CreditBalanceResponse, error)
+	// ListOrders 分页查询用户的订单
+	ListOrders(req *models.ListOrdersRequest) (*models.ListOrdersResponse, error)
+	// ListPositions 查询用户的持仓及余额
+	ListPositions(req *models.ListPositionsRequest) (*models.ListPositionsResponse, error)
+	// CreateTeamGroup 创建运动队及其各语言的名称
+	CreateTeamGroup(req *models.CreateTeamGroupRequest) (*models.TeamGroupDetailResponse, error)
+	// GetTeamGroup 查询运动队详情
+	GetTeamGroup(teamGroupGUID string) (*models.TeamGroupDetailResponse, error)
+	// UpdateTeamGroup 更新运动队 Logo 及各语言的名称
+	UpdateTeamGroup(req *models.UpdateTeamGroupRequest) (*models.TeamGroupDetailResponse, error)
+	// DeleteTeamGroup 软删除未被事件引用的运动队
+	DeleteTeamGroup(teamGroupGUID string) error
+	// RestoreTeamGroup 恢复已删除的运动队
+	RestoreTeamGroup(teamGroupGUID string) (*models.TeamGroupDetailResponse, error)
+	// UploadTeamGroupLogo 上传运动队 Logo 到对象存储
+	UploadTeamGroupLogo(ctx context.Context, req *models.UploadTeamGroupLogoRequest) (*models.TeamGroupDetailResponse, error)
+	// ListTeamGroups 分页查询运动队，可按名称搜索
+	ListTeamGroups(req *models.ListTeamGroupsRequest) (*models.ListTeamGroupsResponse, error)
+	// CreateCategory 创建分类及其各语言的名称
+	CreateCategory(req *models.CreateCategoryRequest) (*models.CategoryDetailResponse, error)
+	// GetCategory 查询分类详情
+	GetCategory(categoryGUID string) (*models.CategoryDetailResponse, error)
+	// UpdateCategory 更新分类（移动、启用停用、翻译）
+	UpdateCategory(req *models.UpdateCategoryRequest) (*models.CategoryDetailResponse, error)
+	// DeleteCategory 软删除没有子分类且未被引用的分类
+	DeleteCategory(categoryGUID string) error
+	// RestoreCategory 恢复已删除的分类
+	RestoreCategory(categoryGUID string) (*models.CategoryDetailResponse, error)
+	// ReorderCategories 拖拽排序同级分类
+	ReorderCategories(req *models.ReorderCategoriesRequest) error
+	// ListCategories 查询分类列表或分类树（支持多语言）
+	ListCategories(req *models.ListCategoriesRequest) (*models.ListCategoriesResponse, error)
+	// CreateEcosystem 创建生态及其各语言的名称
+	CreateEcosystem(req *models.CreateEcosystemRequest) (*models.EcosystemDetailResponse, error)
+	// GetEcosystem 查询生态详情
+	GetEcosystem(ecosystemGUID string) (*models.EcosystemDetailResponse, error)
+	// UpdateEcosystem 更新生态字段和翻译
+	UpdateEcosystem(req *models.UpdateEcosystemRequest) (*models.EcosystemDetailResponse, error)
+	// DeleteEcosystem 软删除未被事件引用的生态
+	DeleteEcosystem(ecosystemGUID string) error
+	// RestoreEcosystem 恢复已删除的生态
+	RestoreEcosystem(ecosystemGUID string) (*models.EcosystemDetailResponse, error)
+	// ListEcosystems 查询生态列表（支持多语言）
+	ListEcosystems(req *models.ListEcosystemsRequest) (*models.ListEcosystemsResponse, error)
+	// CreateEventPeriod 创建时间标签及其各语言的名称
+	CreateEventPeriod(req *models.CreateEventPeriodRequest) (*models.EventPeriodDetailResponse, error)
+	// GetEventPeriod 查询时间标签详情
+	GetEventPeriod(eventPeriodGUID string) (*models.EventPeriodDetailResponse, error)
+	// UpdateEventPeriod 更新时间标签字段和翻译
+	UpdateEventPeriod(req *models.UpdateEventPeriodRequest) (*models.EventPeriodDetailResponse, error)
+	// DeleteEventPeriod 软删除未被事件引用的时间标签
+	DeleteEventPeriod(eventPeriodGUID string) error
+	// RestoreEventPeriod 恢复已删除的时间标签
+	RestoreEventPeriod(eventPeriodGUID string) (*models.EventPeriodDetailResponse, error)
+	// ListEventPeriods 查询时间标签列表（支持多语言）
+	ListEventPeriods(req *models.ListEventPeriodsRequest) (*models.ListEventPeriodsResponse, error)
+
+	// ListLanguages 查询全部语言（包含停用的语言）
+	ListLanguages() (*models.ListLanguagesResponse, error)
+	// CreateLanguage 创建语言，可同时设为默认语言
+	CreateLanguage(req *models.CreateLanguageRequest) (*models.LanguageResponse, error)
+	// UpdateLanguage 更新语言显示名称或设为默认语言
+	UpdateLanguage(req *models.UpdateLanguageRequest) (*models.LanguageResponse, error)
+	// ActivateLanguage 启用语言并报告缺少该语言翻译的记录
+	ActivateLanguage(languageGUID string) (*models.ActivateLanguageResponse, error)
+	// DeactivateLanguage 停用非默认语言
+	DeactivateLanguage(languageGUID string) (*models.LanguageResponse, error)
+	// DeleteLanguage 软删除非默认语言
+	DeleteLanguage(languageGUID string) error
+	// RestoreLanguage 恢复已删除的语言
+	RestoreLanguage(languageGUID string) (*models.LanguageResponse, error)
+	// GetMissingTranslations 查询缺少某语言翻译的事件、分类、运动队
+	GetMissingTranslations(languageGUID string) (*models.TranslationGapsResponse, error)
+	// ResolveLanguage 将语言标签匹配到 languages 表，返回语言 GUID；未命中时返回默认语言
+	ResolveLanguage(tags []string) (string, bool, error)
 }
 
 type HandlerSvc struct {
@@ -36,6 +153,7 @@ type HandlerSvc struct {
 	s3Service            *common.S3Service
 	minioService         *common.StorageService
 	jwtSecret            string
+	languages            *languageCache
 }
 
 func New(v *validator.Validator,
@@ -59,5 +177,6 @@ func New(v *validator.Validator,
 		minioService:         minioService,
 		siweVerifier:         common.NewSIWEVerifier(jwtSecret, domain),
 		jwtSecret:            jwtSecret,
+		languages:            newLanguageCache(),
 	}
 }
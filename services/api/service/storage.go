@@ -0,0 +1,67 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/minio/minio-go/v7"
+)
+
+// ErrStorageUnavailable 未配置任何对象存储（S3、Kodo、MinIO）
+var ErrStorageUnavailable = errors.New("object storage is not configured")
+
+// maxImageSize 上传图片的最大字节数
+const maxImageSize = 2 << 20
+
+// imageExtensions 允许上传的图片类型及其扩展名，类型按文件内容识别，不信任客户端声明的 Content-Type
+var imageExtensions = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// detectImage 校验图片大小并按内容识别类型，返回 Content-Type 和扩展名
+func detectImage(data []byte) (string, string, error) {
+	if len(data) == 0 {
+		return "", "", fmt.Errorf("%w: file is empty", ErrInvalidRequest)
+	}
+	if len(data) > maxImageSize {
+		return "", "", fmt.Errorf("%w: file must be at most %d bytes", ErrInvalidRequest, maxImageSize)
+	}
+	contentType := http.DetectContentType(data)
+	ext, ok := imageExtensions[contentType]
+	if !ok {
+		return "", "", fmt.Errorf("%w: unsupported image type %s, expected png, jpeg, gif or webp", ErrInvalidRequest, contentType)
+	}
+	return contentType, ext, nil
+}
+
+// uploadObject 将文件上传到已配置的对象存储（依次尝试 S3、Kodo、MinIO），返回可公开访问的 URL
+// 对象名为 folder/<uuid><ext>，避免覆盖已有文件
+func (h *HandlerSvc) uploadObject(ctx context.Context, folder, ext, contentType string, data []byte) (string, error) {
+	objectName := path.Join(strings.Trim(folder, "/"), uuid.New().String()+ext)
+
+	switch {
+	case h.s3Service != nil:
+		return h.s3Service.UploadFileWithContentType(ctx, data, objectName, contentType)
+	case h.kodoService != nil:
+		return h.kodoService.UploadFile(ctx, data, objectName)
+	case h.minioService != nil:
+		bucket := h.minioService.Config.BucketName
+		_, err := h.minioService.Client.PutObject(ctx, bucket, objectName, bytes.NewReader(data), int64(len(data)),
+			minio.PutObjectOptions{ContentType: contentType})
+		if err != nil {
+			return "", fmt.Errorf("failed to upload file: %w", err)
+		}
+		return h.minioService.GenerateAccessURL(fmt.Sprintf("/%s/%s", bucket, objectName)), nil
+	default:
+		return "", ErrStorageUnavailable
+	}
+}
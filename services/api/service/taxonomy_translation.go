@@ -0,0 +1,171 @@
+package service
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/database"
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// 分类、生态、时间标签的名称、描述、编码、备注长度限制，与各表及其多语言表的列定义一致
+const (
+	maxTaxonomyNameLength        = 50
+	maxTaxonomyDescriptionLength = 200
+	maxTaxonomyCodeLength        = 64
+	maxTaxonomyRemarkLength      = 200
+)
+
+// taxonomyLanguage 分类、生态、时间标签在某语言下的名称和描述
+type taxonomyLanguage struct {
+	OwnerGUID    string // 分类、生态或时间标签的 GUID
+	LanguageGUID string
+	Name         string
+	Description  string
+}
+
+// localizedTaxonomyTranslations 按 languageGUIDs 的顺序为每条记录选择第一个已有的翻译
+func localizedTaxonomyTranslations(rows []taxonomyLanguage, languageGUIDs []string) map[string]models.TaxonomyTranslation {
+	translations := make(map[string]models.TaxonomyTranslation, len(rows))
+	rank := make(map[string]int, len(rows))
+	for _, row := range rows {
+		i := slices.Index(languageGUIDs, row.LanguageGUID)
+		if i < 0 {
+			continue
+		}
+		if current, ok := rank[row.OwnerGUID]; ok && current <= i {
+			continue
+		}
+		rank[row.OwnerGUID] = i
+		translations[row.OwnerGUID] = models.TaxonomyTranslation{Name: row.Name, Description: row.Description}
+	}
+	return translations
+}
+
+// eventTaxonomyNames 获取事件所属分类、生态、时间标签的名称，按 languageGUIDs 的顺序选择第一个已有的翻译
+func eventTaxonomyNames(db *gorm.DB, event *database.Event, languageGUIDs []string) (categoryName, ecosystemName, eventPeriodName string, err error) {
+	var rows []taxonomyLanguage
+
+	categoryLangs, err := database.NewCategoryRepository().GetCategoryLanguages(db, []string{event.CategoryGUID}, languageGUIDs)
+	if err != nil {
+		return "", "", "", err
+	}
+	for _, categoryLang := range categoryLangs {
+		rows = append(rows, taxonomyLanguage{OwnerGUID: categoryLang.CategoryGUID, LanguageGUID: categoryLang.LanguageGUID, Name: categoryLang.Name})
+	}
+
+	ecosystemLangs, err := database.NewEcosystemRepository().GetEcosystemLanguages(db, []string{event.EcosystemGUID}, languageGUIDs)
+	if err != nil {
+		return "", "", "", err
+	}
+	for _, ecosystemLang := range ecosystemLangs {
+		rows = append(rows, taxonomyLanguage{OwnerGUID: ecosystemLang.EcosystemGUID, LanguageGUID: ecosystemLang.LanguageGUID, Name: ecosystemLang.Name})
+	}
+
+	eventPeriodLangs, err := database.NewEventPeriodRepository().GetEventPeriodLanguages(db, []string{event.EventPeriodGUID}, languageGUIDs)
+	if err != nil {
+		return "", "", "", err
+	}
+	for _, eventPeriodLang := range eventPeriodLangs {
+		rows = append(rows, taxonomyLanguage{OwnerGUID: eventPeriodLang.EventPeriodGUID, LanguageGUID: eventPeriodLang.LanguageGUID, Name: eventPeriodLang.Name})
+	}
+
+	// 分类、生态、时间标签的 GUID 各不相同，可以放在同一个索引中
+	translations := localizedTaxonomyTranslations(rows, languageGUIDs)
+	return translations[event.CategoryGUID].Name, translations[event.EcosystemGUID].Name, translations[event.EventPeriodGUID].Name, nil
+}
+
+// validateTaxonomyTranslation 校验某语言下的名称和描述
+func validateTaxonomyTranslation(errs *ValidationErrors, languageGUID string, translation *models.TaxonomyTranslation) {
+	if languageGUID == "" {
+		errs.add("translations", "language guid must not be empty")
+		return
+	}
+	field := fmt.Sprintf("translations.%s", languageGUID)
+	switch {
+	case translation.Name == "":
+		errs.add(field+".name", "must not be empty")
+	case utf8.RuneCountInString(translation.Name) > maxTaxonomyNameLength:
+		errs.add(field+".name", "must be at most %d characters", maxTaxonomyNameLength)
+	}
+	if utf8.RuneCountInString(translation.Description) > maxTaxonomyDescriptionLength {
+		errs.add(field+".description", "must be at most %d characters", maxTaxonomyDescriptionLength)
+	}
+}
+
+// splitTranslationUpdates 校验更新请求中的翻译，拆分为要写入的翻译和要删除的语言（值为 null）
+func splitTranslationUpdates(errs *ValidationErrors, translations map[string]*models.TaxonomyTranslation) (map[string]models.TaxonomyTranslation, []string) {
+	upserts := make(map[string]models.TaxonomyTranslation, len(translations))
+	var removed []string
+	for _, languageGUID := range slices.Sorted(maps.Keys(translations)) {
+		translation := translations[languageGUID]
+		if translation == nil {
+			removed = append(removed, languageGUID)
+			continue
+		}
+		validateTaxonomyTranslation(errs, languageGUID, translation)
+		upserts[languageGUID] = *translation
+	}
+	return upserts, removed
+}
+
+// validateTaxonomyCode 校验业务编码长度
+func validateTaxonomyCode(errs *ValidationErrors, code string) {
+	if len(code) > maxTaxonomyCodeLength {
+		errs.add("code", "must be at most %d characters", maxTaxonomyCodeLength)
+	}
+}
+
+// validateTaxonomyRemark 校验运营备注长度
+func validateTaxonomyRemark(errs *ValidationErrors, remark string) {
+	if utf8.RuneCountInString(remark) > maxTaxonomyRemarkLength {
+		errs.add("remark", "must be at most %d characters", maxTaxonomyRemarkLength)
+	}
+}
+
+// checkTranslationLanguages 校验翻译使用的语言存在且已启用
+func checkTranslationLanguages(db *gorm.DB, languageGUIDs []string) error {
+	slices.Sort(languageGUIDs)
+	unknown, err := inactiveLanguages(db, languageGUIDs)
+	if err != nil {
+		return err
+	}
+	var errs ValidationErrors
+	for _, languageGUID := range unknown {
+		errs.add(fmt.Sprintf("translations.%s", languageGUID), "language does not exist or is inactive")
+	}
+	return errs.err()
+}
+
+// checkTaxonomyCategory 校验生态、时间标签所属的分类存在（加共享锁，防止分类在提交前被删除）
+func checkTaxonomyCategory(db *gorm.DB, categoryGUID string) error {
+	category, err := database.NewTaxonomyRepository().GetCategory(db, categoryGUID)
+	if err != nil {
+		return err
+	}
+	var errs ValidationErrors
+	if category == nil {
+		errs.add("category_guid", "category %s does not exist", categoryGUID)
+	}
+	return errs.err()
+}
+
+// optionalString 空字符串映射为 NULL
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// derefString 返回指针指向的字符串，nil 时为空字符串
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
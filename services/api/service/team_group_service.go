@@ -0,0 +1,422 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+
+	"github.com/multimarket-labs/event-pod-services/database"
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+var (
+	// ErrTeamGroupNotFound 运动队不存在
+	ErrTeamGroupNotFound = errors.New("team group not found")
+	// ErrTeamGroupInUse 运动队仍被事件引用，不能删除
+	ErrTeamGroupInUse = errors.New("team group is referenced by events")
+)
+
+const (
+	// maxTeamGroupNameLength 运动队名称的最大长度（字符），与 team_group_language.name 一致
+	maxTeamGroupNameLength = 255
+	// maxTeamGroupLogoLength Logo URL 的最大长度，与 team_group.logo 一致
+	maxTeamGroupLogoLength = 255
+	// teamGroupLogoFolder 运动队 Logo 在对象存储中的目录
+	teamGroupLogoFolder = "team-groups"
+)
+
+// CreateTeamGroup 创建运动队及其各语言的名称
+func (h *HandlerSvc) CreateTeamGroup(req *models.CreateTeamGroupRequest) (*models.TeamGroupDetailResponse, error) {
+	var errs ValidationErrors
+	validateTeamGroupLogo(&errs, req.Logo)
+	if len(req.Names) == 0 {
+		errs.add("names", "at least one language is required")
+	}
+	validateTeamGroupNames(&errs, req.Names, false)
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	var response *models.TeamGroupDetailResponse
+	repo := database.NewTeamGroupRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		if err := checkTeamGroupLanguages(db, req.Names); err != nil {
+			return err
+		}
+
+		teamGroup := &database.TeamGroup{Logo: req.Logo}
+		if err := repo.CreateTeamGroup(db, teamGroup); err != nil {
+			return err
+		}
+		for languageGUID, name := range req.Names {
+			teamGroupLang := &database.TeamGroupLanguage{
+				TeamGroupGUID: teamGroup.GUID,
+				LanguageGUID:  languageGUID,
+				Name:          name,
+			}
+			if err := repo.UpsertTeamGroupLanguage(db, teamGroupLang); err != nil {
+				return err
+			}
+		}
+
+		detail, err := loadTeamGroupDetail(db, repo, teamGroup.GUID)
+		if err != nil {
+			return err
+		}
+		response = detail
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// GetTeamGroup 查询运动队详情（包含全部语言的名称）
+func (h *HandlerSvc) GetTeamGroup(teamGroupGUID string) (*models.TeamGroupDetailResponse, error) {
+	return loadTeamGroupDetail(h.db.GetGorm(), database.NewTeamGroupRepository(), teamGroupGUID)
+}
+
+// UpdateTeamGroup 更新运动队 Logo 及各语言的名称，名称为空字符串时删除该语言，至少保留一种语言
+func (h *HandlerSvc) UpdateTeamGroup(req *models.UpdateTeamGroupRequest) (*models.TeamGroupDetailResponse, error) {
+	if req.GUID == "" {
+		return nil, fmt.Errorf("%w: guid is required", ErrInvalidRequest)
+	}
+
+	var errs ValidationErrors
+	if req.Logo != nil {
+		validateTeamGroupLogo(&errs, *req.Logo)
+	}
+	validateTeamGroupNames(&errs, req.Names, true)
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	var response *models.TeamGroupDetailResponse
+	repo := database.NewTeamGroupRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		teamGroup, err := repo.LockTeamGroup(db, req.GUID)
+		if err != nil {
+			return err
+		}
+		if teamGroup == nil {
+			return fmt.Errorf("%w: %s", ErrTeamGroupNotFound, req.GUID)
+		}
+
+		upserts := make(map[string]string, len(req.Names))
+		var removed []string
+		for languageGUID, name := range req.Names {
+			if name == "" {
+				removed = append(removed, languageGUID)
+			} else {
+				upserts[languageGUID] = name
+			}
+		}
+		if err := checkTeamGroupLanguages(db, upserts); err != nil {
+			return err
+		}
+
+		if err := repo.DeleteTeamGroupLanguages(db, teamGroup.GUID, removed); err != nil {
+			return err
+		}
+		for languageGUID, name := range upserts {
+			teamGroupLang := &database.TeamGroupLanguage{
+				TeamGroupGUID: teamGroup.GUID,
+				LanguageGUID:  languageGUID,
+				Name:          name,
+			}
+			if err := repo.UpsertTeamGroupLanguage(db, teamGroupLang); err != nil {
+				return err
+			}
+		}
+		if req.Logo != nil || len(req.Names) > 0 {
+			logo := teamGroup.Logo
+			if req.Logo != nil {
+				logo = *req.Logo
+			}
+			if err := repo.UpdateTeamGroup(db, teamGroup.GUID, logo); err != nil {
+				return err
+			}
+		}
+
+		detail, err := loadTeamGroupDetail(db, repo, teamGroup.GUID)
+		if err != nil {
+			return err
+		}
+		if len(detail.Names) == 0 {
+			return fmt.Errorf("%w: team group must keep at least one name", ErrInvalidRequest)
+		}
+		response = detail
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// DeleteTeamGroup 软删除运动队，仍被事件引用时拒绝删除；名称保留以便恢复
+func (h *HandlerSvc) DeleteTeamGroup(teamGroupGUID string) error {
+	repo := database.NewTeamGroupRepository()
+
+	return h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		teamGroup, err := repo.LockTeamGroup(db, teamGroupGUID)
+		if err != nil {
+			return err
+		}
+		if teamGroup == nil {
+			return fmt.Errorf("%w: %s", ErrTeamGroupNotFound, teamGroupGUID)
+		}
+
+		count, err := repo.CountTeamGroupEvents(db, teamGroup.GUID)
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return fmt.Errorf("%w: %s is used by %d events", ErrTeamGroupInUse, teamGroup.GUID, count)
+		}
+
+		return repo.DeleteTeamGroup(db, teamGroup.GUID)
+	})
+}
+
+// RestoreTeamGroup 恢复已删除的运动队及其名称
+func (h *HandlerSvc) RestoreTeamGroup(teamGroupGUID string) (*models.TeamGroupDetailResponse, error) {
+	var response *models.TeamGroupDetailResponse
+	repo := database.NewTeamGroupRepository()
+
+	err := h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		teamGroup, err := repo.LockDeletedTeamGroup(db, teamGroupGUID)
+		if err != nil {
+			return err
+		}
+		if teamGroup == nil {
+			return fmt.Errorf("%w: no deleted team group %s", ErrTeamGroupNotFound, teamGroupGUID)
+		}
+		if err := repo.RestoreTeamGroup(db, teamGroup.GUID); err != nil {
+			return err
+		}
+
+		response, err = loadTeamGroupDetail(db, repo, teamGroup.GUID)
+		return err
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// UploadTeamGroupLogo 上传运动队 Logo 到对象存储并更新 logo 字段
+func (h *HandlerSvc) UploadTeamGroupLogo(ctx context.Context, req *models.UploadTeamGroupLogoRequest) (*models.TeamGroupDetailResponse, error) {
+	if req.GUID == "" {
+		return nil, fmt.Errorf("%w: guid is required", ErrInvalidRequest)
+	}
+	contentType, ext, err := detectImage(req.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := database.NewTeamGroupRepository()
+	teamGroup, err := repo.GetTeamGroup(h.db.GetGorm(), req.GUID)
+	if err != nil {
+		return nil, err
+	}
+	if teamGroup == nil {
+		return nil, fmt.Errorf("%w: %s", ErrTeamGroupNotFound, req.GUID)
+	}
+
+	// 先上传再写库，上传失败时不修改 logo
+	logo, err := h.uploadObject(ctx, teamGroupLogoFolder+"/"+teamGroup.GUID, ext, contentType, req.Data)
+	if err != nil {
+		return nil, err
+	}
+	if len(logo) > maxTeamGroupLogoLength {
+		return nil, fmt.Errorf("uploaded logo url exceeds %d characters: %s", maxTeamGroupLogoLength, logo)
+	}
+
+	var response *models.TeamGroupDetailResponse
+	err = h.db.Transaction(func(txDB *database.DB) error {
+		db := txDB.GetGorm()
+
+		locked, err := repo.LockTeamGroup(db, teamGroup.GUID)
+		if err != nil {
+			return err
+		}
+		if locked == nil {
+			return fmt.Errorf("%w: %s", ErrTeamGroupNotFound, teamGroup.GUID)
+		}
+		if err := repo.UpdateTeamGroup(db, locked.GUID, logo); err != nil {
+			return err
+		}
+
+		detail, err := loadTeamGroupDetail(db, repo, locked.GUID)
+		if err != nil {
+			return err
+		}
+		response = detail
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// ListTeamGroups 分页查询运动队，可按名称搜索，名称按请求语言返回（缺少时回退到默认语言）
+func (h *HandlerSvc) ListTeamGroups(req *models.ListTeamGroupsRequest) (*models.ListTeamGroupsResponse, error) {
+	if req.LanguageGUID == "" {
+		return nil, fmt.Errorf("%w: language_guid is required", ErrInvalidRequest)
+	}
+	page, limit := validatePagination(req.Page, req.Limit)
+
+	db := h.db.GetGorm()
+	repo := database.NewTeamGroupRepository()
+
+	teamGroups, total, err := repo.SearchTeamGroups(db, strings.TrimSpace(req.Query), page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	languageGUIDs, err := languagePreference(db, req.LanguageGUID)
+	if err != nil {
+		return nil, err
+	}
+	teamGroupGUIDs := make([]string, 0, len(teamGroups))
+	for _, teamGroup := range teamGroups {
+		teamGroupGUIDs = append(teamGroupGUIDs, teamGroup.GUID)
+	}
+	teamGroupLangs, err := repo.GetTeamGroupLanguages(db, teamGroupGUIDs)
+	if err != nil {
+		return nil, err
+	}
+	names := localizedTeamGroupNames(teamGroupLangs, languageGUIDs)
+
+	responses := make([]models.TeamGroupResponse, 0, len(teamGroups))
+	for _, teamGroup := range teamGroups {
+		responses = append(responses, models.TeamGroupResponse{
+			GUID: teamGroup.GUID,
+			Name: names[teamGroup.GUID],
+			Logo: teamGroup.Logo,
+		})
+	}
+
+	totalPages := int(total) / limit
+	if int(total)%limit > 0 {
+		totalPages++
+	}
+	return &models.ListTeamGroupsResponse{
+		TeamGroups: responses,
+		Pagination: &models.PaginationInfo{
+			Page:       page,
+			Limit:      limit,
+			Total:      int(total),
+			TotalPages: totalPages,
+		},
+	}, nil
+}
+
+// localizedTeamGroupNames 按 languageGUIDs 的顺序为每个运动队选择第一个已有的名称
+func localizedTeamGroupNames(teamGroupLangs []database.TeamGroupLanguage, languageGUIDs []string) map[string]string {
+	names := make(map[string]string, len(teamGroupLangs))
+	rank := make(map[string]int, len(teamGroupLangs))
+	for _, teamGroupLang := range teamGroupLangs {
+		i := slices.Index(languageGUIDs, teamGroupLang.LanguageGUID)
+		if i < 0 {
+			continue
+		}
+		if current, ok := rank[teamGroupLang.TeamGroupGUID]; ok && current <= i {
+			continue
+		}
+		rank[teamGroupLang.TeamGroupGUID] = i
+		names[teamGroupLang.TeamGroupGUID] = teamGroupLang.Name
+	}
+	return names
+}
+
+// loadTeamGroupDetail 加载运动队及其全部语言的名称
+func loadTeamGroupDetail(db *gorm.DB, repo database.TeamGroupRepository, teamGroupGUID string) (*models.TeamGroupDetailResponse, error) {
+	teamGroup, err := repo.GetTeamGroup(db, teamGroupGUID)
+	if err != nil {
+		return nil, err
+	}
+	if teamGroup == nil {
+		return nil, fmt.Errorf("%w: %s", ErrTeamGroupNotFound, teamGroupGUID)
+	}
+	teamGroupLangs, err := repo.GetTeamGroupLanguages(db, []string{teamGroup.GUID})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]string, len(teamGroupLangs))
+	for _, teamGroupLang := range teamGroupLangs {
+		names[teamGroupLang.LanguageGUID] = teamGroupLang.Name
+	}
+	return &models.TeamGroupDetailResponse{
+		GUID:      teamGroup.GUID,
+		Logo:      teamGroup.Logo,
+		Names:     names,
+		CreatedAt: teamGroup.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: teamGroup.UpdatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+// validateTeamGroupLogo 校验 Logo URL 长度
+func validateTeamGroupLogo(errs *ValidationErrors, logo string) {
+	if len(logo) > maxTeamGroupLogoLength {
+		errs.add("logo", "must be at most %d characters", maxTeamGroupLogoLength)
+	}
+}
+
+// validateTeamGroupNames 校验各语言的名称，allowEmpty 为 true 时空字符串表示删除
+func validateTeamGroupNames(errs *ValidationErrors, names map[string]string, allowEmpty bool) {
+	for _, languageGUID := range slices.Sorted(maps.Keys(names)) {
+		name := names[languageGUID]
+		field := fmt.Sprintf("names.%s", languageGUID)
+		switch {
+		case languageGUID == "":
+			errs.add("names", "language guid must not be empty")
+		case name == "" && !allowEmpty:
+			errs.add(field, "must not be empty")
+		case utf8.RuneCountInString(name) > maxTeamGroupNameLength:
+			errs.add(field, "must be at most %d characters", maxTeamGroupNameLength)
+		}
+	}
+}
+
+// checkTeamGroupLanguages 校验名称使用的语言存在且已启用
+func checkTeamGroupLanguages(db *gorm.DB, names map[string]string) error {
+	unknown, err := inactiveLanguages(db, slices.Sorted(maps.Keys(names)))
+	if err != nil {
+		return err
+	}
+	var errs ValidationErrors
+	for _, languageGUID := range unknown {
+		errs.add(fmt.Sprintf("names.%s", languageGUID), "language does not exist or is inactive")
+	}
+	return errs.err()
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+// ValidationErrors 按字段收集的校验错误，errors.Is(err, ErrInvalidRequest) 成立
+type ValidationErrors []models.FieldError
+
+func (e ValidationErrors) Error() string {
+	parts := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		parts = append(parts, fieldErr.Field+": "+fieldErr.Message)
+	}
+	return ErrInvalidRequest.Error() + ": " + strings.Join(parts, "; ")
+}
+
+func (e ValidationErrors) Unwrap() error {
+	return ErrInvalidRequest
+}
+
+// add 记录一个字段错误
+func (e *ValidationErrors) add(field, format string, args ...interface{}) {
+	*e = append(*e, models.FieldError{Field: field, Message: fmt.Sprintf(format, args...)})
+}
+
+// err 没有字段错误时返回 nil
+func (e ValidationErrors) err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/multimarket-labs/event-pod-services/database"
+	"github.com/multimarket-labs/event-pod-services/services/api/models"
+)
+
+const (
+	// walletNonceTTL 登录随机数的有效期
+	walletNonceTTL = 5 * time.Minute
+	// walletTokenHours 钱包令牌的有效期（小时）
+	walletTokenHours = 24
+	// walletLoginMessagePrefix 登录消息前缀，与 SIWEVerifier 解析的格式一致
+	walletLoginMessagePrefix = "Login to DappLink with nonce: "
+)
+
+// ErrUnauthorized 钱包签名或令牌无效
+var ErrUnauthorized = errors.New("unauthorized")
+
+// IssueWalletNonce 签发登录随机数
+// 随机数为 8 位十六进制签发时间加 8 位十六进制 HMAC，无需存储即可校验是否由本服务在有效期内签发
+func (h *HandlerSvc) IssueWalletNonce() (*models.WalletNonceResponse, error) {
+	if h.jwtSecret == "" {
+		return nil, fmt.Errorf("%w: wallet login is not enabled", ErrUnauthorized)
+	}
+	now := time.Now()
+	nonce := h.walletNonce(now)
+	return &models.WalletNonceResponse{
+		Nonce:     nonce,
+		Message:   walletLoginMessagePrefix + nonce,
+		ExpiresAt: now.Add(walletNonceTTL).Unix(),
+	}, nil
+}
+
+// WalletLogin 校验钱包对登录消息的签名并签发钱包令牌
+// 随机数需由本服务签发且未过期；同一地址的随机数只能登录一次，防止截获的签名被重放
+func (h *HandlerSvc) WalletLogin(req *models.WalletLoginRequest) (*models.WalletLoginResponse, error) {
+	if h.jwtSecret == "" {
+		return nil, fmt.Errorf("%w: wallet login is not enabled", ErrUnauthorized)
+	}
+
+	var errs ValidationErrors
+	if req.Message == "" {
+		errs.add("message", "is required")
+	}
+	if req.Signature == "" {
+		errs.add("signature", "is required")
+	}
+	if err := errs.err(); err != nil {
+		return nil, err
+	}
+
+	nonce := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(req.Message), strings.TrimSpace(walletLoginMessagePrefix)))
+	now := time.Now()
+	if err := h.checkWalletNonce(nonce, now); err != nil {
+		return nil, err
+	}
+	address, err := h.siweVerifier.VerifySignature(req.Message, req.Signature)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrUnauthorized, err)
+	}
+	address = strings.ToLower(address)
+
+	// 占用 地址+随机数，已使用过的随机数不能再次登录
+	signatureHash := sha256.Sum256([]byte(req.Signature))
+	err = h.db.Transaction(func(txDB *database.DB) error {
+		claimed, _, err := database.NewIdempotencyRepository().ClaimIdempotencyKey(txDB.GetGorm(),
+			idempotencyScopeWalletLogin, address+":"+nonce, hex.EncodeToString(signatureHash[:]))
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			return fmt.Errorf("%w: nonce already used", ErrUnauthorized)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := h.siweVerifier.GenerateJWT(address, walletTokenHours)
+	if err != nil {
+		return nil, err
+	}
+	return &models.WalletLoginResponse{
+		Token:       token,
+		UserAddress: address,
+		ExpiresAt:   now.Add(walletTokenHours * time.Hour).Unix(),
+	}, nil
+}
+
+// VerifyWalletToken 校验钱包令牌，返回令牌中的钱包地址（小写）
+func (h *HandlerSvc) VerifyWalletToken(token string) (string, error) {
+	if h.jwtSecret == "" {
+		return "", fmt.Errorf("%w: wallet login is not enabled", ErrUnauthorized)
+	}
+	claims, err := h.siweVerifier.VerifyJWT(token)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrUnauthorized, err)
+	}
+	if claims.ExpiresAt == nil || claims.Address == "" {
+		return "", fmt.Errorf("%w: token has no wallet address or expiry", ErrUnauthorized)
+	}
+	return strings.ToLower(claims.Address), nil
+}
+
+// walletNonce 生成签发时间为 issuedAt 的登录随机数
+func (h *HandlerSvc) walletNonce(issuedAt time.Time) string {
+	var ts [4]byte
+	binary.BigEndian.PutUint32(ts[:], uint32(issuedAt.Unix()))
+	return hex.EncodeToString(ts[:]) + h.walletNonceMAC(ts[:])
+}
+
+// walletNonceMAC 计算签发时间的 HMAC，截取前 4 字节
+func (h *HandlerSvc) walletNonceMAC(ts []byte) string {
+	mac := hmac.New(sha256.New, []byte(h.jwtSecret))
+	mac.Write([]byte("wallet_nonce:"))
+	mac.Write(ts)
+	return hex.EncodeToString(mac.Sum(nil)[:4])
+}
+
+// checkWalletNonce 校验随机数由本服务签发且未过期
+func (h *HandlerSvc) checkWalletNonce(nonce string, now time.Time) error {
+	if len(nonce) != 16 {
+		return fmt.Errorf("%w: invalid nonce", ErrUnauthorized)
+	}
+	ts, err := hex.DecodeString(nonce[:8])
+	if err != nil || !hmac.Equal([]byte(nonce[8:]), []byte(h.walletNonceMAC(ts))) {
+		return fmt.Errorf("%w: invalid nonce", ErrUnauthorized)
+	}
+	issuedAt := time.Unix(int64(binary.BigEndian.Uint32(ts)), 0)
+	if now.Before(issuedAt.Add(-time.Minute)) || now.After(issuedAt.Add(walletNonceTTL)) {
+		return fmt.Errorf("%w: nonce expired", ErrUnauthorized)
+	}
+	return nil
+}